@@ -207,6 +207,26 @@ var createFlags = []cli.Flag{
 		Name:  "group-add",
 		Usage: "Add additional groups to join (default [])",
 	},
+	cli.StringFlag{
+		Name:  "health-cmd",
+		Usage: "Command to run with /bin/sh to check that the container is healthy, or 'none' to disable the image's healthcheck",
+	},
+	cli.StringFlag{
+		Name:  "health-interval",
+		Usage: "Time between healthchecks (default 30s)",
+	},
+	cli.UintFlag{
+		Name:  "health-retries",
+		Usage: "Number of consecutive failed healthchecks needed to consider the container unhealthy (default 3)",
+	},
+	cli.StringFlag{
+		Name:  "health-start-period",
+		Usage: "Time after the container starts during which failed healthchecks are not counted",
+	},
+	cli.StringFlag{
+		Name:  "health-timeout",
+		Usage: "Maximum time a healthcheck may run before it is considered to have failed (default 30s)",
+	},
 	cli.StringFlag{
 		Name:  "hostname",
 		Usage: "Set container hostname",
@@ -220,6 +240,10 @@ var createFlags = []cli.Flag{
 		Name:  "interactive, i",
 		Usage: "Keep STDIN open even if not attached",
 	},
+	cli.StringFlag{
+		Name:  "ip",
+		Usage: "Specify a static IPv4 address for the container",
+	},
 	cli.StringFlag{
 		Name:  "ipc",
 		Usage: "IPC Namespace to use",
@@ -238,7 +262,7 @@ var createFlags = []cli.Flag{
 	},
 	cli.StringFlag{
 		Name:  "log-driver",
-		Usage: "Logging driver for the container",
+		Usage: "Logging driver for the container (k8s-file, journald, none)",
 	},
 	cli.StringSliceFlag{
 		Name:  "log-opt",
@@ -279,6 +303,14 @@ var createFlags = []cli.Flag{
 		Usage: "Connect a container to a network",
 		Value: "bridge",
 	},
+	cli.StringSliceFlag{
+		Name:  "network-alias",
+		Usage: "Add network-scoped alias for the container (default [])",
+	},
+	cli.BoolFlag{
+		Name:  "no-healthcheck",
+		Usage: "Disable any healthcheck specified by the image",
+	},
 	cli.BoolFlag{
 		Name:  "oom-kill-disable",
 		Usage: "Disable OOM Killer",
@@ -319,6 +351,10 @@ var createFlags = []cli.Flag{
 		Name:  "read-only",
 		Usage: "Make containers root filesystem read-only",
 	},
+	cli.StringFlag{
+		Name:  "restart",
+		Usage: "Restart policy to apply when a container exits (no, on-failure[:max-retries], always, unless-stopped)",
+	},
 	cli.BoolFlag{
 		Name:  "rm",
 		Usage: "Remove container (and pod if created) after exit",
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+var (
+	containerSubCommands = []cli.Command{
+		checkpointCommand,
+		restoreCommand,
+	}
+
+	containerDescription = `
+   podman container
+
+   Manage containers.
+`
+	containerCommand = cli.Command{
+		Name:                   "container",
+		Usage:                  "Manage containers",
+		Description:            containerDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            containerSubCommands,
+	}
+)
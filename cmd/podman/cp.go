@@ -0,0 +1,155 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	cpDescription = `
+   podman cp
+
+   Copies files and directories between a container and the local filesystem.
+   One of the source and the destination must be a path in a container, given
+   as CONTAINER:PATH. Use '-' as the local path to read or write a tar archive
+   on STDIN or STDOUT.
+`
+	cpCommand = cli.Command{
+		Name:        "cp",
+		Usage:       "Copy files/folders between a container and the local filesystem",
+		Description: cpDescription,
+		Action:      cpCmd,
+		ArgsUsage:   "[CONTAINER:]SRC_PATH [CONTAINER:]DEST_PATH",
+	}
+)
+
+func cpCmd(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return errors.Errorf("podman cp requires exactly a source and a destination")
+	}
+
+	srcCtr, srcPath := splitCpArg(args[0])
+	dstCtr, dstPath := splitCpArg(args[1])
+	if srcCtr != "" && dstCtr != "" {
+		return errors.Errorf("copying between containers is not supported")
+	}
+	if srcCtr == "" && dstCtr == "" {
+		return errors.Errorf("either the source or the destination must be a path in a container")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if srcCtr != "" {
+		ctr, err := runtime.LookupContainer(srcCtr)
+		if err != nil {
+			return errors.Wrapf(err, "unable to find container %s", srcCtr)
+		}
+		return copyFromContainer(ctr, srcPath, dstPath)
+	}
+
+	ctr, err := runtime.LookupContainer(dstCtr)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find container %s", dstCtr)
+	}
+	return copyToContainer(ctr, srcPath, dstPath)
+}
+
+// splitCpArg splits an argument of podman cp into a container and a path
+// Absolute and explicitly relative paths are always local paths, so that
+// local paths containing a colon can be given
+func splitCpArg(arg string) (string, string) {
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) == 1 {
+		return "", arg
+	}
+	return parts[0], parts[1]
+}
+
+// copyFromContainer copies a path in the container to a local path, or
+// writes it as a tar archive to STDOUT if the local path is '-'
+func copyFromContainer(ctr *libpod.Container, srcPath, dstPath string) error {
+	if dstPath == "-" {
+		if logrus.IsTerminal(os.Stdout) {
+			return errors.Errorf("refusing to write archive to terminal. Redirect STDOUT")
+		}
+		return ctr.CopyFrom(srcPath, os.Stdout)
+	}
+
+	info, err := ctr.StatPath(srcPath)
+	if err != nil {
+		return err
+	}
+	srcInfo := archive.CopyInfo{
+		Path:   srcPath,
+		Exists: true,
+		IsDir:  info.IsDir(),
+	}
+
+	reader, writer := io.Pipe()
+	copyErr := make(chan error, 1)
+	go func() {
+		err := ctr.CopyFrom(srcPath, writer)
+		writer.CloseWithError(err)
+		copyErr <- err
+	}()
+
+	extractErr := archive.CopyTo(reader, srcInfo, dstPath)
+	// Stop copying from the container if extracting failed early
+	reader.Close()
+	if err := <-copyErr; err != nil {
+		return err
+	}
+	return extractErr
+}
+
+// copyToContainer copies a local path to a path in the container, or extracts
+// a tar archive from STDIN into it if the local path is '-'
+func copyToContainer(ctr *libpod.Container, srcPath, dstPath string) error {
+	if srcPath == "-" {
+		return ctr.CopyTo(dstPath, os.Stdin)
+	}
+
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	info, err := ctr.StatPath(dstPath)
+	if err == nil {
+		dstInfo.Exists = true
+		dstInfo.IsDir = info.IsDir()
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return err
+	}
+
+	srcInfo, err := archive.CopyInfoSourcePath(srcPath, false)
+	if err != nil {
+		return errors.Wrapf(err, "error reading %q", srcPath)
+	}
+	srcArchive, err := archive.TarResource(srcInfo)
+	if err != nil {
+		return errors.Wrapf(err, "error archiving %q", srcPath)
+	}
+	defer srcArchive.Close()
+
+	dstDir, content, err := archive.PrepareArchiveCopy(srcArchive, srcInfo, dstInfo)
+	if err != nil {
+		return errors.Wrapf(err, "error copying %q to %q", srcPath, dstPath)
+	}
+	defer content.Close()
+
+	return ctr.CopyTo(dstDir, content)
+}
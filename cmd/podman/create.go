@@ -97,16 +97,36 @@ func createCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	ctr, err := createContainerFromCreateConfig(ctx, runtime, createConfig)
+	if err != nil {
+		return err
+	}
+
+	if c.String("cidfile") != "" {
+		err := libpod.WriteFile(ctr.ID(), c.String("cidfile"))
+		if err != nil {
+			logrus.Error(err)
+		}
+	}
+	fmt.Printf("%s\n", ctr.ID())
+	return nil
+}
+
+// createContainerFromCreateConfig creates a new container from the given
+// create config, and saves the config as an artifact of the container
+func createContainerFromCreateConfig(ctx context.Context, runtime *libpod.Runtime, createConfig *cc.CreateConfig) (*libpod.Container, error) {
 	useImageVolumes := createConfig.ImageVolumeType == "bind"
 
 	runtimeSpec, err := cc.CreateConfigToOCISpec(createConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
+
 	options, err := createConfig.GetContainerCreateOptions()
 	if err != nil {
-		return errors.Wrapf(err, "unable to parse new container options")
+		return nil, errors.Wrapf(err, "unable to parse new container options")
 	}
+
 	// Gather up the options for NewContainer which consist of With... funcs
 	options = append(options, libpod.WithRootFSFromImage(createConfig.ImageID, createConfig.Image, useImageVolumes))
 	options = append(options, libpod.WithSELinuxLabels(createConfig.ProcessLabel, createConfig.MountLabel))
@@ -117,29 +137,35 @@ func createCmd(c *cli.Context) error {
 	options = append(options, libpod.WithShmSize(createConfig.Resources.ShmSize))
 	options = append(options, libpod.WithGroups(createConfig.GroupAdd))
 	options = append(options, libpod.WithIDMappings(*createConfig.IDMappings))
+
+	// Default used if not overridden on command line
+	if createConfig.CgroupParent != "" {
+		options = append(options, libpod.WithCgroupParent(createConfig.CgroupParent))
+	}
+
 	ctr, err := runtime.NewContainer(ctx, runtimeSpec, options...)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	logrus.Debugf("New container created %q", ctr.ID())
+
+	if logrus.GetLevel() == logrus.DebugLevel {
+		cgroupPath, err := ctr.CGroupPath()
+		if err == nil {
+			logrus.Debugf("container %q has CgroupParent %q", ctr.ID(), cgroupPath)
+		}
 	}
 
 	createConfigJSON, err := json.Marshal(createConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err := ctr.AddArtifact("create-config", createConfigJSON); err != nil {
-		return err
+		return nil, err
 	}
 
-	logrus.Debug("new container created ", ctr.ID())
-
-	if c.String("cidfile") != "" {
-		err := libpod.WriteFile(ctr.ID(), c.String("cidfile"))
-		if err != nil {
-			logrus.Error(err)
-		}
-	}
-	fmt.Printf("%s\n", ctr.ID())
-	return nil
+	return ctr, nil
 }
 
 func parseSecurityOpt(config *cc.CreateConfig, securityOpts []string) error {
@@ -307,12 +333,26 @@ func parseCreateOpts(ctx context.Context, c *cli.Context, runtime *libpod.Runtim
 	if c.Bool("detach") && c.Bool("rm") {
 		return nil, errors.Errorf("--rm and --detach can not be specified together")
 	}
+	restartPolicy, restartRetries, err := parseRestartPolicy(c.String("restart"))
+	if err != nil {
+		return nil, err
+	}
+	if c.Bool("rm") && restartPolicy != libpod.RestartPolicyNone && restartPolicy != libpod.RestartPolicyNo {
+		return nil, errors.Errorf("--rm and --restart can not be specified together")
+	}
+	healthCheck, err := parseHealthCheck(c, data.HealthCheck)
+	if err != nil {
+		return nil, err
+	}
 	if c.Int64("cpu-period") != 0 && c.Float64("cpus") > 0 {
 		return nil, errors.Errorf("--cpu-period and --cpus cannot be set together")
 	}
 	if c.Int64("cpu-quota") != 0 && c.Float64("cpus") > 0 {
 		return nil, errors.Errorf("--cpu-quota and --cpus cannot be set together")
 	}
+	if _, err := parseLoggingOpts(c.String("log-driver"), c.StringSlice("log-opt")); err != nil {
+		return nil, err
+	}
 
 	utsMode := container.UTSMode(c.String("uts"))
 	if !utsMode.Valid() {
@@ -536,17 +576,20 @@ func parseCreateOpts(ctx context.Context, c *cli.Context, runtime *libpod.Runtim
 			PidsLimit: c.Int64("pids-limit"),
 			Ulimit:    c.StringSlice("ulimit"),
 		},
-		Rm:          c.Bool("rm"),
-		ShmDir:      shmDir,
-		StopSignal:  stopSignal,
-		StopTimeout: c.Uint("stop-timeout"),
-		Sysctl:      sysctl,
-		Tmpfs:       c.StringSlice("tmpfs"),
-		Tty:         tty,
-		User:        user,
-		UsernsMode:  usernsMode,
-		Volumes:     c.StringSlice("volume"),
-		WorkDir:     workDir,
+		HealthCheck:    healthCheck,
+		RestartPolicy:  restartPolicy,
+		RestartRetries: restartRetries,
+		Rm:             c.Bool("rm"),
+		ShmDir:         shmDir,
+		StopSignal:     stopSignal,
+		StopTimeout:    c.Uint("stop-timeout"),
+		Sysctl:         sysctl,
+		Tmpfs:          c.StringSlice("tmpfs"),
+		Tty:            tty,
+		User:           user,
+		UsernsMode:     usernsMode,
+		Volumes:        c.StringSlice("volume"),
+		WorkDir:        workDir,
 	}
 
 	if !config.Privileged {
@@ -4,12 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/containers/image/manifest"
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
 	cc "github.com/projectatomic/libpod/pkg/spec"
+	"github.com/projectatomic/libpod/pkg/util"
 	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
 )
 
 const (
@@ -74,6 +80,99 @@ func addWarning(warnings []string, msg string) []string {
 	return append(warnings, msg)
 }
 
+// parseRestartPolicy splits a restart policy of the form
+// no|on-failure[:max-retries]|always|unless-stopped into the policy and its
+// maximum number of retries
+func parseRestartPolicy(restart string) (string, uint, error) {
+	split := strings.SplitN(restart, ":", 2)
+	policy := split[0]
+	switch policy {
+	case libpod.RestartPolicyNone, libpod.RestartPolicyNo, libpod.RestartPolicyAlways, libpod.RestartPolicyUnlessStopped:
+		if len(split) > 1 {
+			return "", 0, errors.Errorf("maximum retries can only be given with the %s restart policy", libpod.RestartPolicyOnFailure)
+		}
+		return policy, 0, nil
+	case libpod.RestartPolicyOnFailure:
+		if len(split) == 1 {
+			return policy, 0, nil
+		}
+		retries, err := strconv.ParseUint(split[1], 10, 32)
+		if err != nil {
+			return "", 0, errors.Errorf("invalid maximum retries %q in restart policy %q", split[1], restart)
+		}
+		return policy, uint(retries), nil
+	default:
+		return "", 0, errors.Errorf("invalid restart policy %q, must be one of no, on-failure[:max-retries], always or unless-stopped", restart)
+	}
+}
+
+// parseHealthCheck combines the image's healthcheck, if any, with the
+// healthcheck options given on the command line
+// nil is returned if the container will not have a healthcheck
+func parseHealthCheck(c *cli.Context, imageHealthCheck *manifest.Schema2HealthConfig) (*libpod.HealthCheckConfig, error) {
+	healthFlags := []string{"health-cmd", "health-interval", "health-retries", "health-start-period", "health-timeout"}
+	healthFlagsSet := false
+	for _, flag := range healthFlags {
+		if c.IsSet(flag) {
+			healthFlagsSet = true
+		}
+	}
+
+	if c.Bool("no-healthcheck") {
+		if healthFlagsSet {
+			return nil, errors.Errorf("--no-healthcheck conflicts with the --health-* options")
+		}
+		return nil, nil
+	}
+
+	healthCheck := new(libpod.HealthCheckConfig)
+	if imageHealthCheck != nil {
+		healthCheck.Test = imageHealthCheck.Test
+		healthCheck.Interval = imageHealthCheck.Interval
+		healthCheck.Timeout = imageHealthCheck.Timeout
+		healthCheck.Retries = imageHealthCheck.Retries
+	}
+
+	if c.IsSet("health-cmd") {
+		healthCmd := c.String("health-cmd")
+		if strings.ToLower(healthCmd) == "none" {
+			return nil, nil
+		}
+		healthCheck.Test = []string{libpod.HealthCheckTestCmdShell, healthCmd}
+	}
+
+	if len(healthCheck.Test) == 0 || healthCheck.Test[0] == libpod.HealthCheckTestNone {
+		if healthFlagsSet {
+			return nil, errors.Errorf("the --health-* options require a healthcheck, from --health-cmd or the image")
+		}
+		return nil, nil
+	}
+
+	durations := map[string]*time.Duration{
+		"health-interval":     &healthCheck.Interval,
+		"health-start-period": &healthCheck.StartPeriod,
+		"health-timeout":      &healthCheck.Timeout,
+	}
+	for flag, duration := range durations {
+		if !c.IsSet(flag) {
+			continue
+		}
+		value, err := time.ParseDuration(c.String(flag))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for --%s", flag)
+		}
+		*duration = value
+	}
+	if c.IsSet("health-retries") {
+		healthCheck.Retries = int(c.Uint("health-retries"))
+	}
+
+	if err := healthCheck.Validate(); err != nil {
+		return nil, err
+	}
+	return healthCheck, nil
+}
+
 func parseVolumes(volumes []string) error {
 	if len(volumes) == 0 {
 		return nil
@@ -81,10 +180,14 @@ func parseVolumes(volumes []string) error {
 	for _, volume := range volumes {
 		arr := strings.SplitN(volume, ":", 3)
 		if len(arr) < 2 {
-			return errors.Errorf("incorrect volume format %q, should be host-dir:ctr-dir:[option]", volume)
+			return errors.Errorf("incorrect volume format %q, should be host-dir:ctr-dir:[option] or volume-name:ctr-dir:[option]", volume)
 		}
-		if err := validateVolumeHostDir(arr[0]); err != nil {
-			return err
+		// Named volumes are validated, and created if they do not
+		// exist, by libpod
+		if !cc.IsNamedVolume(arr[0]) {
+			if err := validateVolumeHostDir(arr[0]); err != nil {
+				return err
+			}
 		}
 		if err := validateVolumeCtrDir(arr[1]); err != nil {
 			return err
@@ -93,6 +196,9 @@ func parseVolumes(volumes []string) error {
 			if err := validateVolumeOpts(arr[2]); err != nil {
 				return err
 			}
+			if !cc.IsNamedVolume(arr[0]) && util.StringInSlice("nocopy", strings.Split(arr[2], ",")) {
+				return errors.Errorf("invalid option %q, nocopy can only be used with named volumes", volume)
+			}
 		}
 	}
 	return nil
@@ -116,7 +222,7 @@ func validateVolumeCtrDir(ctrDir string) error {
 }
 
 func validateVolumeOpts(option string) error {
-	var foundRootPropagation, foundRWRO, foundLabelChange int
+	var foundRootPropagation, foundRWRO, foundLabelChange, foundCopy int
 	options := strings.Split(option, ",")
 	for _, opt := range options {
 		switch opt {
@@ -135,6 +241,11 @@ func validateVolumeOpts(option string) error {
 				return errors.Errorf("invalid options %q, can only specify 1 '[r]shared', '[r]private' or '[r]slave' option", option)
 			}
 			foundRootPropagation++
+		case "nocopy":
+			if foundCopy > 1 {
+				return errors.Errorf("invalid options %q, can only specify 1 'nocopy' option", option)
+			}
+			foundCopy++
 		default:
 			return errors.Errorf("invalid option type %q", option)
 		}
@@ -4,7 +4,10 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/containers/image/manifest"
+	"github.com/projectatomic/libpod/libpod"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +71,92 @@ func TestGetAllLabelsFile(t *testing.T) {
 	result, _ := getAllLabels(fileLabels, Var1)
 	assert.Equal(t, len(result), 3)
 }
+
+func TestParseRestartPolicy(t *testing.T) {
+	policy, retries, err := parseRestartPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", policy)
+	assert.Equal(t, uint(0), retries)
+
+	policy, retries, err = parseRestartPolicy("always")
+	assert.NoError(t, err)
+	assert.Equal(t, "always", policy)
+	assert.Equal(t, uint(0), retries)
+
+	policy, retries, err = parseRestartPolicy("on-failure:5")
+	assert.NoError(t, err)
+	assert.Equal(t, "on-failure", policy)
+	assert.Equal(t, uint(5), retries)
+}
+
+func TestParseRestartPolicyInvalid(t *testing.T) {
+	for _, restart := range []string{"sometimes", "always:3", "on-failure:many", "on-failure:-1"} {
+		_, _, err := parseRestartPolicy(restart)
+		assert.Error(t, err, restart)
+	}
+}
+
+// parseHealthCheckArgs parses the given create flags and the image
+// healthcheck into a container healthcheck
+func parseHealthCheckArgs(args []string, imageHealthCheck *manifest.Schema2HealthConfig) (*libpod.HealthCheckConfig, error) {
+	a := createCLI()
+	a.Run(append(cmd, args...))
+	return parseHealthCheck(CLI, imageHealthCheck)
+}
+
+func TestParseHealthCheckFlags(t *testing.T) {
+	healthCheck, err := parseHealthCheckArgs([]string{"--health-cmd", "curl -f http://localhost/", "--health-interval", "1m", "--health-retries", "5", "--health-start-period", "10s", "--health-timeout", "5s"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &libpod.HealthCheckConfig{
+		Test:        []string{libpod.HealthCheckTestCmdShell, "curl -f http://localhost/"},
+		Interval:    time.Minute,
+		Timeout:     5 * time.Second,
+		StartPeriod: 10 * time.Second,
+		Retries:     5,
+	}, healthCheck)
+}
+
+func TestParseHealthCheckImage(t *testing.T) {
+	imageHealthCheck := &manifest.Schema2HealthConfig{
+		Test:     []string{libpod.HealthCheckTestCmd, "/bin/check"},
+		Interval: time.Minute,
+		Retries:  2,
+	}
+
+	healthCheck, err := parseHealthCheckArgs(nil, imageHealthCheck)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{libpod.HealthCheckTestCmd, "/bin/check"}, healthCheck.Test)
+	assert.Equal(t, time.Minute, healthCheck.Interval)
+	assert.Equal(t, 2, healthCheck.Retries)
+
+	healthCheck, err = parseHealthCheckArgs([]string{"--health-retries", "4"}, imageHealthCheck)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{libpod.HealthCheckTestCmd, "/bin/check"}, healthCheck.Test)
+	assert.Equal(t, 4, healthCheck.Retries)
+
+	healthCheck, err = parseHealthCheckArgs([]string{"--no-healthcheck"}, imageHealthCheck)
+	assert.NoError(t, err)
+	assert.Nil(t, healthCheck)
+
+	healthCheck, err = parseHealthCheckArgs([]string{"--health-cmd", "none"}, imageHealthCheck)
+	assert.NoError(t, err)
+	assert.Nil(t, healthCheck)
+
+	imageHealthCheck.Test = []string{libpod.HealthCheckTestNone}
+	healthCheck, err = parseHealthCheckArgs(nil, imageHealthCheck)
+	assert.NoError(t, err)
+	assert.Nil(t, healthCheck)
+}
+
+func TestParseHealthCheckInvalid(t *testing.T) {
+	invalid := [][]string{
+		{"--health-retries", "3"},
+		{"--no-healthcheck", "--health-cmd", "true"},
+		{"--health-cmd", "true", "--health-interval", "often"},
+		{"--health-cmd", "true", "--health-timeout", "-1s"},
+	}
+	for _, args := range invalid {
+		_, err := parseHealthCheckArgs(args, nil)
+		assert.Error(t, err, "%v", args)
+	}
+}
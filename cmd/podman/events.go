@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/projectatomic/libpod/libpod/events"
+	"github.com/urfave/cli"
+)
+
+var (
+	eventsFlags = []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "filter, f",
+			Usage: "Filter output based on conditions given (container, event, image, pod, type, volume)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Change the output format to JSON or a Go template",
+		},
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "Show all events created since timestamp",
+		},
+		cli.BoolTFlag{
+			Name:  "stream",
+			Usage: "Stream new events as they happen.  The default is true",
+		},
+		cli.StringFlag{
+			Name:  "until",
+			Usage: "Show all events created until timestamp",
+		},
+	}
+	eventsDescription = `
+   podman events
+
+   Shows the events recorded for containers, pods, images and volumes, and
+   streams new events as they happen. Streaming ends once the time given with
+   --until has passed.
+`
+	eventsCommand = cli.Command{
+		Name:        "events",
+		Usage:       "Show podman events",
+		Description: eventsDescription,
+		Flags:       eventsFlags,
+		Action:      eventsCmd,
+	}
+)
+
+func eventsCmd(c *cli.Context) error {
+	if err := validateFlags(c, eventsFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) > 0 {
+		return errors.Errorf("'podman events' does not take any arguments")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	options := events.ReadOptions{
+		Follow:       c.BoolT("stream"),
+		EventChannel: make(chan *events.Event),
+	}
+	if c.IsSet("since") {
+		options.Since, err = parseInputTime(c.String("since"))
+		if err != nil {
+			return errors.Wrapf(err, "could not parse time: %q", c.String("since"))
+		}
+	}
+	if c.IsSet("until") {
+		options.Until, err = parseInputTime(c.String("until"))
+		if err != nil {
+			return errors.Wrapf(err, "could not parse time: %q", c.String("until"))
+		}
+	}
+	options.Filters, err = generateEventFilters(runtime, c.StringSlice("filter"))
+	if err != nil {
+		return err
+	}
+
+	printEvent, err := eventPrinter(c.String("format"))
+	if err != nil {
+		return err
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- runtime.Events(getContext(), options)
+	}()
+	for event := range options.EventChannel {
+		if err := printEvent(event); err != nil {
+			return err
+		}
+	}
+	return <-readErr
+}
+
+// Get a function that prints events in the given format
+func eventPrinter(format string) (func(*events.Event) error, error) {
+	switch format {
+	case "":
+		return func(event *events.Event) error {
+			fmt.Println(event.ToHumanReadable())
+			return nil
+		}, nil
+	case formats.JSONString:
+		return func(event *events.Event) error {
+			line, err := event.ToJSONString()
+			if err != nil {
+				return err
+			}
+			fmt.Println(line)
+			return nil
+		}, nil
+	}
+
+	tmpl, err := template.New("events").Parse(format)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid format %q", format)
+	}
+	return func(event *events.Event) error {
+		if err := tmpl.Execute(os.Stdout, event); err != nil {
+			return err
+		}
+		fmt.Println()
+		return nil
+	}, nil
+}
+
+// Generate the event filters given with --filter
+// Filters for the same key match an event if any of them does; filters for
+// different keys must all match
+func generateEventFilters(r *libpod.Runtime, filters []string) ([]events.Filter, error) {
+	values := make(map[string][]string)
+	var keys []string
+	for _, f := range filters {
+		split := strings.SplitN(f, "=", 2)
+		if len(split) != 2 {
+			return nil, errors.Errorf("invalid filter %q, must be of the form KEY=VALUE", f)
+		}
+		key, value := split[0], split[1]
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], value)
+	}
+
+	var eventFilters []events.Filter
+	for _, key := range keys {
+		var matchers []events.Filter
+		for _, value := range values[key] {
+			matcher, err := generateEventFilter(r, key, value)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, matcher)
+		}
+		eventFilters = append(eventFilters, func(e *events.Event) bool {
+			for _, matcher := range matchers {
+				if matcher(e) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return eventFilters, nil
+}
+
+// Generate a single event filter
+func generateEventFilter(r *libpod.Runtime, key, value string) (events.Filter, error) {
+	switch key {
+	case "container":
+		// An existing container is matched by its full ID, so events
+		// of removed containers that had the same name are left out
+		if ctr, err := r.LookupContainer(value); err == nil {
+			id := ctr.ID()
+			return func(e *events.Event) bool {
+				return e.Type == events.Container && e.ID == id
+			}, nil
+		}
+		return func(e *events.Event) bool {
+			return e.Type == events.Container && (strings.HasPrefix(e.ID, value) || e.Name == value)
+		}, nil
+	case "pod":
+		if pod, err := r.LookupPod(value); err == nil {
+			id := pod.ID()
+			return func(e *events.Event) bool {
+				return (e.Type == events.Pod && e.ID == id) ||
+					(e.Type == events.Container && e.Attributes["pod"] == id)
+			}, nil
+		}
+		return func(e *events.Event) bool {
+			switch e.Type {
+			case events.Pod:
+				return strings.HasPrefix(e.ID, value) || e.Name == value
+			case events.Container:
+				return strings.HasPrefix(e.Attributes["pod"], value)
+			}
+			return false
+		}, nil
+	case "image":
+		return func(e *events.Event) bool {
+			switch e.Type {
+			case events.Image:
+				return strings.HasPrefix(e.ID, value) || e.Name == value
+			case events.Container:
+				return e.Image == value
+			}
+			return false
+		}, nil
+	case "volume":
+		return func(e *events.Event) bool {
+			return e.Type == events.Volume && e.Name == value
+		}, nil
+	case "event":
+		status, err := events.StringToStatus(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(e *events.Event) bool {
+			return e.Status == status
+		}, nil
+	case "type":
+		eventType, err := events.StringToType(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(e *events.Event) bool {
+			return e.Type == eventType
+		}, nil
+	}
+	return nil, errors.Errorf("invalid filter %q", key)
+}
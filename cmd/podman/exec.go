@@ -12,10 +12,22 @@ import (
 
 var (
 	execFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "detach, d",
+			Usage: "Run the command in the background and print the ID of the exec session",
+		},
 		cli.StringSliceFlag{
 			Name:  "env, e",
 			Usage: "Set environment variables",
 		},
+		cli.StringSliceFlag{
+			Name:  "env-file",
+			Usage: "Read in a file of environment variables",
+		},
+		cli.UintFlag{
+			Name:  "preserve-fds",
+			Usage: "Pass N additional file descriptors to the command, starting at 3",
+		},
 		cli.BoolFlag{
 			Name:  "privileged",
 			Usage: "Give the process extended Linux capabilities inside the container.  The default is false",
@@ -32,12 +44,17 @@ var (
 			Name:  "user, u",
 			Usage: "Sets the username or UID used and optionally the groupname or GID for the specified command",
 		},
+		cli.StringFlag{
+			Name:  "workdir, w",
+			Usage: "Working directory inside the container",
+		},
 		LatestFlag,
 	}
 	execDescription = `
 	podman exec
 
-	Run a command in a running container
+	Run a command in a running container. podman exec exits with the exit code
+	of the command, unless the command is run in the background with --detach.
 `
 
 	execCommand = cli.Command{
@@ -66,6 +83,9 @@ func execCmd(c *cli.Context) error {
 	if c.Bool("latest") {
 		argStart = 0
 	}
+	if c.Bool("detach") && c.Bool("tty") {
+		return errors.Errorf("--detach and --tty cannot be used together")
+	}
 	cmd := args[argStart:]
 	runtime, err := libpodruntime.GetRuntime(c)
 	if err != nil {
@@ -93,7 +113,7 @@ func execCmd(c *cli.Context) error {
 		}
 	}
 
-	if err := readKVStrings(env, []string{}, c.StringSlice("env")); err != nil {
+	if err := readKVStrings(env, c.StringSlice("env-file"), c.StringSlice("env")); err != nil {
 		return errors.Wrapf(err, "unable to process environment variables")
 	}
 	envs := []string{}
@@ -101,5 +121,32 @@ func execCmd(c *cli.Context) error {
 		envs = append(envs, fmt.Sprintf("%s=%s", k, v))
 	}
 
-	return ctr.Exec(c.Bool("tty"), c.Bool("privileged"), envs, cmd, c.String("user"))
+	config := &libpod.ExecConfig{
+		Command:     cmd,
+		Env:         envs,
+		Tty:         c.Bool("tty"),
+		Privileged:  c.Bool("privileged"),
+		User:        c.String("user"),
+		WorkDir:     c.String("workdir"),
+		PreserveFDs: c.Uint("preserve-fds"),
+	}
+
+	if c.Bool("detach") {
+		sessionID, err := ctr.ExecCreate(config)
+		if err != nil {
+			return err
+		}
+		if err := ctr.ExecStart(sessionID); err != nil {
+			return err
+		}
+		fmt.Println(sessionID)
+		return nil
+	}
+
+	ecode, err := ctr.Exec(config, nil)
+	if err != nil {
+		return err
+	}
+	exitCode = ecode
+	return nil
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+var (
+	generateSubCommands = []cli.Command{
+		containerKubeCommand,
+	}
+
+	generateDescription = `
+   podman generate
+
+   Generate structured data based on containers and pods.
+`
+	generateCommand = cli.Command{
+		Name:                   "generate",
+		Usage:                  "Generate structured data",
+		Description:            generateDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            generateSubCommands,
+	}
+)
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	podmanVersion "github.com/projectatomic/libpod/version"
+	"github.com/urfave/cli"
+	"k8s.io/api/core/v1"
+)
+
+var (
+	containerKubeFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "service, s",
+			Usage: "generate YAML for a Kubernetes service object",
+		},
+	}
+	containerKubeDescription = `
+   podman generate kube
+
+   Generate Kubernetes Pod YAML from a pod or container.
+   With --service, a Kubernetes service exposing the published ports is generated as well.
+`
+	containerKubeCommand = cli.Command{
+		Name:                   "kube",
+		Usage:                  "Generate Kubernetes pod YAML for a container or pod",
+		Description:            containerKubeDescription,
+		Flags:                  containerKubeFlags,
+		Action:                 generateKubeYAMLCmd,
+		ArgsUsage:              "CONTAINER|POD-NAME",
+		UseShortOptionHandling: true,
+	}
+)
+
+// generateKubeYAMLCmd generates Kubernetes YAML from a pod or container
+func generateKubeYAMLCmd(c *cli.Context) error {
+	var (
+		podYAML      *v1.Pod
+		servicePorts []v1.ServicePort
+	)
+
+	if err := validateFlags(c, containerKubeFlags); err != nil {
+		return err
+	}
+
+	args := c.Args()
+	if len(args) != 1 {
+		return errors.Errorf("you must provide exactly one container|pod ID or name")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	// Pods take precedence over containers of the same name
+	if pod, err := runtime.LookupPod(args[0]); err == nil {
+		podYAML, servicePorts, err = pod.GenerateForKube()
+		if err != nil {
+			return err
+		}
+	} else {
+		ctr, err := runtime.LookupContainer(args[0])
+		if err != nil {
+			return errors.Wrapf(err, "unable to find a pod or container named %s", args[0])
+		}
+		if ctr.IsInfra() {
+			return errors.Errorf("%s is an infra container and cannot be used to generate Kubernetes YAML", args[0])
+		}
+		podYAML, err = ctr.GenerateForKube()
+		if err != nil {
+			return err
+		}
+		servicePorts = libpod.ContainerPortsToServicePorts(podYAML.Spec.Containers[0].Ports)
+	}
+
+	marshalledPod, err := yaml.Marshal(podYAML)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling pod YAML")
+	}
+
+	var marshalledService []byte
+	if c.Bool("service") {
+		serviceYAML := libpod.GenerateKubeServiceFromV1Pod(podYAML, servicePorts)
+		marshalledService, err = yaml.Marshal(serviceYAML)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling service YAML")
+		}
+	}
+
+	header := `# Generation of Kubernetes YAML is still under development!
+#
+# Save the output of this file and use kubectl create -f to import
+# it into Kubernetes.
+#
+# Created with podman-%s
+`
+	fmt.Printf(header, podmanVersion.Version)
+	fmt.Println(string(marshalledPod))
+	if c.Bool("service") {
+		fmt.Println("---")
+		fmt.Println(string(marshalledService))
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+var (
+	healthcheckSubCommands = []cli.Command{
+		healthcheckRunCommand,
+	}
+
+	healthcheckDescription = `
+   podman healthcheck
+
+   Manage the healthchecks of containers.
+`
+	healthcheckCommand = cli.Command{
+		Name:                   "healthcheck",
+		Usage:                  "Manage container healthchecks",
+		Description:            healthcheckDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            healthcheckSubCommands,
+	}
+)
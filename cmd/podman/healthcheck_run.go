@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	healthcheckRunFlags = []cli.Flag{
+		LatestFlag,
+	}
+	healthcheckRunDescription = `
+   podman healthcheck run
+
+   Run the healthcheck of a running container once, record its result, and
+   print the resulting health of the container.
+   Exits with 1 if the container is not healthy.
+`
+	healthcheckRunCommand = cli.Command{
+		Name:                   "run",
+		Usage:                  "Run the healthcheck of a container",
+		Description:            healthcheckRunDescription,
+		Flags:                  healthcheckRunFlags,
+		Action:                 healthcheckRunCmd,
+		ArgsUsage:              "CONTAINER-NAME|CONTAINER-ID",
+		UseShortOptionHandling: true,
+	}
+)
+
+// healthcheckRunCmd runs a container's healthcheck and exits with 1 if the
+// container is not healthy
+func healthcheckRunCmd(c *cli.Context) error {
+	if err := validateFlags(c, healthcheckRunFlags); err != nil {
+		return err
+	}
+
+	args := c.Args()
+	if len(args) > 1 || (len(args) == 0 && !c.Bool("latest")) {
+		return errors.Errorf("you must provide exactly one container name or ID")
+	}
+	if len(args) > 0 && c.Bool("latest") {
+		return errors.Errorf("--latest and containers cannot be used together")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	var ctr *libpod.Container
+	if c.Bool("latest") {
+		ctr, err = runtime.GetLatestContainer()
+	} else {
+		ctr, err = runtime.LookupContainer(args[0])
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to find container")
+	}
+
+	status, err := ctr.RunHealthCheck()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(status)
+	if status != libpod.HealthCheckHealthy {
+		exitCode = 1
+	}
+	return nil
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 
+	"github.com/docker/go-connections/nat"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/projectatomic/libpod/cmd/podman/formats"
@@ -20,6 +22,7 @@ import (
 const (
 	inspectTypeContainer = "container"
 	inspectTypeImage     = "image"
+	inspectTypeExec      = "exec"
 	inspectAll           = "all"
 )
 
@@ -28,7 +31,7 @@ var (
 		cli.StringFlag{
 			Name:  "type, t",
 			Value: inspectAll,
-			Usage: "Return JSON for specified type, (e.g image, container or task)",
+			Usage: "Return JSON for specified type, (e.g image, container, exec or task)",
 		},
 		cli.StringFlag{
 			Name:  "format, f",
@@ -72,8 +75,8 @@ func inspectCmd(c *cli.Context) error {
 	}
 	defer runtime.Shutdown(false)
 
-	if !util.StringInSlice(inspectType, []string{inspectTypeContainer, inspectTypeImage, inspectAll}) {
-		return errors.Errorf("the only recognized types are %q, %q, and %q", inspectTypeContainer, inspectTypeImage, inspectAll)
+	if !util.StringInSlice(inspectType, []string{inspectTypeContainer, inspectTypeImage, inspectTypeExec, inspectAll}) {
+		return errors.Errorf("the only recognized types are %q, %q, %q, and %q", inspectTypeContainer, inspectTypeImage, inspectTypeExec, inspectAll)
 	}
 
 	outputFormat := c.String("format")
@@ -141,6 +144,17 @@ func iterateInput(ctx context.Context, c *cli.Context, args []string, runtime *l
 				inspectError = errors.Wrapf(err, "error parsing image data %q", image.ID())
 				break
 			}
+		case inspectTypeExec:
+			ctr, err := runtime.GetExecSessionContainer(input)
+			if err != nil {
+				inspectError = errors.Wrapf(err, "error looking up exec session %q", input)
+				break
+			}
+			data, err = ctr.InspectExecSession(input)
+			if err != nil {
+				inspectError = errors.Wrapf(err, "error getting exec session inspect data %q", input)
+				break
+			}
 		case inspectAll:
 			ctr, err := runtime.LookupContainer(input)
 			if err != nil {
@@ -223,6 +237,8 @@ func getCtrInspectInfo(ctr *libpod.Container, ctrInspectData *inspect.ContainerI
 			ReadonlyRootfs:       spec.Root.Readonly,
 			Runtime:              ctr.RuntimeName(),
 			NetworkMode:          string(createArtifact.NetMode),
+			PortBindings:         getPortBindings(ctr),
+			PublishAllPorts:      createArtifact.PublishAll,
 			IpcMode:              string(createArtifact.IpcMode),
 			Cgroup:               cgroup,
 			UTSMode:              string(createArtifact.UtsMode),
@@ -238,10 +254,18 @@ func getCtrInspectInfo(ctr *libpod.Container, ctrInspectData *inspect.ContainerI
 			PidMode:              string(createArtifact.PidMode),
 			CgroupParent:         createArtifact.CgroupParent,
 			ShmSize:              createArtifact.Resources.ShmSize,
-			Memory:               createArtifact.Resources.Memory,
+			Memory:               getMemoryLimit(spec),
 			Ulimits:              createArtifact.Resources.Ulimit,
 			SecurityOpt:          createArtifact.SecurityOpts,
 			Tmpfs:                createArtifact.Tmpfs,
+			LogConfig: &inspect.LogConfig{
+				Type:   ctr.LogDriver(),
+				Config: convertKVStringsToMap(createArtifact.LogDriverOpt),
+			},
+			RestartPolicy: &inspect.RestartPolicy{
+				Name:              ctr.RestartPolicy(),
+				MaximumRetryCount: ctr.RestartRetries(),
+			},
 		},
 		&inspect.CtrConfig{
 			Hostname:    spec.Hostname,
@@ -256,11 +280,45 @@ func getCtrInspectInfo(ctr *libpod.Container, ctrInspectData *inspect.ContainerI
 			StopSignal:  config.StopSignal,
 			Cmd:         config.Spec.Process.Args,
 			Entrypoint:  strings.Join(createArtifact.Entrypoint, " "),
+			Healthcheck: getHealthCheck(ctr),
 		},
 	}
 	return data, nil
 }
 
+// getPortBindings returns the container's port mappings in inspect format,
+// including the host ports allocated to them
+func getPortBindings(ctr *libpod.Container) nat.PortMap {
+	bindings := make(nat.PortMap)
+	for _, mapping := range ctr.PortMappings() {
+		port, err := nat.NewPort(mapping.Protocol, strconv.Itoa(int(mapping.ContainerPort)))
+		if err != nil {
+			continue
+		}
+		bindings[port] = append(bindings[port], nat.PortBinding{
+			HostIP:   mapping.HostIP,
+			HostPort: strconv.Itoa(int(mapping.HostPort)),
+		})
+	}
+	return bindings
+}
+
+// getHealthCheck returns the healthcheck of a container in inspect format,
+// or nil if it has none
+func getHealthCheck(ctr *libpod.Container) *inspect.HealthConfig {
+	healthCheck := ctr.HealthCheckConfig()
+	if healthCheck == nil {
+		return nil
+	}
+	return &inspect.HealthConfig{
+		Test:        healthCheck.Test,
+		Interval:    healthCheck.Interval,
+		Timeout:     healthCheck.Timeout,
+		StartPeriod: healthCheck.StartPeriod,
+		Retries:     healthCheck.Retries,
+	}
+}
+
 func getCPUInfo(spec *specs.Spec) (string, string, *uint64, *int64, *uint64, *int64, *uint64) {
 	if spec.Linux.Resources == nil {
 		return "", "", nil, nil, nil, nil, nil
@@ -294,6 +352,15 @@ func getMemoryInfo(spec *specs.Spec) (*int64, *int64, *int64, *uint64, *bool) {
 	return memory.Kernel, memory.Reservation, memory.Swap, memory.Swappiness, memory.DisableOOMKiller
 }
 
+// getMemoryLimit returns the memory limit of the container, which may have
+// been changed since it was created
+func getMemoryLimit(spec *specs.Spec) int64 {
+	if spec.Linux.Resources == nil || spec.Linux.Resources.Memory == nil || spec.Linux.Resources.Memory.Limit == nil {
+		return 0
+	}
+	return *spec.Linux.Resources.Memory.Limit
+}
+
 func getPidsInfo(spec *specs.Spec) *int64 {
 	if spec.Linux.Resources == nil {
 		return nil
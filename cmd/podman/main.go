@@ -37,11 +37,16 @@ func main() {
 	app.Commands = []cli.Command{
 		attachCommand,
 		commitCommand,
+		containerCommand,
+		cpCommand,
 		buildCommand,
 		createCommand,
 		diffCommand,
+		eventsCommand,
 		execCommand,
 		exportCommand,
+		generateCommand,
+		healthcheckCommand,
 		historyCommand,
 		imagesCommand,
 		importCommand,
@@ -53,11 +58,15 @@ func main() {
 		logoutCommand,
 		logsCommand,
 		mountCommand,
+		networkCommand,
 		pauseCommand,
+		playCommand,
+		podCommand,
 		psCommand,
 		portCommand,
 		pullCommand,
 		pushCommand,
+		renameCommand,
 		restartCommand,
 		rmCommand,
 		rmiCommand,
@@ -67,12 +76,15 @@ func main() {
 		startCommand,
 		statsCommand,
 		stopCommand,
+		superviseCommand,
 		tagCommand,
 		topCommand,
 		umountCommand,
 		unpauseCommand,
+		updateCommand,
 		varlinkCommand,
 		versionCommand,
+		volumeCommand,
 		waitCommand,
 	}
 	app.Before = func(c *cli.Context) error {
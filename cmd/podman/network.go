@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkDescription = `
+   podman network
+
+   Manage CNI networks.
+   Networks are configured by files in the CNI configuration directory, and
+   containers join them with --network.
+`
+	networkSubCommands = []cli.Command{
+		networkConnectCommand,
+		networkCreateCommand,
+		networkDisconnectCommand,
+		networkInspectCommand,
+		networkLsCommand,
+		networkRmCommand,
+	}
+	networkCommand = cli.Command{
+		Name:                   "network",
+		Usage:                  "Manage networks",
+		Description:            networkDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            networkSubCommands,
+	}
+)
+
+// getNetworksFromContext returns the networks named on the command line
+func getNetworksFromContext(c *cli.Context, r *libpod.Runtime) ([]*libpod.Network, error) {
+	var networks []*libpod.Network
+	var lastError error
+
+	for _, name := range c.Args() {
+		network, err := r.GetNetwork(name)
+		if err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to find network %s", name)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks, lastError
+}
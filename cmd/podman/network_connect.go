@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkConnectDescription = `
+   podman network connect
+
+   Connects a container to a network. A running container is attached to the
+   network right away, with a new interface; otherwise, the container joins the
+   network when it is next started.
+`
+	networkConnectCommand = cli.Command{
+		Name:        "connect",
+		Usage:       "Connect a container to a network",
+		Description: networkConnectDescription,
+		Action:      networkConnectCmd,
+		ArgsUsage:   "NETWORK-NAME CONTAINER-NAME|CONTAINER-ID",
+	}
+)
+
+func networkConnectCmd(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return errors.Errorf("you must provide a network name and a container name or ID")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	network, err := runtime.GetNetwork(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "unable to find network %s", args[0])
+	}
+	ctr, err := runtime.LookupContainer(args[1])
+	if err != nil {
+		return errors.Wrapf(err, "unable to find container %s", args[1])
+	}
+
+	return runtime.ConnectNetwork(getContext(), network, ctr)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkCreateFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "driver, d",
+			Usage: "Driver to manage the network (bridge or macvlan)",
+			Value: libpod.BridgeNetworkDriver,
+		},
+		cli.StringFlag{
+			Name:  "gateway",
+			Usage: "IPv4 gateway of the subnet",
+		},
+		cli.BoolFlag{
+			Name:  "internal",
+			Usage: "Restrict external access to the network",
+		},
+		cli.StringFlag{
+			Name:  "ip-range",
+			Usage: "Allocate container IPs from a range of the subnet, in CIDR format",
+		},
+		cli.StringFlag{
+			Name:  "macvlan",
+			Usage: "Host interface macvlan networks are connected to",
+		},
+		cli.StringFlag{
+			Name:  "subnet",
+			Usage: "IPv4 subnet of the network, in CIDR format",
+		},
+	}
+	networkCreateDescription = `
+   podman network create
+
+   Creates a new CNI network, writing its configuration to the CNI
+   configuration directory. A free subnet is chosen for bridge networks if
+   none is given. The path of the configuration file is printed to stdout.
+`
+	networkCreateCommand = cli.Command{
+		Name:                   "create",
+		Usage:                  "Create a new network",
+		Description:            networkCreateDescription,
+		Flags:                  networkCreateFlags,
+		Action:                 networkCreateCmd,
+		ArgsUsage:              "NETWORK-NAME",
+		UseShortOptionHandling: true,
+	}
+)
+
+func networkCreateCmd(c *cli.Context) error {
+	if err := validateFlags(c, networkCreateFlags); err != nil {
+		return err
+	}
+	args := c.Args()
+	if len(args) != 1 {
+		return errors.Errorf("'podman network create' requires a network name")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	options := []libpod.NetworkCreateOption{
+		libpod.WithNetworkName(args[0]),
+		libpod.WithNetworkDriver(c.String("driver")),
+	}
+	if c.IsSet("subnet") {
+		_, subnet, err := net.ParseCIDR(c.String("subnet"))
+		if err != nil {
+			return errors.Wrapf(err, "invalid subnet %q", c.String("subnet"))
+		}
+		options = append(options, libpod.WithNetworkSubnet(subnet))
+	}
+	if c.IsSet("gateway") {
+		gateway := net.ParseIP(c.String("gateway"))
+		if gateway == nil {
+			return errors.Errorf("invalid gateway %q", c.String("gateway"))
+		}
+		options = append(options, libpod.WithNetworkGateway(gateway))
+	}
+	if c.IsSet("ip-range") {
+		_, ipRange, err := net.ParseCIDR(c.String("ip-range"))
+		if err != nil {
+			return errors.Wrapf(err, "invalid IP range %q", c.String("ip-range"))
+		}
+		options = append(options, libpod.WithNetworkIPRange(ipRange))
+	}
+	if c.Bool("internal") {
+		options = append(options, libpod.WithNetworkInternal())
+	}
+	if c.IsSet("macvlan") {
+		options = append(options, libpod.WithMacvlanParent(c.String("macvlan")))
+	}
+
+	network, err := runtime.NewNetwork(getContext(), options...)
+	if err != nil {
+		return errors.Wrapf(err, "error creating network")
+	}
+
+	fmt.Println(network.Path())
+	return nil
+}
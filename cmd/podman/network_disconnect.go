@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkDisconnectDescription = `
+   podman network disconnect
+
+   Disconnects a container from a network. A running container is detached from
+   the network right away, removing its interface. A container cannot be
+   disconnected from its last network.
+`
+	networkDisconnectCommand = cli.Command{
+		Name:        "disconnect",
+		Usage:       "Disconnect a container from a network",
+		Description: networkDisconnectDescription,
+		Action:      networkDisconnectCmd,
+		ArgsUsage:   "NETWORK-NAME CONTAINER-NAME|CONTAINER-ID",
+	}
+)
+
+func networkDisconnectCmd(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return errors.Errorf("you must provide a network name and a container name or ID")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	network, err := runtime.GetNetwork(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "unable to find network %s", args[0])
+	}
+	ctr, err := runtime.LookupContainer(args[1])
+	if err != nil {
+		return errors.Wrapf(err, "unable to find container %s", args[1])
+	}
+
+	return runtime.DisconnectNetwork(getContext(), network, ctr)
+}
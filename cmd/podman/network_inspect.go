@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkInspectFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "Format network output using Go template",
+		},
+	}
+	networkInspectDescription = `
+   podman network inspect
+
+   Displays the configuration of one or more networks, by default in JSON.
+`
+	networkInspectCommand = cli.Command{
+		Name:                   "inspect",
+		Usage:                  "Display detailed information on one or more networks",
+		Description:            networkInspectDescription,
+		Flags:                  networkInspectFlags,
+		Action:                 networkInspectCmd,
+		ArgsUsage:              "NETWORK-NAME [NETWORK-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func networkInspectCmd(c *cli.Context) error {
+	if err := validateFlags(c, networkInspectFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) < 1 {
+		return errors.Errorf("you must provide at least one network name")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	networks, lastError := getNetworksFromContext(c, runtime)
+
+	var inspected []interface{}
+	for _, network := range networks {
+		inspected = append(inspected, network.Inspect())
+	}
+
+	var out formats.Writer
+	if format := c.String("format"); format != "" && format != formats.JSONString {
+		out = formats.StdoutTemplateArray{Output: inspected, Template: format}
+	} else {
+		out = formats.JSONStructArray{Output: inspected}
+	}
+	if err := formats.Writer(out).Out(); err != nil {
+		return err
+	}
+	return lastError
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+type networkLsTemplateParams struct {
+	Name     string
+	Driver   string
+	Subnets  string
+	Internal bool
+	Path     string
+}
+
+type networkLsJSONParams struct {
+	Name     string   `json:"name"`
+	Driver   string   `json:"driver"`
+	Subnets  []string `json:"subnets"`
+	Internal bool     `json:"internal"`
+	Path     string   `json:"path"`
+}
+
+var (
+	networkLsFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Format network output using Go template",
+		},
+		cli.BoolFlag{
+			Name:  "quiet, q",
+			Usage: "Print network names only",
+		},
+	}
+	networkLsDescription = `
+   podman network ls
+
+   Lists the CNI networks containers can join.
+`
+	networkLsCommand = cli.Command{
+		Name:                   "ls",
+		Aliases:                []string{"list"},
+		Usage:                  "List networks",
+		Description:            networkLsDescription,
+		Flags:                  networkLsFlags,
+		Action:                 networkLsCmd,
+		UseShortOptionHandling: true,
+	}
+)
+
+func networkLsCmd(c *cli.Context) error {
+	if err := validateFlags(c, networkLsFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) > 0 {
+		return errors.Errorf("'podman network ls' does not take any arguments")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	networks, err := runtime.Networks()
+	if err != nil {
+		return err
+	}
+
+	var out formats.Writer
+	format := c.String("format")
+	switch {
+	case format == formats.JSONString:
+		var params []interface{}
+		for _, network := range networks {
+			data := network.Inspect()
+			params = append(params, networkLsJSONParams{
+				Name:     data.Name,
+				Driver:   data.Driver,
+				Subnets:  data.Subnets,
+				Internal: data.Internal,
+				Path:     data.Path,
+			})
+		}
+		out = formats.JSONStructArray{Output: params}
+	default:
+		if c.Bool("quiet") {
+			format = "{{.Name}}"
+		} else if format == "" {
+			format = "table {{.Name}}\t{{.Driver}}\t{{.Subnets}}"
+		}
+		var params []interface{}
+		for _, network := range networks {
+			data := network.Inspect()
+			params = append(params, networkLsTemplateParams{
+				Name:     data.Name,
+				Driver:   data.Driver,
+				Subnets:  strings.Join(data.Subnets, ","),
+				Internal: data.Internal,
+				Path:     data.Path,
+			})
+		}
+		out = formats.StdoutTemplateArray{
+			Output:   params,
+			Template: format,
+			Fields: map[string]string{
+				"Name":     "NAME",
+				"Driver":   "DRIVER",
+				"Subnets":  "SUBNETS",
+				"Internal": "INTERNAL",
+				"Path":     "PATH",
+			},
+		}
+	}
+	return formats.Writer(out).Out()
+}
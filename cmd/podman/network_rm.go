@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	networkRmFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "Remove a network in use by first removing the containers using it.  The default is false",
+		},
+	}
+	networkRmDescription = `
+   podman network rm
+
+   Removes one or more networks. Networks joined by containers are not removed
+   unless --force is given, in which case the containers are removed too. The
+   default network cannot be removed.
+`
+	networkRmCommand = cli.Command{
+		Name:                   "rm",
+		Aliases:                []string{"remove"},
+		Usage:                  "Remove one or more networks",
+		Description:            networkRmDescription,
+		Flags:                  networkRmFlags,
+		Action:                 networkRmCmd,
+		ArgsUsage:              "NETWORK-NAME [NETWORK-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func networkRmCmd(c *cli.Context) error {
+	if err := validateFlags(c, networkRmFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) < 1 {
+		return errors.Errorf("you must provide at least one network name")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	ctx := getContext()
+
+	// getNetworksFromContext returns an error when a requested network
+	// isn't found, but the networks that were found are still removed
+	networks, lastError := getNetworksFromContext(c, runtime)
+
+	for _, network := range networks {
+		if err := runtime.RemoveNetwork(ctx, network, c.Bool("force")); err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "failed to remove network %s", network.Name())
+		} else {
+			fmt.Println(network.Name())
+		}
+	}
+	return lastError
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
 )
 
 const (
@@ -387,11 +388,18 @@ func validateMACAddress(val string) (string, error) { //nolint
 
 // parseLoggingOpts validates the logDriver and logDriverOpts
 // for log-opt and log-driver flags
-func parseLoggingOpts(logDriver string, logDriverOpt []string) (map[string]string, error) { //nolint
+func parseLoggingOpts(logDriver string, logDriverOpt []string) (map[string]string, error) {
 	logOptsMap := convertKVStringsToMap(logDriverOpt)
-	if logDriver == "none" && len(logDriverOpt) > 0 {
+	if logDriver == libpod.NoLogging && len(logDriverOpt) > 0 {
 		return map[string]string{}, errors.Errorf("invalid logging opts for driver %s", logDriver)
 	}
+	if logDriver == libpod.JournaldLogging {
+		for _, opt := range []string{"path", "max-size", "max-file"} {
+			if _, ok := logOptsMap[opt]; ok {
+				return map[string]string{}, errors.Errorf("invalid logging opt %s for driver %s", opt, logDriver)
+			}
+		}
+	}
 	return logOptsMap, nil
 }
 
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+var (
+	playSubCommands = []cli.Command{
+		playKubeCommand,
+	}
+
+	playDescription = `
+   podman play
+
+   Play a pod and its containers from a structured file.
+`
+	playCommand = cli.Command{
+		Name:                   "play",
+		Usage:                  "Play a pod and its containers from a structured file",
+		Description:            playDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            playSubCommands,
+	}
+)
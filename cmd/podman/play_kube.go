@@ -0,0 +1,556 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/containers/image/types"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/projectatomic/libpod/libpod/image"
+	cc "github.com/projectatomic/libpod/pkg/spec"
+	"github.com/projectatomic/libpod/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"k8s.io/api/core/v1"
+)
+
+var (
+	playKubeFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "authfile",
+			Usage: "Path of the authentication file. Default is ${XDG_RUNTIME_DIR}/containers/auth.json",
+		},
+		cli.StringFlag{
+			Name:  "cert-dir",
+			Usage: "`pathname` of a directory containing TLS certificates and keys",
+		},
+		cli.StringFlag{
+			Name:  "creds",
+			Usage: "`credentials` (USERNAME:PASSWORD) to use for authenticating to a registry",
+		},
+		cli.BoolFlag{
+			Name:  "quiet, q",
+			Usage: "Suppress output information when pulling images",
+		},
+		cli.StringFlag{
+			Name:  "signature-policy",
+			Usage: "`pathname` of signature policy file (not usually used)",
+		},
+		cli.BoolFlag{
+			Name:  "start",
+			Usage: "start the pod after creating it",
+		},
+		cli.BoolTFlag{
+			Name:  "tls-verify",
+			Usage: "require HTTPS and verify certificates when contacting registries (default: true)",
+		},
+	}
+	playKubeDescription = `
+   podman play kube
+
+   Create a pod and its containers from Kubernetes Pod YAML. The pod ID is
+   printed, followed by the IDs of its containers.
+`
+	playKubeCommand = cli.Command{
+		Name:                   "kube",
+		Usage:                  "Create a pod and its containers from Kubernetes Pod YAML",
+		Description:            playKubeDescription,
+		Flags:                  playKubeFlags,
+		Action:                 playKubeYAMLCmd,
+		ArgsUsage:              "KUBEFILE",
+		UseShortOptionHandling: true,
+	}
+)
+
+// playKubeYAMLCmd creates a pod and its containers from Kubernetes YAML
+func playKubeYAMLCmd(c *cli.Context) error {
+	if err := validateFlags(c, playKubeFlags); err != nil {
+		return err
+	}
+
+	args := c.Args()
+	if len(args) != 1 {
+		return errors.Errorf("you must provide exactly one Kubernetes YAML file")
+	}
+
+	content, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "unable to read %s", args[0])
+	}
+
+	var podYAML v1.Pod
+	if err := yaml.Unmarshal(content, &podYAML); err != nil {
+		return errors.Wrapf(err, "unable to parse %s as Kubernetes Pod YAML", args[0])
+	}
+	if podYAML.Kind != "" && podYAML.Kind != "Pod" {
+		return errors.Errorf("%s describes a %s, only Pods are supported", args[0], podYAML.Kind)
+	}
+	if len(podYAML.Spec.Containers) == 0 {
+		return errors.Errorf("pod in %s has no containers", args[0])
+	}
+
+	volumes, err := kubeHostPathVolumes(podYAML.Spec.Volumes)
+	if err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	ctx := getContext()
+
+	// Pull the images first, so a failed pull does not leave a partial pod
+	// behind
+	images := make([]*image.Image, 0, len(podYAML.Spec.Containers))
+	for _, containerYAML := range podYAML.Spec.Containers {
+		newImage, err := getKubeImage(ctx, c, runtime, containerYAML)
+		if err != nil {
+			return err
+		}
+		images = append(images, newImage)
+	}
+
+	pod, err := runtime.NewPod(ctx, kubePodOptions(&podYAML)...)
+	if err != nil {
+		return err
+	}
+
+	// Do not leave a partial pod behind if one of its containers cannot be
+	// created
+	removePod := func() {
+		if err := runtime.RemovePod(pod, true, true); err != nil {
+			logrus.Errorf("error removing pod %s: %v", pod.ID(), err)
+		}
+	}
+
+	ctrs := make([]*libpod.Container, 0, len(podYAML.Spec.Containers))
+	for i, containerYAML := range podYAML.Spec.Containers {
+		createConfig, err := kubeContainerToCreateConfig(ctx, runtime, containerYAML, images[i], pod, &podYAML.Spec, volumes)
+		if err != nil {
+			removePod()
+			return err
+		}
+		ctr, err := createContainerFromCreateConfig(ctx, runtime, createConfig)
+		if err != nil {
+			removePod()
+			return errors.Wrapf(err, "error creating container %s", containerYAML.Name)
+		}
+		ctrs = append(ctrs, ctr)
+	}
+
+	if c.Bool("start") {
+		ctrErrs, err := pod.Start(ctx)
+		if err != nil {
+			for ctr, err := range ctrErrs {
+				logrus.Errorf("error starting container %s: %v", ctr, err)
+			}
+			return errors.Wrapf(err, "unable to start pod %q", pod.ID())
+		}
+	}
+
+	fmt.Println(pod.ID())
+	for _, ctr := range ctrs {
+		fmt.Println(ctr.ID())
+	}
+
+	return nil
+}
+
+// kubeHostPathVolumes maps the names of the volumes of a Kubernetes pod to
+// their host paths, preparing the host paths as requested by their types
+// Only hostPath volumes are supported
+func kubeHostPathVolumes(volumes []v1.Volume) (map[string]string, error) {
+	hostPaths := make(map[string]string)
+	for _, volume := range volumes {
+		hostPath := volume.VolumeSource.HostPath
+		if hostPath == nil {
+			return nil, errors.Errorf("volume %s is not supported, only hostPath volumes can be used", volume.Name)
+		}
+
+		if hostPath.Type != nil {
+			switch *hostPath.Type {
+			case v1.HostPathDirectoryOrCreate:
+				if err := os.MkdirAll(hostPath.Path, 0755); err != nil {
+					return nil, errors.Wrapf(err, "error creating directory %s for volume %s", hostPath.Path, volume.Name)
+				}
+			case v1.HostPathDirectory:
+				if info, err := os.Stat(hostPath.Path); err != nil || !info.IsDir() {
+					return nil, errors.Errorf("host path %s of volume %s is not a directory", hostPath.Path, volume.Name)
+				}
+			case v1.HostPathFile:
+				if info, err := os.Stat(hostPath.Path); err != nil || !info.Mode().IsRegular() {
+					return nil, errors.Errorf("host path %s of volume %s is not a file", hostPath.Path, volume.Name)
+				}
+			case v1.HostPathUnset:
+			default:
+				return nil, errors.Errorf("host path type %s of volume %s is not supported", *hostPath.Type, volume.Name)
+			}
+		}
+
+		hostPaths[volume.Name] = hostPath.Path
+	}
+	return hostPaths, nil
+}
+
+// getKubeImage retrieves the image of a Kubernetes container, pulling it as
+// required by the container's image pull policy
+func getKubeImage(ctx context.Context, c *cli.Context, runtime *libpod.Runtime, containerYAML v1.Container) (*image.Image, error) {
+	pullPolicy := containerYAML.ImagePullPolicy
+	if pullPolicy == "" {
+		// Kubernetes always pulls images without a tag or tagged
+		// latest, and only pulls other images if they are missing
+		pullPolicy = v1.PullIfNotPresent
+		named, err := reference.ParseNormalizedNamed(containerYAML.Image)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid image name %s of container %s", containerYAML.Image, containerYAML.Name)
+		}
+		if tagged, ok := named.(reference.Tagged); !ok || tagged.Tag() == "latest" {
+			if _, digested := named.(reference.Digested); !digested {
+				pullPolicy = v1.PullAlways
+			}
+		}
+	}
+
+	switch pullPolicy {
+	case v1.PullNever:
+		newImage, err := runtime.ImageRuntime().NewFromLocal(containerYAML.Image)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to find image %s of container %s", containerYAML.Image, containerYAML.Name)
+		}
+		return newImage, nil
+	case v1.PullAlways, v1.PullIfNotPresent:
+	default:
+		return nil, errors.Errorf("invalid image pull policy %s of container %s", pullPolicy, containerYAML.Name)
+	}
+
+	var registryCreds *types.DockerAuthConfig
+	if c.IsSet("creds") {
+		creds, err := util.ParseRegistryCreds(c.String("creds"))
+		if err != nil {
+			return nil, err
+		}
+		registryCreds = creds
+	}
+
+	var writer io.Writer
+	if !c.Bool("quiet") {
+		writer = os.Stderr
+	}
+
+	dockerRegistryOptions := image.DockerRegistryOptions{
+		DockerRegistryCreds:         registryCreds,
+		DockerCertPath:              c.String("cert-dir"),
+		DockerInsecureSkipTLSVerify: !c.BoolT("tls-verify"),
+	}
+	forceSecure := false
+	if c.IsSet("tls-verify") {
+		forceSecure = c.Bool("tls-verify")
+	}
+
+	newImage, err := runtime.ImageRuntime().New(ctx, containerYAML.Image, c.String("signature-policy"), c.String("authfile"), writer, &dockerRegistryOptions, image.SigningOptions{}, pullPolicy == v1.PullAlways, forceSecure)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get image %s of container %s", containerYAML.Image, containerYAML.Name)
+	}
+	return newImage, nil
+}
+
+// kubePodOptions converts a Kubernetes pod into the options of a pod
+// Like Kubernetes pods, the pod has an infra container sharing its network,
+// IPC and UTS namespaces, unless it uses the host's namespaces instead
+func kubePodOptions(podYAML *v1.Pod) []libpod.PodCreateOption {
+	options := []libpod.PodCreateOption{
+		libpod.WithInfraContainer(),
+		libpod.WithPodCgroups(),
+		libpod.WithPodUTS(),
+	}
+	if podYAML.Name != "" {
+		options = append(options, libpod.WithPodName(podYAML.Name))
+	}
+	if len(podYAML.Labels) > 0 {
+		options = append(options, libpod.WithPodLabels(podYAML.Labels))
+	}
+	if !podYAML.Spec.HostIPC {
+		options = append(options, libpod.WithPodIPC())
+	}
+	if !podYAML.Spec.HostNetwork {
+		options = append(options, libpod.WithPodNet())
+
+		// Containers of the pod share the network namespace of the
+		// infra container, so it publishes all their host ports
+		var portBindings []ocicni.PortMapping
+		for _, containerYAML := range podYAML.Spec.Containers {
+			portBindings = append(portBindings, kubePortsToPortMappings(containerYAML.Ports)...)
+		}
+		if len(portBindings) > 0 {
+			options = append(options, libpod.WithInfraPortBindings(portBindings))
+		}
+	}
+	return options
+}
+
+// kubePortsToPortMappings converts the ports of a Kubernetes container that
+// are bound to a host port into port mappings
+func kubePortsToPortMappings(ports []v1.ContainerPort) []ocicni.PortMapping {
+	var mappings []ocicni.PortMapping
+	for _, port := range ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		protocol := strings.ToLower(string(port.Protocol))
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		mappings = append(mappings, ocicni.PortMapping{
+			HostPort:      port.HostPort,
+			ContainerPort: port.ContainerPort,
+			Protocol:      protocol,
+			HostIP:        port.HostIP,
+		})
+	}
+	return mappings
+}
+
+// kubeContainerToCreateConfig converts a Kubernetes container into the create
+// config of a container in the given pod
+func kubeContainerToCreateConfig(ctx context.Context, runtime *libpod.Runtime, containerYAML v1.Container, newImage *image.Image, pod *libpod.Pod, podSpec *v1.PodSpec, volumes map[string]string) (*cc.CreateConfig, error) {
+	data, err := newImage.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imageName := newImage.ID()
+	if len(newImage.Names()) > 0 {
+		imageName = newImage.Names()[0]
+	}
+
+	idmappings, err := util.ParseIDMapping(nil, nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	shmSize, err := units.FromHumanSize("65536k")
+	if err != nil {
+		return nil, err
+	}
+
+	// COMMAND
+	// The Kubernetes command replaces the image's entrypoint and ignores
+	// its CMD, and the Kubernetes args replace the image's CMD
+	entrypoint := data.ContainerConfig.Entrypoint
+	args := data.ContainerConfig.Cmd
+	if len(containerYAML.Command) > 0 {
+		entrypoint = containerYAML.Command
+		args = nil
+	}
+	if len(containerYAML.Args) > 0 {
+		args = containerYAML.Args
+	}
+	command := make([]string, 0, len(entrypoint)+len(args))
+	command = append(command, entrypoint...)
+	command = append(command, args...)
+	if len(command) == 0 {
+		return nil, errors.Errorf("no command specified in Kubernetes YAML or as CMD or ENTRYPOINT in image %s", imageName)
+	}
+
+	// ENVIRONMENT VARIABLES
+	env := make(map[string]string)
+	for key, val := range defaultEnvVariables {
+		env[key] = val
+	}
+	for _, e := range data.ContainerConfig.Env {
+		split := strings.SplitN(e, "=", 2)
+		if len(split) > 1 {
+			env[split[0]] = split[1]
+		} else {
+			env[split[0]] = ""
+		}
+	}
+	for _, e := range containerYAML.Env {
+		if e.ValueFrom != nil {
+			logrus.Warnf("ignoring environment variable %s of container %s: valueFrom is not supported", e.Name, containerYAML.Name)
+			continue
+		}
+		env[e.Name] = e.Value
+	}
+
+	// WORKING DIRECTORY
+	workDir := "/"
+	if containerYAML.WorkingDir != "" {
+		workDir = containerYAML.WorkingDir
+	} else if data.ContainerConfig.WorkingDir != "" {
+		workDir = data.ContainerConfig.WorkingDir
+	}
+
+	// STOP SIGNAL
+	stopSignal := syscall.SIGTERM
+	if data.ContainerConfig.StopSignal != "" {
+		stopSignal, err = signal.ParseSignal(data.ContainerConfig.StopSignal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// VOLUMES
+	var binds []string
+	for _, volumeMount := range containerYAML.VolumeMounts {
+		hostPath, ok := volumes[volumeMount.Name]
+		if !ok {
+			return nil, errors.Errorf("volume %s mounted by container %s is not defined in the pod", volumeMount.Name, containerYAML.Name)
+		}
+		bind := fmt.Sprintf("%s:%s", hostPath, volumeMount.MountPath)
+		if volumeMount.ReadOnly {
+			bind += ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	if err := parseVolumes(binds); err != nil {
+		return nil, err
+	}
+
+	// NAMESPACES
+	netMode := "bridge"
+	if podSpec.HostNetwork {
+		netMode = "host"
+	}
+	ipcMode := container.IpcMode("")
+	shmDir := ""
+	if podSpec.HostIPC {
+		ipcMode = container.IpcMode("host")
+		shmDir = "/dev/shm"
+	}
+	pidMode := container.PidMode("")
+	if podSpec.HostPID {
+		pidMode = container.PidMode("host")
+	}
+
+	config := &cc.CreateConfig{
+		Runtime:           runtime,
+		BuiltinImgVolumes: data.ContainerConfig.Volumes,
+		ImageVolumeType:   "bind",
+		Command:           command,
+		Entrypoint:        entrypoint,
+		Env:               env,
+		IDMappings:        idmappings,
+		Image:             imageName,
+		ImageID:           data.ID,
+		Interactive:       containerYAML.Stdin,
+		Labels:            data.ContainerConfig.Labels,
+		Name:              containerYAML.Name,
+		Network:           netMode,
+		NetMode:           container.NetworkMode(netMode),
+		IpcMode:           ipcMode,
+		PidMode:           pidMode,
+		Pod:               pod.ID(),
+		PortBindings:      nat.PortMap{},
+		Resources: cc.CreateResourceConfig{
+			ShmSize: shmSize,
+		},
+		ShmDir:     shmDir,
+		StopSignal: stopSignal,
+		Tty:        containerYAML.TTY,
+		User:       data.ContainerConfig.User,
+		Volumes:    binds,
+		WorkDir:    workDir,
+	}
+
+	if err := kubeResourcesToCreateConfig(config, containerYAML.Resources); err != nil {
+		return nil, errors.Wrapf(err, "invalid resources of container %s", containerYAML.Name)
+	}
+
+	var securityOpts []string
+	if securityContext := containerYAML.SecurityContext; securityContext != nil {
+		if securityContext.Privileged != nil {
+			config.Privileged = *securityContext.Privileged
+		}
+		if securityContext.ReadOnlyRootFilesystem != nil {
+			config.ReadOnlyRootfs = *securityContext.ReadOnlyRootFilesystem
+		}
+		if securityContext.AllowPrivilegeEscalation != nil && !*securityContext.AllowPrivilegeEscalation {
+			securityOpts = append(securityOpts, "no-new-privileges")
+		}
+		if securityContext.RunAsUser != nil {
+			config.User = strconv.FormatInt(*securityContext.RunAsUser, 10)
+		}
+		if caps := securityContext.Capabilities; caps != nil {
+			for _, capability := range caps.Add {
+				config.CapAdd = append(config.CapAdd, string(capability))
+			}
+			for _, capability := range caps.Drop {
+				config.CapDrop = append(config.CapDrop, string(capability))
+			}
+		}
+		if seopt := securityContext.SELinuxOptions; seopt != nil {
+			if seopt.User != "" {
+				securityOpts = append(securityOpts, fmt.Sprintf("label=user:%s", seopt.User))
+			}
+			if seopt.Role != "" {
+				securityOpts = append(securityOpts, fmt.Sprintf("label=role:%s", seopt.Role))
+			}
+			if seopt.Type != "" {
+				securityOpts = append(securityOpts, fmt.Sprintf("label=type:%s", seopt.Type))
+			}
+			if seopt.Level != "" {
+				securityOpts = append(securityOpts, fmt.Sprintf("label=level:%s", seopt.Level))
+			}
+		}
+	}
+
+	if !config.Privileged {
+		if err := parseSecurityOpt(config, securityOpts); err != nil {
+			return nil, err
+		}
+	}
+	config.SecurityOpts = securityOpts
+
+	warnings, err := verifyContainerResources(config, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	return config, nil
+}
+
+// kubeResourcesToCreateConfig sets the resource limits of a create config from
+// the resource requirements of a Kubernetes container
+// CPU limits become a CFS quota, and CPU requests become CPU shares relative to
+// the 1024 shares of a full CPU, as done by the kubelet
+func kubeResourcesToCreateConfig(config *cc.CreateConfig, resources v1.ResourceRequirements) error {
+	if memory, ok := resources.Limits[v1.ResourceMemory]; ok {
+		config.Resources.Memory = memory.Value()
+	}
+	if memory, ok := resources.Requests[v1.ResourceMemory]; ok {
+		config.Resources.MemoryReservation = memory.Value()
+	}
+	if cpu, ok := resources.Limits[v1.ResourceCPU]; ok {
+		config.Resources.CPUPeriod = libpod.DefaultCPUPeriod
+		config.Resources.CPUQuota = cpu.MilliValue() * libpod.DefaultCPUPeriod / 1000
+	}
+	if cpu, ok := resources.Requests[v1.ResourceCPU]; ok {
+		config.Resources.CPUShares = cc.MilliCPUToShares(cpu.MilliValue())
+	}
+	if config.Resources.Memory != 0 && config.Resources.MemoryReservation > config.Resources.Memory {
+		return errors.Errorf("memory request must not exceed the memory limit")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cc "github.com/projectatomic/libpod/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestKubePortsToPortMappings(t *testing.T) {
+	ports := []v1.ContainerPort{
+		{ContainerPort: 80, HostPort: 8080, Protocol: v1.ProtocolTCP},
+		{ContainerPort: 53, HostPort: 5353, Protocol: v1.ProtocolUDP},
+		{ContainerPort: 443},
+		{ContainerPort: 22, HostPort: 2222},
+	}
+	mappings := kubePortsToPortMappings(ports)
+	assert.Len(t, mappings, 3)
+	assert.Equal(t, "tcp", mappings[0].Protocol)
+	assert.Equal(t, int32(8080), mappings[0].HostPort)
+	assert.Equal(t, "udp", mappings[1].Protocol)
+	assert.Equal(t, "tcp", mappings[2].Protocol)
+}
+
+func TestKubeResourcesToCreateConfig(t *testing.T) {
+	config := new(cc.CreateConfig)
+	resources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("512Mi"),
+			v1.ResourceCPU:    resource.MustParse("1500m"),
+		},
+		Requests: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("256Mi"),
+			v1.ResourceCPU:    resource.MustParse("500m"),
+		},
+	}
+	assert.NoError(t, kubeResourcesToCreateConfig(config, resources))
+	assert.Equal(t, int64(512*1024*1024), config.Resources.Memory)
+	assert.Equal(t, int64(256*1024*1024), config.Resources.MemoryReservation)
+	assert.Equal(t, uint64(100000), config.Resources.CPUPeriod)
+	assert.Equal(t, int64(150000), config.Resources.CPUQuota)
+	assert.Equal(t, uint64(512), config.Resources.CPUShares)
+}
+
+func TestKubeResourcesMinimumCPUShares(t *testing.T) {
+	config := new(cc.CreateConfig)
+	resources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1m")},
+	}
+	assert.NoError(t, kubeResourcesToCreateConfig(config, resources))
+	assert.Equal(t, uint64(2), config.Resources.CPUShares)
+}
+
+func TestKubeResourcesRequestAboveLimit(t *testing.T) {
+	resources := v1.ResourceRequirements{
+		Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+		Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	assert.Error(t, kubeResourcesToCreateConfig(new(cc.CreateConfig), resources))
+}
+
+func TestKubeHostPathVolumes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podman-play-kube")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	create := v1.HostPathDirectoryOrCreate
+	newDir := filepath.Join(dir, "new")
+	volumes := []v1.Volume{
+		{Name: "data", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: dir}}},
+		{Name: "created", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: newDir, Type: &create}}},
+	}
+	hostPaths, err := kubeHostPathVolumes(volumes)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"data": dir, "created": newDir}, hostPaths)
+	_, err = os.Stat(newDir)
+	assert.NoError(t, err)
+
+	file := v1.HostPathFile
+	volumes = []v1.Volume{{Name: "file", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: dir, Type: &file}}}}
+	_, err = kubeHostPathVolumes(volumes)
+	assert.Error(t, err)
+
+	volumes = []v1.Volume{{Name: "empty", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	_, err = kubeHostPathVolumes(volumes)
+	assert.Error(t, err)
+}
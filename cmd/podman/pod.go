@@ -0,0 +1,97 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	podDescription = `
+   podman pod
+
+   Manage container pods.
+   Pods are a group of one or more containers that are managed together.
+`
+	podSubCommands = []cli.Command{
+		podCreateCommand,
+		podExistsCommand,
+		podInspectCommand,
+		podKillCommand,
+		podPauseCommand,
+		podPsCommand,
+		podRestartCommand,
+		podRmCommand,
+		podStartCommand,
+		podStatsCommand,
+		podStopCommand,
+		podTopCommand,
+		podUnpauseCommand,
+	}
+	podCommand = cli.Command{
+		Name:                   "pod",
+		Usage:                  "Manage pods",
+		Description:            podDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            podSubCommands,
+	}
+
+	LatestPodFlag = cli.BoolFlag{
+		Name:  "latest, l",
+		Usage: "act on the latest pod podman is aware of",
+	}
+)
+
+// getPodsFromContext returns the pods named on the command line, all pods if
+// --all was given, or the latest pod if --latest was given
+func getPodsFromContext(c *cli.Context, r *libpod.Runtime) ([]*libpod.Pod, error) {
+	args := c.Args()
+	var pods []*libpod.Pod
+	var lastError error
+	var err error
+
+	if c.Bool("all") {
+		pods, err = r.Pods()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get pods")
+		}
+	}
+
+	if c.Bool("latest") {
+		pod, err := r.GetLatestPod()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get latest pod")
+		}
+		pods = append(pods, pod)
+	}
+
+	for _, i := range args {
+		pod, err := r.LookupPod(i)
+		if err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to find pod %s", i)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, lastError
+}
+
+// checkAllAndLatest verifies that exactly one of --all, --latest or a list of
+// pods was given
+func checkAllAndLatest(c *cli.Context) error {
+	argLen := len(c.Args())
+	if (c.Bool("all") || c.Bool("latest")) && argLen > 0 {
+		return errors.Errorf("no arguments are needed with --all or --latest")
+	}
+	if c.Bool("all") && c.Bool("latest") {
+		return errors.Errorf("--all and --latest cannot be used together")
+	}
+	if argLen < 1 && !c.Bool("all") && !c.Bool("latest") {
+		return errors.Errorf("you must provide at least one pod name or id")
+	}
+	return nil
+}
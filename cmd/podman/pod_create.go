@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/cmd/podman/shared"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var podCreateDescription = "Creates a new empty pod. The pod ID is then" +
+	" printed to stdout. You can then start it at any time with the" +
+	" podman pod start <pod_id> command. The pod will be created with the" +
+	" initial state 'created'. Unless --infra=false is given, an infra" +
+	" container holding the namespaces shared by the pod is created with it."
+
+var podCreateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "cgroup-parent",
+		Usage: "Set parent cgroup for the pod",
+	},
+	cli.Uint64Flag{
+		Name:  "cpu-period",
+		Usage: "Limit the CPU CFS (Completely Fair Scheduler) period of the pod",
+	},
+	cli.Int64Flag{
+		Name:  "cpu-quota",
+		Usage: "Limit the CPU CFS (Completely Fair Scheduler) quota of the pod",
+	},
+	cli.Uint64Flag{
+		Name:  "cpu-shares",
+		Usage: "CPU shares (relative weight) of the pod",
+	},
+	cli.Float64Flag{
+		Name:  "cpus",
+		Usage: "Number of CPUs shared by the containers of the pod. The default is 0.000 which means no limit",
+	},
+	cli.StringFlag{
+		Name:  "cpuset-cpus",
+		Usage: "CPUs in which to allow execution for the containers of the pod (0-3, 0,1)",
+	},
+	cli.StringFlag{
+		Name:  "cpuset-mems",
+		Usage: "Memory nodes (MEMs) in which to allow execution for the containers of the pod (0-3, 0,1). Only effective on NUMA systems.",
+	},
+	cli.BoolTFlag{
+		Name:  "infra",
+		Usage: "Create an infra container associated with the pod to share namespaces with",
+	},
+	cli.StringFlag{
+		Name:  "infra-command",
+		Usage: "The command to run on the infra container when the pod is started",
+	},
+	cli.StringFlag{
+		Name:  "infra-image",
+		Usage: "The image of the infra container to associate with the pod",
+	},
+	cli.StringSliceFlag{
+		Name:  "label-file",
+		Usage: "Read in a line delimited file of labels (default [])",
+	},
+	cli.StringSliceFlag{
+		Name:  "label, l",
+		Usage: "Set metadata on pod (default [])",
+	},
+	cli.StringFlag{
+		Name:  "memory, m",
+		Usage: "Memory limit shared by the containers of the pod (format: <number>[<unit>], where unit = b, k, m or g)",
+	},
+	cli.StringFlag{
+		Name:  "name, n",
+		Usage: "Assign a name to the pod",
+	},
+	cli.Int64Flag{
+		Name:  "pids-limit",
+		Usage: "Limit the number of processes shared by the containers of the pod",
+	},
+	cli.StringFlag{
+		Name:  "podidfile",
+		Usage: "Write the pod ID to the file",
+	},
+	cli.StringFlag{
+		Name:  "share",
+		Usage: "A comma delimited list of kernel namespaces the pod will share",
+		Value: shared.DefaultPodNamespaces,
+	},
+}
+
+var podCreateCommand = cli.Command{
+	Name:                   "create",
+	Usage:                  "create a new empty pod",
+	Description:            podCreateDescription,
+	Flags:                  podCreateFlags,
+	Action:                 podCreateCmd,
+	SkipArgReorder:         true,
+	UseShortOptionHandling: true,
+}
+
+func podCreateCmd(c *cli.Context) error {
+	var options []libpod.PodCreateOption
+	var err error
+
+	if err = validateFlags(c, podCreateFlags); err != nil {
+		return err
+	}
+
+	if len(c.Args()) > 0 {
+		return errors.Errorf("too many arguments, pod create takes no arguments")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if c.IsSet("podidfile") {
+		if _, err = os.Stat(c.String("podidfile")); err == nil {
+			return errors.Errorf("pod id file exists. ensure another pod is not using it or delete %s", c.String("podidfile"))
+		}
+	}
+
+	if c.IsSet("cgroup-parent") {
+		options = append(options, libpod.WithPodCgroupParent(c.String("cgroup-parent")))
+	}
+
+	labels, err := getAllLabels(c.StringSlice("label-file"), c.StringSlice("label"))
+	if err != nil {
+		return errors.Wrapf(err, "unable to process labels")
+	}
+	if len(labels) != 0 {
+		options = append(options, libpod.WithPodLabels(labels))
+	}
+
+	if c.IsSet("name") {
+		options = append(options, libpod.WithPodName(c.String("name")))
+	}
+
+	if c.Bool("infra") {
+		options = append(options, libpod.WithInfraContainer())
+		if c.IsSet("infra-image") {
+			options = append(options, libpod.WithInfraImage(c.String("infra-image")))
+		}
+		if c.IsSet("infra-command") {
+			options = append(options, libpod.WithInfraCommand(strings.Fields(c.String("infra-command"))))
+		}
+		nsOptions, err := shared.GetPodNamespaceOptions(c.String("share"))
+		if err != nil {
+			return err
+		}
+		options = append(options, nsOptions...)
+	} else {
+		if c.IsSet("share") && c.String("share") != "" {
+			return errors.Errorf("you must have an infra container to share namespaces")
+		}
+		if c.IsSet("infra-image") || c.IsSet("infra-command") {
+			return errors.Errorf("--infra-image and --infra-command cannot be used without an infra container")
+		}
+	}
+
+	resourceOptions, err := getPodResourceOptions(c)
+	if err != nil {
+		return err
+	}
+	options = append(options, resourceOptions...)
+
+	// always have containers use pod cgroups
+	options = append(options, libpod.WithPodCgroups())
+
+	pod, err := runtime.NewPod(getContext(), options...)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("podidfile") {
+		if err := libpod.WriteFile(pod.ID(), c.String("podidfile")); err != nil {
+			return errors.Wrapf(err, "unable to write pod id file %s", c.String("podidfile"))
+		}
+	}
+
+	fmt.Printf("%s\n", pod.ID())
+	return nil
+}
+
+// getPodResourceOptions parses the resource limit flags of pod create into pod
+// create options
+func getPodResourceOptions(c *cli.Context) ([]libpod.PodCreateOption, error) {
+	var options []libpod.PodCreateOption
+
+	if c.String("memory") != "" {
+		memoryLimit, err := units.RAMInBytes(c.String("memory"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for memory")
+		}
+		options = append(options, libpod.WithPodMemoryLimit(memoryLimit))
+	}
+
+	if c.IsSet("cpu-shares") {
+		options = append(options, libpod.WithPodCPUShares(c.Uint64("cpu-shares")))
+	}
+
+	if c.Float64("cpus") > 0 {
+		if c.IsSet("cpu-period") || c.IsSet("cpu-quota") {
+			return nil, errors.Errorf("--cpus cannot be set together with --cpu-period or --cpu-quota")
+		}
+		quota := int64(c.Float64("cpus") * libpod.DefaultCPUPeriod)
+		options = append(options, libpod.WithPodCPUQuota(quota, libpod.DefaultCPUPeriod))
+	} else if c.IsSet("cpu-quota") {
+		options = append(options, libpod.WithPodCPUQuota(c.Int64("cpu-quota"), c.Uint64("cpu-period")))
+	} else if c.IsSet("cpu-period") {
+		return nil, errors.Errorf("--cpu-period requires --cpu-quota")
+	}
+
+	if c.String("cpuset-cpus") != "" || c.String("cpuset-mems") != "" {
+		options = append(options, libpod.WithPodCPUSet(c.String("cpuset-cpus"), c.String("cpuset-mems")))
+	}
+
+	if c.IsSet("pids-limit") {
+		options = append(options, libpod.WithPodPidsLimit(c.Int64("pids-limit")))
+	}
+
+	return options, nil
+}
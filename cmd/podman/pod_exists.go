@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	podExistsDescription = `
+	podman pod exists
+
+	Check if a pod exists in local storage
+`
+
+	podExistsCommand = cli.Command{
+		Name:        "exists",
+		Usage:       "Check if a pod exists in local storage",
+		Description: podExistsDescription,
+		Action:      podExistsCmd,
+		ArgsUsage:   "POD-NAME",
+	}
+)
+
+// podExistsCmd exits with 0 if the pod exists and 1 if it does not
+func podExistsCmd(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return errors.New("you may only check for the existence of one pod at a time")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if _, err := runtime.LookupPod(args[0]); err != nil {
+		if errors.Cause(err) == libpod.ErrNoSuchPod {
+			exitCode = 1
+			return nil
+		}
+		return err
+	}
+	return nil
+}
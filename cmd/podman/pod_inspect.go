@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	podInspectFlags = []cli.Flag{
+		LatestPodFlag,
+	}
+	podInspectDescription = "display the configuration for a pod by name or id"
+	podInspectCommand     = cli.Command{
+		Name:                   "inspect",
+		Usage:                  "displays a pod configuration",
+		Description:            podInspectDescription,
+		Flags:                  podInspectFlags,
+		Action:                 podInspectCmd,
+		UseShortOptionHandling: true,
+		ArgsUsage:              "[POD_NAME_OR_ID]",
+	}
+)
+
+func podInspectCmd(c *cli.Context) error {
+	var (
+		pod *libpod.Pod
+	)
+	if err := validateFlags(c, podInspectFlags); err != nil {
+		return err
+	}
+	args := c.Args()
+	if len(args) < 1 && !c.Bool("latest") {
+		return errors.Errorf("you must provide the name or id of a pod")
+	}
+	if len(args) > 0 && c.Bool("latest") {
+		return errors.Errorf("you cannot provide a pod name or id with --latest")
+	}
+	if len(args) > 1 {
+		return errors.Errorf("you can only inspect one pod at a time")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if c.Bool("latest") {
+		pod, err = runtime.GetLatestPod()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get latest pod")
+		}
+	} else {
+		pod, err = runtime.LookupPod(args[0])
+		if err != nil {
+			return errors.Wrapf(err, "unable to find pod %s", args[0])
+		}
+	}
+
+	podInspectData, err := pod.Inspect()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(&podInspectData, "", "     ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
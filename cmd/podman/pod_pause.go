@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	podPauseFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "pause all pods",
+		},
+		LatestPodFlag,
+	}
+	podPauseDescription = `
+   podman pod pause
+
+   Pauses all the running containers of one or more pods.  The pod name or ID
+   can be used.
+`
+
+	podPauseCommand = cli.Command{
+		Name:                   "pause",
+		Usage:                  "Pause one or more pods",
+		Description:            podPauseDescription,
+		Flags:                  podPauseFlags,
+		Action:                 podPauseCmd,
+		ArgsUsage:              "POD-NAME [POD-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func podPauseCmd(c *cli.Context) error {
+	if err := validateFlags(c, podPauseFlags); err != nil {
+		return err
+	}
+	if err := checkAllAndLatest(c); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	// getPodsFromContext returns an error when a requested pod
+	// isn't found. The only fatal error scenerio is when there are no pods
+	// in which case the following loop will be skipped.
+	pods, lastError := getPodsFromContext(c, runtime)
+
+	for _, pod := range pods {
+		ctrErrs, err := pod.Pause()
+		if err != nil {
+			for ctr, err := range ctrErrs {
+				logrus.Errorf("error pausing container %s: %v", ctr, err)
+			}
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to pause pod %q", pod.ID())
+			continue
+		}
+		fmt.Println(pod.ID())
+	}
+
+	return lastError
+}
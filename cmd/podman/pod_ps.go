@@ -0,0 +1,477 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/cmd/podman/shared"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/projectatomic/libpod/pkg/util"
+	"github.com/urfave/cli"
+)
+
+type podPsOptions struct {
+	NoTrunc   bool
+	Format    string
+	Quiet     bool
+	Latest    bool
+	Filter    string
+	CtrNames  bool
+	CtrIDs    bool
+	CtrStatus bool
+	Cgroup    bool
+}
+
+type podPsTemplateParams struct {
+	ID                 string
+	Name               string
+	Status             string
+	Created            string
+	Labels             string
+	Cgroup             string
+	NumberOfContainers int
+	ContainerIDs       string
+	ContainerNames     string
+	ContainerStatuses  string
+}
+
+// podPsJSONParams is used when the JSON format is specified,
+// and is better for data processing from JSON.
+// podPsJSONParams will be populated by data from libpod.Pod,
+// the members of the struct are the sama data types as their sources.
+type podPsJSONParams struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	Status             string            `json:"status"`
+	CreatedAt          time.Time         `json:"createdAt"`
+	Labels             map[string]string `json:"labels"`
+	CgroupParent       string            `json:"cgroupParent"`
+	NumberOfContainers int               `json:"numberOfContainers"`
+	Containers         []podPsCtrInfo    `json:"containerInfo"`
+}
+
+// podPsCtrInfo contains the information on a pod member shown by pod ps
+type podPsCtrInfo struct {
+	Name   string `json:"name"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+var (
+	podPsFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "cgroup",
+			Usage: "Print the cgroup parent of the pod",
+		},
+		cli.BoolFlag{
+			Name:  "ctr-names",
+			Usage: "Display the names of the containers in the pod",
+		},
+		cli.BoolFlag{
+			Name:  "ctr-ids",
+			Usage: "Display the IDs of the containers in the pod",
+		},
+		cli.BoolFlag{
+			Name:  "ctr-status",
+			Usage: "Display the statuses of the containers in the pod",
+		},
+		cli.StringFlag{
+			Name:  "filter, f",
+			Usage: "Filter output based on conditions given",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Pretty-print pods to JSON or using a Go template",
+		},
+		cli.BoolFlag{
+			Name:  "latest, l",
+			Usage: "Show the latest pod created",
+		},
+		cli.BoolFlag{
+			Name:  "no-trunc",
+			Usage: "Do not truncate pod and container IDs",
+		},
+		cli.BoolFlag{
+			Name:  "quiet, q",
+			Usage: "Print the numeric IDs of the pods only",
+		},
+	}
+	podPsDescription = "List all pods on system including their names, ids and current state."
+	podPsCommand     = cli.Command{
+		Name:                   "ps",
+		Aliases:                []string{"ls", "list"},
+		Usage:                  "List pods",
+		Description:            podPsDescription,
+		Flags:                  podPsFlags,
+		Action:                 podPsCmd,
+		UseShortOptionHandling: true,
+	}
+)
+
+func podPsCmd(c *cli.Context) error {
+	if err := validateFlags(c, podPsFlags); err != nil {
+		return err
+	}
+
+	if err := podPsCheckFlagsPassed(c); err != nil {
+		return errors.Wrapf(err, "error with flags passed")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if len(c.Args()) > 0 {
+		return errors.Errorf("too many arguments, pod ps takes no arguments")
+	}
+
+	opts := podPsOptions{
+		NoTrunc:   c.Bool("no-trunc"),
+		Quiet:     c.Bool("quiet"),
+		Latest:    c.Bool("latest"),
+		Filter:    c.String("filter"),
+		CtrNames:  c.Bool("ctr-names"),
+		CtrIDs:    c.Bool("ctr-ids"),
+		CtrStatus: c.Bool("ctr-status"),
+		Cgroup:    c.Bool("cgroup"),
+	}
+	opts.Format = genPodPsFormat(c.String("format"), opts)
+
+	var filterFuncs []libpod.PodFilter
+	if opts.Filter != "" {
+		filters := strings.Split(opts.Filter, ",")
+		for _, f := range filters {
+			filterSplit := strings.SplitN(f, "=", 2)
+			if len(filterSplit) < 2 {
+				return errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
+			}
+			generatedFunc, err := generatePodFilterFuncs(filterSplit[0], filterSplit[1])
+			if err != nil {
+				return errors.Wrapf(err, "invalid filter")
+			}
+			filterFuncs = append(filterFuncs, generatedFunc)
+		}
+	}
+
+	var pods []*libpod.Pod
+	if opts.Latest {
+		pod, err := runtime.GetLatestPod()
+		if err != nil {
+			return err
+		}
+		pods = append(pods, pod)
+	} else {
+		pods, err = runtime.Pods(filterFuncs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return generatePodPsOutput(pods, opts)
+}
+
+// podPsCheckFlagsPassed checks if mutually exclusive flags are passed together
+func podPsCheckFlagsPassed(c *cli.Context) error {
+	// quiet and format with Go template are mutually exclusive
+	flags := 0
+	if c.Bool("quiet") {
+		flags++
+	}
+	if c.IsSet("format") && c.String("format") != formats.JSONString {
+		flags++
+	}
+	if flags > 1 {
+		return errors.Errorf("quiet and format with Go template are mutually exclusive")
+	}
+	return nil
+}
+
+func generatePodFilterFuncs(filter, filterValue string) (func(pod *libpod.Pod) bool, error) {
+	switch filter {
+	case "ctr-ids":
+		return func(p *libpod.Pod) bool {
+			ctrIds, err := p.AllContainersByID()
+			if err != nil {
+				return false
+			}
+			for _, id := range ctrIds {
+				if strings.Contains(id, filterValue) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "ctr-names":
+		return func(p *libpod.Pod) bool {
+			ctrs, err := p.AllContainers()
+			if err != nil {
+				return false
+			}
+			for _, ctr := range ctrs {
+				if filterValue == ctr.Name() {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "ctr-number":
+		ctrNum, err := strconv.Atoi(filterValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "number of containers must be an integer %q", filterValue)
+		}
+		return func(p *libpod.Pod) bool {
+			ctrIds, err := p.AllContainersByID()
+			if err != nil {
+				return false
+			}
+			return len(ctrIds) == ctrNum
+		}, nil
+	case "ctr-status":
+		if !util.StringInSlice(filterValue, []string{"created", "running", "paused", "exited", "unknown"}) {
+			return nil, errors.Errorf("%s is not a valid status", filterValue)
+		}
+		return func(p *libpod.Pod) bool {
+			ctrStatuses, err := p.Status()
+			if err != nil {
+				return false
+			}
+			for _, ctrStatus := range ctrStatuses {
+				state := ctrStatus.String()
+				if ctrStatus == libpod.ContainerStateConfigured {
+					state = "created"
+				}
+				if state == filterValue {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "id":
+		return func(p *libpod.Pod) bool {
+			return strings.Contains(p.ID(), filterValue)
+		}, nil
+	case "name":
+		return func(p *libpod.Pod) bool {
+			return strings.Contains(p.Name(), filterValue)
+		}, nil
+	case "label":
+		var filterArray = strings.SplitN(filterValue, "=", 2)
+		var filterKey = filterArray[0]
+		if len(filterArray) > 1 {
+			filterValue = filterArray[1]
+		} else {
+			filterValue = ""
+		}
+		return func(p *libpod.Pod) bool {
+			for labelKey, labelValue := range p.Labels() {
+				if labelKey == filterKey && (filterValue == "" || labelValue == filterValue) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "status":
+		if !util.StringInSlice(filterValue, []string{"stopped", "running", "paused", "exited", "error", "created"}) {
+			return nil, errors.Errorf("%s is not a valid pod status", filterValue)
+		}
+		return func(p *libpod.Pod) bool {
+			ctrStatuses, err := p.Status()
+			if err != nil {
+				return false
+			}
+			return strings.ToLower(shared.GetPodStatus(ctrStatuses)) == filterValue
+		}, nil
+	}
+	return nil, errors.Errorf("%s is an invalid filter", filter)
+}
+
+// generate the template based on conditions given
+func genPodPsFormat(format string, opts podPsOptions) string {
+	if format != "" {
+		// "\t" from the command line is not being recognized as a tab
+		// replacing the string "\t" to a tab character if the user passes in "\t"
+		return strings.Replace(format, `\t`, "\t", -1)
+	}
+	if opts.Quiet {
+		return formats.IDString
+	}
+	format = "table {{.ID}}\t{{.Name}}\t{{.Status}}\t{{.Created}}"
+	if opts.Cgroup {
+		format += "\t{{.Cgroup}}"
+	}
+	if opts.CtrNames || opts.CtrIDs || opts.CtrStatus {
+		if opts.CtrNames {
+			format += "\t{{.ContainerNames}}"
+		}
+		if opts.CtrIDs {
+			format += "\t{{.ContainerIDs}}"
+		}
+		if opts.CtrStatus {
+			format += "\t{{.ContainerStatuses}}"
+		}
+	} else {
+		format += "\t{{.NumberOfContainers}}"
+	}
+	return format
+}
+
+func podPsToGeneric(templParams []podPsTemplateParams, JSONParams []podPsJSONParams) (genericParams []interface{}) {
+	if len(templParams) > 0 {
+		for _, v := range templParams {
+			genericParams = append(genericParams, interface{}(v))
+		}
+		return
+	}
+	for _, v := range JSONParams {
+		genericParams = append(genericParams, interface{}(v))
+	}
+	return
+}
+
+// generate the accurate header based on template given
+func (p *podPsTemplateParams) podHeaderMap() map[string]string {
+	v := reflect.Indirect(reflect.ValueOf(p))
+	values := make(map[string]string)
+
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Name
+		value := key
+		if value == "ID" {
+			value = "Pod" + value
+		}
+		if value == "NumberOfContainers" {
+			value = "#OfContainers"
+		}
+		values[key] = strings.ToUpper(splitCamelCase(value))
+	}
+	return values
+}
+
+// getPodCtrInfo returns the name, ID and status of every container in the pod
+func getPodCtrInfo(pod *libpod.Pod) ([]podPsCtrInfo, map[string]libpod.ContainerStatus, error) {
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+	ctrStatuses := make(map[string]libpod.ContainerStatus, len(ctrs))
+	ctrsInfo := make([]podPsCtrInfo, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err != nil {
+			// The container was removed out from under us, skip it
+			if errors.Cause(err) == libpod.ErrNoSuchCtr || errors.Cause(err) == libpod.ErrCtrRemoved {
+				continue
+			}
+			return nil, nil, err
+		}
+		ctrStatuses[ctr.ID()] = state
+		ctrsInfo = append(ctrsInfo, podPsCtrInfo{
+			Name:   ctr.Name(),
+			ID:     ctr.ID(),
+			Status: state.String(),
+		})
+	}
+	return ctrsInfo, ctrStatuses, nil
+}
+
+// getPodTemplateOutput returns the modified pod information
+func getPodTemplateOutput(pods []*libpod.Pod, opts podPsOptions) ([]podPsTemplateParams, error) {
+	var psOutput []podPsTemplateParams
+
+	for _, pod := range pods {
+		ctrsInfo, ctrStatuses, err := getPodCtrInfo(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		podID := pod.ID()
+		var ctrIDs, ctrNames, ctrStates []string
+		for _, ctrInfo := range ctrsInfo {
+			ctrID := ctrInfo.ID
+			if !opts.NoTrunc {
+				ctrID = shortID(ctrID)
+			}
+			ctrIDs = append(ctrIDs, ctrID)
+			ctrNames = append(ctrNames, ctrInfo.Name)
+			ctrStates = append(ctrStates, ctrInfo.Status)
+		}
+		if !opts.NoTrunc {
+			podID = shortID(podID)
+		}
+
+		params := podPsTemplateParams{
+			ID:                 podID,
+			Name:               pod.Name(),
+			Status:             shared.GetPodStatus(ctrStatuses),
+			Created:            units.HumanDuration(time.Since(pod.CreatedTime())) + " ago",
+			Labels:             formatLabels(pod.Labels()),
+			Cgroup:             pod.CgroupParent(),
+			NumberOfContainers: len(ctrsInfo),
+			ContainerIDs:       strings.Join(ctrIDs, ","),
+			ContainerNames:     strings.Join(ctrNames, ","),
+			ContainerStatuses:  strings.Join(ctrStates, ","),
+		}
+		psOutput = append(psOutput, params)
+	}
+	return psOutput, nil
+}
+
+// getPodJSONOutput returns the pod info in its raw form
+func getPodJSONOutput(pods []*libpod.Pod) ([]podPsJSONParams, error) {
+	var psOutput []podPsJSONParams
+
+	for _, pod := range pods {
+		ctrsInfo, ctrStatuses, err := getPodCtrInfo(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		params := podPsJSONParams{
+			ID:                 pod.ID(),
+			Name:               pod.Name(),
+			Status:             shared.GetPodStatus(ctrStatuses),
+			CreatedAt:          pod.CreatedTime(),
+			Labels:             pod.Labels(),
+			CgroupParent:       pod.CgroupParent(),
+			NumberOfContainers: len(ctrsInfo),
+			Containers:         ctrsInfo,
+		}
+		psOutput = append(psOutput, params)
+	}
+	return psOutput, nil
+}
+
+func generatePodPsOutput(pods []*libpod.Pod, opts podPsOptions) error {
+	if len(pods) == 0 && opts.Format != formats.JSONString {
+		return nil
+	}
+	var out formats.Writer
+
+	switch opts.Format {
+	case formats.JSONString:
+		psOutput, err := getPodJSONOutput(pods)
+		if err != nil {
+			return errors.Wrapf(err, "unable to create JSON for output")
+		}
+		out = formats.JSONStructArray{Output: podPsToGeneric([]podPsTemplateParams{}, psOutput)}
+	default:
+		psOutput, err := getPodTemplateOutput(pods, opts)
+		if err != nil {
+			return errors.Wrapf(err, "unable to create output")
+		}
+		if len(psOutput) == 0 {
+			return nil
+		}
+		out = formats.StdoutTemplateArray{Output: podPsToGeneric(psOutput, []podPsJSONParams{}), Template: opts.Format, Fields: psOutput[0].podHeaderMap()}
+	}
+
+	return formats.Writer(out).Out()
+}
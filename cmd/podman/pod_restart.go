@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	podRestartFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "restart all pods",
+		},
+		LatestPodFlag,
+	}
+	podRestartDescription = `
+   podman pod restart
+
+   Restarts one or more pods.  The pod name or ID can be used.
+   Containers are stopped in the reverse of the order they are started in,
+   then started again in dependency order.
+`
+
+	podRestartCommand = cli.Command{
+		Name:                   "restart",
+		Usage:                  "Restart one or more pods",
+		Description:            podRestartDescription,
+		Flags:                  podRestartFlags,
+		Action:                 podRestartCmd,
+		ArgsUsage:              "POD-NAME [POD-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func podRestartCmd(c *cli.Context) error {
+	if err := validateFlags(c, podRestartFlags); err != nil {
+		return err
+	}
+	if err := checkAllAndLatest(c); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	// getPodsFromContext returns an error when a requested pod
+	// isn't found. The only fatal error scenerio is when there are no pods
+	// in which case the following loop will be skipped.
+	pods, lastError := getPodsFromContext(c, runtime)
+
+	ctx := getContext()
+	for _, pod := range pods {
+		ctrErrs, err := pod.Restart(ctx)
+		if err != nil {
+			for ctr, err := range ctrErrs {
+				logrus.Errorf("error restarting container %s: %v", ctr, err)
+			}
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to restart pod %q", pod.ID())
+			continue
+		}
+		fmt.Println(pod.ID())
+	}
+
+	return lastError
+}
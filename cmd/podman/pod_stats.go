@@ -0,0 +1,247 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	tm "github.com/buger/goterm"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+type podStatsOutputParams struct {
+	Pod      string `json:"pod"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	CPUPerc  string `json:"cpu_percent"`
+	MemUsage string `json:"mem_usage"`
+	MemPerc  string `json:"mem_percent"`
+	NetIO    string `json:"netio"`
+	BlockIO  string `json:"blocki"`
+	PIDS     string `json:"pids"`
+}
+
+var (
+	podStatsFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "show stats for all pods. All pods are shown if no pods are given",
+		},
+		cli.BoolFlag{
+			Name:  "containers, c",
+			Usage: "show the stats of each container in the pods instead of the pod totals",
+		},
+		cli.BoolFlag{
+			Name:  "no-stream",
+			Usage: "disable streaming stats and only pull the first result, default setting is false",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "pretty-print pod statistics to JSON or using a Go template",
+		},
+		cli.BoolFlag{
+			Name:  "no-reset",
+			Usage: "disable resetting the screen between intervals",
+		},
+		LatestPodFlag,
+	}
+
+	podStatsDescription = `
+   podman pod stats
+
+   Display a live stream of the resource usage statistics of one or more pods.
+   The usage of all containers in a pod is summed, unless --containers is given.
+`
+	podStatsCommand = cli.Command{
+		Name:                   "stats",
+		Usage:                  "Display percentage of CPU, memory, network I/O, block I/O and PIDs for containers in one or more pods",
+		Description:            podStatsDescription,
+		Flags:                  podStatsFlags,
+		Action:                 podStatsCmd,
+		ArgsUsage:              "[POD-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func podStatsCmd(c *cli.Context) error {
+	if err := validateFlags(c, podStatsFlags); err != nil {
+		return err
+	}
+	if (c.Bool("all") || c.Bool("latest")) && len(c.Args()) > 0 {
+		return errors.Errorf("no arguments are needed with --all or --latest")
+	}
+	if c.Bool("all") && c.Bool("latest") {
+		return errors.Errorf("--all and --latest cannot be used together")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	times := -1
+	if c.Bool("no-stream") {
+		times = 1
+	}
+
+	podFunc := func() ([]*libpod.Pod, error) { return runtime.Pods() }
+	if len(c.Args()) > 0 || c.Bool("latest") {
+		podFunc = func() ([]*libpod.Pod, error) { return getPodsFromContext(c, runtime) }
+	}
+
+	pods, err := podFunc()
+	if err != nil {
+		return errors.Wrapf(err, "unable to get list of pods")
+	}
+
+	podStats := make(map[string]*libpod.PodStats)
+	for _, pod := range pods {
+		initialStats, err := pod.GetPodStats(nil)
+		if err != nil {
+			return err
+		}
+		podStats[pod.ID()] = initialStats
+	}
+
+	format := genPodStatsFormat(c.String("format"), c.Bool("containers"))
+
+	step := 1
+	if times == -1 {
+		times = 1
+		step = 0
+	}
+	for i := 0; i < times; i += step {
+		reportStats := []*libpod.PodStats{}
+		for _, pod := range pods {
+			stats, err := pod.GetPodStats(podStats[pod.ID()])
+			if err != nil {
+				if errors.Cause(err) == libpod.ErrPodRemoved || errors.Cause(err) == libpod.ErrNoSuchPod {
+					continue
+				}
+				return err
+			}
+			// replace the previous measurement with the current one
+			podStats[pod.ID()] = stats
+			reportStats = append(reportStats, stats)
+		}
+		pods, err = podFunc()
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(format) != formats.JSONString && !c.Bool("no-reset") {
+			tm.Clear()
+			tm.MoveCursor(1, 1)
+			tm.Flush()
+		}
+		if err := outputPodStats(reportStats, format, c.Bool("containers")); err != nil {
+			return err
+		}
+		time.Sleep(time.Second)
+	}
+	return nil
+}
+
+func outputPodStats(stats []*libpod.PodStats, format string, perContainer bool) error {
+	var out formats.Writer
+	var outputStats []podStatsOutputParams
+	for _, s := range stats {
+		outputStats = append(outputStats, getPodStatsOutputParams(s, perContainer)...)
+	}
+	if len(outputStats) == 0 {
+		return nil
+	}
+	if strings.ToLower(format) == formats.JSONString {
+		out = formats.JSONStructArray{Output: podStatsToGeneric(outputStats)}
+	} else {
+		out = formats.StdoutTemplateArray{Output: podStatsToGeneric(outputStats), Template: format, Fields: outputStats[0].headerMap()}
+	}
+	return formats.Writer(out).Out()
+}
+
+func genPodStatsFormat(format string, perContainer bool) string {
+	if format != "" {
+		// "\t" from the command line is not being recognized as a tab
+		// replacing the string "\t" to a tab character if the user passes in "\t"
+		return strings.Replace(format, `\t`, "\t", -1)
+	}
+	if perContainer {
+		return "table {{.Pod}}\t{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}"
+	}
+	return "table {{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}"
+}
+
+// podStatsToGeneric creates an empty array of interfaces for output
+func podStatsToGeneric(templParams []podStatsOutputParams) (genericParams []interface{}) {
+	for _, v := range templParams {
+		genericParams = append(genericParams, interface{}(v))
+	}
+	return
+}
+
+// generate the header based on the template provided
+func (i *podStatsOutputParams) headerMap() map[string]string {
+	v := reflect.Indirect(reflect.ValueOf(i))
+	values := make(map[string]string)
+
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Name
+		value := key
+		switch value {
+		case "CPUPerc":
+			value = "CPU%"
+		case "MemUsage":
+			value = "MemUsage/Limit"
+		case "MemPerc":
+			value = "Mem%"
+		}
+		values[key] = strings.ToUpper(splitCamelCase(value))
+	}
+	return values
+}
+
+// getPodStatsOutputParams returns the output of the pod's summed stats, or of
+// each of its containers if perContainer is true
+func getPodStatsOutputParams(stats *libpod.PodStats, perContainer bool) []podStatsOutputParams {
+	if !perContainer {
+		return []podStatsOutputParams{
+			{
+				Pod:      shortID(stats.PodID),
+				ID:       shortID(stats.PodID),
+				Name:     stats.Name,
+				CPUPerc:  floatToPercentString(stats.CPU),
+				MemUsage: combineHumanValues(stats.MemUsage, stats.MemLimit),
+				MemPerc:  floatToPercentString(stats.MemPerc),
+				NetIO:    combineHumanValues(stats.NetInput, stats.NetOutput),
+				BlockIO:  combineHumanValues(stats.BlockInput, stats.BlockOutput),
+				PIDS:     pidsToString(stats.PIDs),
+			},
+		}
+	}
+
+	ctrStats := make([]*libpod.ContainerStats, len(stats.Containers))
+	copy(ctrStats, stats.Containers)
+	sort.Slice(ctrStats, func(i, j int) bool { return ctrStats[i].Name < ctrStats[j].Name })
+
+	var params []podStatsOutputParams
+	for _, s := range ctrStats {
+		ctrParams := getStatsOutputParams(s)
+		params = append(params, podStatsOutputParams{
+			Pod:      shortID(stats.PodID),
+			ID:       ctrParams.ID,
+			Name:     ctrParams.Name,
+			CPUPerc:  ctrParams.CPUPerc,
+			MemUsage: ctrParams.MemUsage,
+			MemPerc:  ctrParams.MemPerc,
+			NetIO:    ctrParams.NetIO,
+			BlockIO:  ctrParams.BlockIO,
+			PIDS:     ctrParams.PIDS,
+		})
+	}
+	return params
+}
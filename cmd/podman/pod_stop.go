@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	podStopFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "stop all running pods",
+		},
+		LatestPodFlag,
+		cli.UintFlag{
+			Name:  "timeout, time, t",
+			Usage: "Seconds to wait for each container to stop before killing it",
+		},
+	}
+	podStopDescription = `
+   podman pod stop
+
+   Stops one or more running pods.  The pod name or ID can be used.
+   Containers are stopped in the reverse of the order they are started in.
+   Unless a timeout is given, each container uses its own stop timeout.
+`
+
+	podStopCommand = cli.Command{
+		Name:                   "stop",
+		Usage:                  "Stop one or more pods",
+		Description:            podStopDescription,
+		Flags:                  podStopFlags,
+		Action:                 podStopCmd,
+		ArgsUsage:              "POD-NAME [POD-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func podStopCmd(c *cli.Context) error {
+	if err := validateFlags(c, podStopFlags); err != nil {
+		return err
+	}
+	if err := checkAllAndLatest(c); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	// getPodsFromContext returns an error when a requested pod
+	// isn't found. The only fatal error scenerio is when there are no pods
+	// in which case the following loop will be skipped.
+	pods, lastError := getPodsFromContext(c, runtime)
+
+	timeout := -1
+	if c.IsSet("timeout") {
+		timeout = int(c.Uint("timeout"))
+	}
+
+	for _, pod := range pods {
+		ctrErrs, err := pod.StopWithTimeout(true, timeout)
+		if err != nil {
+			for ctr, err := range ctrErrs {
+				logrus.Errorf("error stopping container %s: %v", ctr, err)
+			}
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to stop pod %q", pod.ID())
+			continue
+		}
+		fmt.Println(pod.ID())
+	}
+
+	return lastError
+}
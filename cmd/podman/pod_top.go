@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	podTopFlags = []cli.Flag{
+		LatestPodFlag,
+	}
+	podTopDescription = `
+   podman pod top
+
+   Display the running processes of all containers in a pod. The container each
+   process belongs to is shown in the first column.
+`
+
+	podTopCommand = cli.Command{
+		Name:           "top",
+		Usage:          "Display the running processes of containers in a pod",
+		Description:    podTopDescription,
+		Flags:          podTopFlags,
+		Action:         podTopCmd,
+		ArgsUsage:      "POD-NAME [ps-OPTIONS]",
+		SkipArgReorder: true,
+	}
+)
+
+func podTopCmd(c *cli.Context) error {
+	var pod *libpod.Pod
+	var err error
+	args := c.Args()
+	psOpts := []string{"-o", "uid,pid,ppid,c,stime,tname,time,cmd"}
+	if len(args) < 1 && !c.Bool("latest") {
+		return errors.Errorf("you must provide the name or id of a running pod")
+	}
+	if err := validateFlags(c, podTopFlags); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if c.Bool("latest") {
+		if len(args) > 0 {
+			psOpts = args
+		}
+		pod, err = runtime.GetLatestPod()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get latest pod")
+		}
+	} else {
+		if len(args) > 1 {
+			psOpts = args[1:]
+		}
+		pod, err = runtime.LookupPod(args[0])
+		if err != nil {
+			return errors.Wrapf(err, "unable to lookup %s", args[0])
+		}
+	}
+
+	psOutput, err := pod.GetPodPidInformation(psOpts)
+	if err != nil {
+		return err
+	}
+	for _, line := range psOutput {
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -167,18 +167,11 @@ func psCmd(c *cli.Context) error {
 	}
 
 	if opts.Filter != "" {
-		filters := strings.Split(opts.Filter, ",")
-		for _, f := range filters {
-			filterSplit := strings.Split(f, "=")
-			if len(filterSplit) < 2 {
-				return errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
-			}
-			generatedFunc, err := generateContainerFilterFuncs(filterSplit[0], filterSplit[1], runtime)
-			if err != nil {
-				return errors.Wrapf(err, "invalid filter")
-			}
-			filterFuncs = append(filterFuncs, generatedFunc)
+		generatedFuncs, err := parseContainerFilters(opts.Filter, runtime)
+		if err != nil {
+			return err
 		}
+		filterFuncs = append(filterFuncs, generatedFuncs...)
 	}
 
 	containers, err := runtime.GetContainers(filterFuncs...)
@@ -220,6 +213,24 @@ func checkFlagsPassed(c *cli.Context) error {
 	return nil
 }
 
+// parseContainerFilters parses a comma-separated list of filter=value container
+// filters
+func parseContainerFilters(filter string, runtime *libpod.Runtime) ([]libpod.ContainerFilter, error) {
+	var filterFuncs []libpod.ContainerFilter
+	for _, f := range strings.Split(filter, ",") {
+		filterSplit := strings.Split(f, "=")
+		if len(filterSplit) < 2 {
+			return nil, errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
+		}
+		generatedFunc, err := generateContainerFilterFuncs(filterSplit[0], filterSplit[1], runtime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid filter")
+		}
+		filterFuncs = append(filterFuncs, generatedFunc)
+	}
+	return filterFuncs, nil
+}
+
 func generateContainerFilterFuncs(filter, filterValue string, runtime *libpod.Runtime) (func(container *libpod.Container) bool, error) {
 	switch filter {
 	case "id":
@@ -318,6 +329,31 @@ func generateContainerFilterFuncs(filter, filterValue string, runtime *libpod.Ru
 			}
 			return false
 		}, nil
+	case "restart-policy":
+		if !util.StringInSlice(filterValue, []string{libpod.RestartPolicyNo, libpod.RestartPolicyOnFailure, libpod.RestartPolicyAlways, libpod.RestartPolicyUnlessStopped}) {
+			return nil, errors.Errorf("%s is not a valid restart policy", filterValue)
+		}
+		return func(c *libpod.Container) bool {
+			policy := c.RestartPolicy()
+			if policy == libpod.RestartPolicyNone {
+				policy = libpod.RestartPolicyNo
+			}
+			return policy == filterValue
+		}, nil
+	case "health":
+		if !util.StringInSlice(filterValue, []string{libpod.HealthCheckHealthy, libpod.HealthCheckUnhealthy, libpod.HealthCheckStarting, "none"}) {
+			return nil, errors.Errorf("%s is not a valid health status", filterValue)
+		}
+		return func(c *libpod.Container) bool {
+			status, err := c.HealthCheckStatus()
+			if err != nil {
+				return false
+			}
+			if status == "" {
+				status = "none"
+			}
+			return status == filterValue
+		}, nil
 	}
 	return nil, errors.Errorf("%s is an invalid filter", filter)
 }
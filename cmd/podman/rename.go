@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+var (
+	renameDescription = `
+   podman rename
+
+   Changes the name of a container. The container name or ID can be used to
+   identify the container.
+`
+	renameCommand = cli.Command{
+		Name:        "rename",
+		Usage:       "Rename a container",
+		Description: renameDescription,
+		Action:      renameCmd,
+		ArgsUsage:   "CONTAINER NEW-NAME",
+	}
+)
+
+func renameCmd(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return errors.Errorf("podman rename requires a container and a new name")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	ctr, err := runtime.LookupContainer(args[0])
+	if err != nil {
+		return errors.Wrapf(err, "unable to find container %s", args[0])
+	}
+
+	return runtime.RenameContainer(ctr, args[1])
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	restoreDescription = `
+   podman container restore
+
+   Restores one or more containers from their checkpoints. The container name
+   or ID can be used.
+`
+	restoreFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "keep, k",
+			Usage: "keep the checkpoint and all temporary restore files",
+		},
+		cli.BoolFlag{
+			Name:  "tcp-established",
+			Usage: "restore a container with established TCP connections",
+		},
+		cli.StringFlag{
+			Name:  "import, i",
+			Usage: "create a container from a checkpoint exported to a tar.gz file and restore it",
+		},
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "restore all checkpointed containers",
+		},
+		LatestFlag,
+	}
+	restoreCommand = cli.Command{
+		Name:        "restore",
+		Usage:       "Restores one or more containers from a checkpoint",
+		Description: restoreDescription,
+		Flags:       restoreFlags,
+		Action:      restoreCmd,
+		ArgsUsage:   "CONTAINER-NAME [CONTAINER-NAME ...]",
+	}
+)
+
+func restoreCmd(c *cli.Context) error {
+	args := c.Args()
+	if (c.Bool("all") || c.Bool("latest") || c.String("import") != "") && len(args) > 0 {
+		return errors.Errorf("no arguments are needed with --all, --latest or --import")
+	}
+	if c.Bool("all") && c.Bool("latest") {
+		return errors.Errorf("--all and --latest cannot be used together")
+	}
+	if c.String("import") != "" && (c.Bool("all") || c.Bool("latest")) {
+		return errors.Errorf("--import cannot be used with --all or --latest")
+	}
+	if len(args) < 1 && !c.Bool("all") && !c.Bool("latest") && c.String("import") == "" {
+		return errors.Errorf("you must provide at least one container name or id")
+	}
+	if err := validateFlags(c, restoreFlags); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	options := libpod.ContainerRestoreOptions{
+		Keep:           c.Bool("keep"),
+		TCPEstablished: c.Bool("tcp-established"),
+	}
+
+	var containers []*libpod.Container
+	var lastError error
+
+	if c.String("import") != "" {
+		ctr, err := runtime.ImportCheckpoint(getContext(), c.String("import"))
+		if err != nil {
+			return errors.Wrapf(err, "unable to import checkpoint %s", c.String("import"))
+		}
+		containers = append(containers, ctr)
+	} else if c.Bool("all") {
+		containers, err = runtime.GetContainers(func(c *libpod.Container) bool {
+			checkpointed, _ := c.Checkpointed()
+			return checkpointed
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to get checkpointed containers")
+		}
+	} else if c.Bool("latest") {
+		lastCtr, err := runtime.GetLatestContainer()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get last created container")
+		}
+		containers = append(containers, lastCtr)
+	} else {
+		for _, i := range args {
+			container, err := runtime.LookupContainer(i)
+			if err != nil {
+				if lastError != nil {
+					fmt.Fprintln(os.Stderr, lastError)
+				}
+				lastError = errors.Wrapf(err, "unable to find container %s", i)
+				continue
+			}
+			containers = append(containers, container)
+		}
+	}
+
+	for _, ctr := range containers {
+		if err := ctr.Restore(getContext(), options); err != nil {
+			if lastError != nil {
+				fmt.Fprintln(os.Stderr, lastError)
+			}
+			lastError = errors.Wrapf(err, "failed to restore container %v", ctr.ID())
+		} else {
+			fmt.Println(ctr.ID())
+		}
+	}
+	return lastError
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -14,7 +13,6 @@ import (
 	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
 	"github.com/projectatomic/libpod/libpod"
 	"github.com/projectatomic/libpod/libpod/image"
-	cc "github.com/projectatomic/libpod/pkg/spec"
 	"github.com/projectatomic/libpod/pkg/util"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
@@ -90,57 +88,11 @@ func runCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	useImageVolumes := createConfig.ImageVolumeType == "bind"
-
-	runtimeSpec, err := cc.CreateConfigToOCISpec(createConfig)
-	if err != nil {
-		return err
-	}
-
-	options, err := createConfig.GetContainerCreateOptions()
-	if err != nil {
-		return errors.Wrapf(err, "unable to parse new container options")
-	}
-
-	// Gather up the options for NewContainer which consist of With... funcs
-	options = append(options, libpod.WithRootFSFromImage(createConfig.ImageID, createConfig.Image, useImageVolumes))
-	options = append(options, libpod.WithSELinuxLabels(createConfig.ProcessLabel, createConfig.MountLabel))
-	options = append(options, libpod.WithConmonPidFile(createConfig.ConmonPidFile))
-	options = append(options, libpod.WithLabels(createConfig.Labels))
-	options = append(options, libpod.WithUser(createConfig.User))
-	options = append(options, libpod.WithShmDir(createConfig.ShmDir))
-	options = append(options, libpod.WithShmSize(createConfig.Resources.ShmSize))
-	options = append(options, libpod.WithGroups(createConfig.GroupAdd))
-	options = append(options, libpod.WithIDMappings(*createConfig.IDMappings))
-
-	// Default used if not overridden on command line
-
-	if createConfig.CgroupParent != "" {
-		options = append(options, libpod.WithCgroupParent(createConfig.CgroupParent))
-	}
-
-	ctr, err := runtime.NewContainer(ctx, runtimeSpec, options...)
+	ctr, err := createContainerFromCreateConfig(ctx, runtime, createConfig)
 	if err != nil {
 		return err
 	}
 
-	logrus.Debugf("New container created %q", ctr.ID())
-
-	if logrus.GetLevel() == logrus.DebugLevel {
-		cgroupPath, err := ctr.CGroupPath()
-		if err == nil {
-			logrus.Debugf("container %q has CgroupParent %q", ctr.ID(), cgroupPath)
-		}
-	}
-
-	createConfigJSON, err := json.Marshal(createConfig)
-	if err != nil {
-		return err
-	}
-	if err := ctr.AddArtifact("create-config", createConfigJSON); err != nil {
-		return err
-	}
-
 	if c.String("cidfile") != "" {
 		if err := libpod.WriteFile(ctr.ID(), c.String("cidfile")); err != nil {
 			logrus.Error(err)
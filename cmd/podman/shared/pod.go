@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
+)
+
+// DefaultPodNamespaces are the namespaces shared by the containers of a pod
+// unless others are requested
+const DefaultPodNamespaces = "ipc,net,uts"
+
+// Pod states, as derived from the states of the containers in a pod
+const (
+	PodStateStopped = "Stopped"
+	PodStateRunning = "Running"
+	PodStatePaused  = "Paused"
+	PodStateExited  = "Exited"
+	PodStateErrored = "Error"
+	PodStateCreated = "Created"
+)
+
+// GetPodStatus determines the status of the pod based on the
+// statuses of its containers
+func GetPodStatus(ctrStatuses map[string]libpod.ContainerStatus) string {
+	ctrNum := len(ctrStatuses)
+	if ctrNum == 0 {
+		return PodStateCreated
+	}
+	statuses := map[string]int{
+		PodStateStopped: 0,
+		PodStateRunning: 0,
+		PodStatePaused:  0,
+		PodStateCreated: 0,
+		PodStateErrored: 0,
+	}
+	for _, ctrStatus := range ctrStatuses {
+		switch ctrStatus {
+		case libpod.ContainerStateStopped:
+			statuses[PodStateStopped]++
+		case libpod.ContainerStateRunning:
+			statuses[PodStateRunning]++
+		case libpod.ContainerStatePaused:
+			statuses[PodStatePaused]++
+		case libpod.ContainerStateCreated, libpod.ContainerStateConfigured:
+			statuses[PodStateCreated]++
+		default:
+			statuses[PodStateErrored]++
+		}
+	}
+
+	if statuses[PodStateRunning] > 0 {
+		return PodStateRunning
+	} else if statuses[PodStatePaused] == ctrNum {
+		return PodStatePaused
+	} else if statuses[PodStateStopped] == ctrNum {
+		return PodStateExited
+	} else if statuses[PodStateStopped] > 0 {
+		return PodStateStopped
+	} else if statuses[PodStateErrored] > 0 {
+		return PodStateErrored
+	}
+	return PodStateCreated
+}
+
+// GetPodNamespaceOptions parses a comma delimited list of namespaces shared by
+// the containers of a pod into pod create options
+func GetPodNamespaceOptions(share string) ([]libpod.PodCreateOption, error) {
+	var options []libpod.PodCreateOption
+	if share == "" {
+		return options, nil
+	}
+	for _, ns := range strings.Split(share, ",") {
+		switch ns {
+		case "net":
+			options = append(options, libpod.WithPodNet())
+		case "ipc":
+			options = append(options, libpod.WithPodIPC())
+		case "uts":
+			options = append(options, libpod.WithPodUTS())
+		default:
+			return nil, errors.Errorf("invalid kernel namespace to share: %s. Options are: net, ipc or uts", ns)
+		}
+	}
+	return options, nil
+}
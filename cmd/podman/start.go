@@ -13,6 +13,10 @@ import (
 
 var (
 	startFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "Start all containers that are not running, or only those matching --filter",
+		},
 		cli.BoolFlag{
 			Name:  "attach, a",
 			Usage: "Attach container's STDOUT and STDERR",
@@ -21,6 +25,10 @@ var (
 			Name:  "detach-keys",
 			Usage: "Override the key sequence for detaching a container. Format is a single character [a-Z] or ctrl-<value> where <value> is one of: a-z, @, ^, [, , or _.",
 		},
+		cli.StringFlag{
+			Name:  "filter, f",
+			Usage: "Filter the containers started by --all based on conditions given",
+		},
 		cli.BoolFlag{
 			Name:  "interactive, i",
 			Usage: "Keep STDIN open even if not attached",
@@ -50,13 +58,19 @@ var (
 
 func startCmd(c *cli.Context) error {
 	args := c.Args()
-	if len(args) < 1 && !c.Bool("latest") {
+	if len(args) < 1 && !c.Bool("latest") && !c.Bool("all") {
 		return errors.Errorf("you must provide at least one container name or id")
 	}
+	if c.Bool("all") && (len(args) > 0 || c.Bool("latest")) {
+		return errors.Errorf("--all cannot be used with container names or --latest")
+	}
+	if c.IsSet("filter") && !c.Bool("all") {
+		return errors.Errorf("--filter can only be used with --all")
+	}
 
 	attach := c.Bool("attach")
 
-	if len(args) > 1 && attach {
+	if (len(args) > 1 || c.Bool("all")) && attach {
 		return errors.Errorf("you cannot start and attach multiple containers at once")
 	}
 
@@ -80,6 +94,28 @@ func startCmd(c *cli.Context) error {
 		}
 		args = append(args, lastCtr.ID())
 	}
+	if c.Bool("all") {
+		filterFuncs := []libpod.ContainerFilter{
+			func(c *libpod.Container) bool {
+				state, _ := c.State()
+				return state != libpod.ContainerStateRunning
+			},
+		}
+		if c.IsSet("filter") {
+			generatedFuncs, err := parseContainerFilters(c.String("filter"), runtime)
+			if err != nil {
+				return err
+			}
+			filterFuncs = append(filterFuncs, generatedFuncs...)
+		}
+		ctrs, err := runtime.GetContainers(filterFuncs...)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get containers")
+		}
+		for _, ctr := range ctrs {
+			args = append(args, ctr.ID())
+		}
+	}
 	var lastError error
 	for _, container := range args {
 		ctr, err := runtime.LookupContainer(container)
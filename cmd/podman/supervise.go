@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"os"
+	gosignal "os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	superviseDescription = `
+	podman supervise
+
+	Watch for containers exiting, clean up their resources, and restart them as
+	required by their restart policies. Also rotates the log files of containers
+	that reached their maximum size, and runs the healthchecks of containers at
+	their intervals. Runs until interrupted.
+`
+	superviseCommand = cli.Command{
+		Name:        "supervise",
+		Usage:       "Clean up exited containers and enforce their restart policies",
+		Description: superviseDescription,
+		Action:      superviseCmd,
+		ArgsUsage:   "",
+	}
+)
+
+func superviseCmd(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return errors.Errorf("podman supervise takes no arguments")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	ctx, cancel := context.WithCancel(getContext())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	gosignal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		logrus.Debugf("Received signal %v, stopping supervision", sig)
+		cancel()
+	}()
+
+	return runtime.Supervise(ctx)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/docker/go-units"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	cc "github.com/projectatomic/libpod/pkg/spec"
+	"github.com/urfave/cli"
+)
+
+var (
+	updateFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "blkio-weight",
+			Usage: "Block IO weight (relative weight) accepts a weight value between 10 and 1000.",
+		},
+		cli.Uint64Flag{
+			Name:  "cpu-period",
+			Usage: "Limit the CPU CFS (Completely Fair Scheduler) period",
+		},
+		cli.Int64Flag{
+			Name:  "cpu-quota",
+			Usage: "Limit the CPU CFS (Completely Fair Scheduler) quota",
+		},
+		cli.Uint64Flag{
+			Name:  "cpu-shares",
+			Usage: "CPU shares (relative weight)",
+		},
+		cli.Float64Flag{
+			Name:  "cpus",
+			Usage: "Number of CPUs",
+		},
+		cli.StringFlag{
+			Name:  "cpuset-cpus",
+			Usage: "CPUs in which to allow execution (0-3, 0,1)",
+		},
+		cli.StringFlag{
+			Name:  "cpuset-mems",
+			Usage: "Memory nodes (MEMs) in which to allow execution (0-3, 0,1). Only effective on NUMA systems.",
+		},
+		cli.StringFlag{
+			Name:  "memory, m",
+			Usage: "Memory limit (format: <number>[<unit>], where unit = b, k, m or g)",
+		},
+		cli.StringFlag{
+			Name:  "memory-reservation",
+			Usage: "Memory soft limit (format: <number>[<unit>], where unit = b, k, m or g)",
+		},
+		cli.StringFlag{
+			Name:  "memory-swap",
+			Usage: "Swap limit equal to memory plus swap: '-1' to enable unlimited swap",
+		},
+		cli.Int64Flag{
+			Name:  "pids-limit",
+			Usage: "Tune container pids limit (set -1 for unlimited)",
+		},
+		LatestFlag,
+	}
+	updateDescription = `
+   podman update
+
+   Updates the resource limits of one or more containers. Running containers
+   are updated immediately, and the new limits are kept when containers are
+   restarted. The container name or ID can be used.
+`
+	updateCommand = cli.Command{
+		Name:        "update",
+		Usage:       "Update the resource limits of one or more containers",
+		Description: updateDescription,
+		Flags:       updateFlags,
+		Action:      updateCmd,
+		ArgsUsage:   "CONTAINER-NAME [CONTAINER-NAME ...]",
+	}
+)
+
+func updateCmd(c *cli.Context) error {
+	args := c.Args()
+	if c.Bool("latest") && len(args) > 0 {
+		return errors.Errorf("no arguments are needed with --latest")
+	}
+	if len(args) < 1 && !c.Bool("latest") {
+		return errors.Errorf("you must provide at least one container name or id")
+	}
+	if err := validateFlags(c, updateFlags); err != nil {
+		return err
+	}
+
+	resources, err := parseUpdateResources(c)
+	if err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	if c.Bool("latest") {
+		lastCtr, err := runtime.GetLatestContainer()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get last created container")
+		}
+		args = append(args, lastCtr.ID())
+	}
+
+	var lastError error
+	for _, arg := range args {
+		ctr, err := runtime.LookupContainer(arg)
+		if err != nil {
+			if lastError != nil {
+				fmt.Fprintln(os.Stderr, lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to find container %s", arg)
+			continue
+		}
+		if err := ctr.Update(resources); err != nil {
+			if lastError != nil {
+				fmt.Fprintln(os.Stderr, lastError)
+			}
+			lastError = errors.Wrapf(err, "failed to update container %v", ctr.ID())
+		} else {
+			fmt.Println(ctr.ID())
+		}
+	}
+	return lastError
+}
+
+// parseUpdateResources validates the resource limits given to podman update
+// and converts them to the resources to update
+// Limits that were not given, or were discarded because the kernel does not
+// support them, are left unset
+func parseUpdateResources(c *cli.Context) (*spec.LinuxResources, error) {
+	if c.IsSet("cpu-period") && c.IsSet("cpus") {
+		return nil, errors.Errorf("--cpu-period and --cpus cannot be set together")
+	}
+	if c.IsSet("cpu-quota") && c.IsSet("cpus") {
+		return nil, errors.Errorf("--cpu-quota and --cpus cannot be set together")
+	}
+
+	config := &cc.CreateConfig{
+		Resources: cc.CreateResourceConfig{
+			CPUPeriod:        c.Uint64("cpu-period"),
+			CPUQuota:         c.Int64("cpu-quota"),
+			CPUShares:        c.Uint64("cpu-shares"),
+			CPUs:             c.Float64("cpus"),
+			CPUsetCPUs:       c.String("cpuset-cpus"),
+			CPUsetMems:       c.String("cpuset-mems"),
+			MemorySwappiness: -1,
+			PidsLimit:        c.Int64("pids-limit"),
+		},
+	}
+	var err error
+	if c.String("memory") != "" {
+		config.Resources.Memory, err = units.RAMInBytes(c.String("memory"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for memory")
+		}
+	}
+	if c.String("memory-reservation") != "" {
+		config.Resources.MemoryReservation, err = units.RAMInBytes(c.String("memory-reservation"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for memory-reservation")
+		}
+	}
+	if c.String("memory-swap") != "" {
+		config.Resources.MemorySwap, err = units.RAMInBytes(c.String("memory-swap"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for memory-swap")
+		}
+	}
+	if c.String("blkio-weight") != "" {
+		u, err := strconv.ParseUint(c.String("blkio-weight"), 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for blkio-weight")
+		}
+		config.Resources.BlkioWeight = uint16(u)
+	}
+
+	warnings, err := verifyContainerResources(config, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	resources := config.Resources.UpdateResources()
+	if resources.CPU == nil && resources.Memory == nil && resources.BlockIO == nil && resources.Pids == nil {
+		return nil, errors.Errorf("you must provide at least one resource limit to update")
+	}
+	return resources, nil
+}
@@ -269,6 +269,38 @@ type Create struct {
 	Security_opts        []string             `json:"security_opts"`
 }
 
+type ListPodContainerInfo struct {
+	Name   string `json:"name"`
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type ListPodData struct {
+	Id                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	Createdat          string                 `json:"createdat"`
+	Cgroup             string                 `json:"cgroup"`
+	Status             string                 `json:"status"`
+	Labels             map[string]string      `json:"labels"`
+	Numberofcontainers int64                  `json:"numberofcontainers"`
+	Containersinfo     []ListPodContainerInfo `json:"containersinfo"`
+}
+
+type PodContainerErrorData struct {
+	Containerid string `json:"containerid"`
+	Reason      string `json:"reason"`
+}
+
+type PodCreate struct {
+	Name         string            `json:"name"`
+	CgroupParent string            `json:"cgroupParent"`
+	Labels       map[string]string `json:"labels"`
+	Share        []string          `json:"share"`
+	Infra        bool              `json:"infra"`
+	InfraImage   string            `json:"infraImage"`
+	InfraCommand []string          `json:"infraCommand"`
+}
+
 // Client method calls
 type ListContainerProcesses_methods struct{}
 
@@ -935,29 +967,35 @@ type RenameContainer_methods struct{}
 
 func RenameContainer() RenameContainer_methods { return RenameContainer_methods{} }
 
-func (m RenameContainer_methods) Call(c *varlink.Connection) (notimplemented_out_ NotImplemented, err_ error) {
-	receive, err_ := m.Send(c, 0)
+func (m RenameContainer_methods) Call(c *varlink.Connection, name_in_ string, newName_in_ string) (container_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, newName_in_)
 	if err_ != nil {
 		return
 	}
-	notimplemented_out_, _, err_ = receive()
+	container_out_, _, err_ = receive()
 	return
 }
 
-func (m RenameContainer_methods) Send(c *varlink.Connection, flags uint64) (func() (NotImplemented, uint64, error), error) {
-	receive, err := c.Send("io.projectatomic.podman.RenameContainer", nil, flags)
+func (m RenameContainer_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, newName_in_ string) (func() (string, uint64, error), error) {
+	var in struct {
+		Name    string `json:"name"`
+		NewName string `json:"newName"`
+	}
+	in.Name = name_in_
+	in.NewName = newName_in_
+	receive, err := c.Send("io.projectatomic.podman.RenameContainer", in, flags)
 	if err != nil {
 		return nil, err
 	}
-	return func() (notimplemented_out_ NotImplemented, flags uint64, err error) {
+	return func() (container_out_ string, flags uint64, err error) {
 		var out struct {
-			Notimplemented NotImplemented `json:"notimplemented"`
+			Container string `json:"container"`
 		}
 		flags, err = receive(&out)
 		if err != nil {
 			return
 		}
-		notimplemented_out_ = out.Notimplemented
+		container_out_ = out.Container
 		return
 	}, nil
 }
@@ -1310,29 +1348,35 @@ type UpdateContainer_methods struct{}
 
 func UpdateContainer() UpdateContainer_methods { return UpdateContainer_methods{} }
 
-func (m UpdateContainer_methods) Call(c *varlink.Connection) (notimplemented_out_ NotImplemented, err_ error) {
-	receive, err_ := m.Send(c, 0)
+func (m UpdateContainer_methods) Call(c *varlink.Connection, name_in_ string, resources_in_ CreateResourceConfig) (container_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, resources_in_)
 	if err_ != nil {
 		return
 	}
-	notimplemented_out_, _, err_ = receive()
+	container_out_, _, err_ = receive()
 	return
 }
 
-func (m UpdateContainer_methods) Send(c *varlink.Connection, flags uint64) (func() (NotImplemented, uint64, error), error) {
-	receive, err := c.Send("io.projectatomic.podman.UpdateContainer", nil, flags)
+func (m UpdateContainer_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, resources_in_ CreateResourceConfig) (func() (string, uint64, error), error) {
+	var in struct {
+		Name      string               `json:"name"`
+		Resources CreateResourceConfig `json:"resources"`
+	}
+	in.Name = name_in_
+	in.Resources = resources_in_
+	receive, err := c.Send("io.projectatomic.podman.UpdateContainer", in, flags)
 	if err != nil {
 		return nil, err
 	}
-	return func() (notimplemented_out_ NotImplemented, flags uint64, err error) {
+	return func() (container_out_ string, flags uint64, err error) {
 		var out struct {
-			Notimplemented NotImplemented `json:"notimplemented"`
+			Container string `json:"container"`
 		}
 		flags, err = receive(&out)
 		if err != nil {
 			return
 		}
-		notimplemented_out_ = out.Notimplemented
+		container_out_ = out.Container
 		return
 	}, nil
 }
@@ -1628,38 +1672,431 @@ func (m Commit_methods) Call(c *varlink.Connection, name_in_ string, image_name_
 	if err_ != nil {
 		return
 	}
-	image_out_, _, err_ = receive()
+	image_out_, _, err_ = receive()
+	return
+}
+
+func (m Commit_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, image_name_in_ string, changes_in_ []string, author_in_ string, message_in_ string, pause_in_ bool) (func() (string, uint64, error), error) {
+	var in struct {
+		Name       string   `json:"name"`
+		Image_name string   `json:"image_name"`
+		Changes    []string `json:"changes"`
+		Author     string   `json:"author"`
+		Message    string   `json:"message"`
+		Pause      bool     `json:"pause"`
+	}
+	in.Name = name_in_
+	in.Image_name = image_name_in_
+	in.Changes = []string(changes_in_)
+	in.Author = author_in_
+	in.Message = message_in_
+	in.Pause = pause_in_
+	receive, err := c.Send("io.projectatomic.podman.Commit", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (image_out_ string, flags uint64, err error) {
+		var out struct {
+			Image string `json:"image"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		image_out_ = out.Image
+		return
+	}, nil
+}
+
+type CreatePod_methods struct{}
+
+func CreatePod() CreatePod_methods { return CreatePod_methods{} }
+
+func (m CreatePod_methods) Call(c *varlink.Connection, create_in_ PodCreate) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, create_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m CreatePod_methods) Send(c *varlink.Connection, flags uint64, create_in_ PodCreate) (func() (string, uint64, error), error) {
+	var in struct {
+		Create PodCreate `json:"create"`
+	}
+	in.Create = create_in_
+	receive, err := c.Send("io.projectatomic.podman.CreatePod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type GetPod_methods struct{}
+
+func GetPod() GetPod_methods { return GetPod_methods{} }
+
+func (m GetPod_methods) Call(c *varlink.Connection, name_in_ string) (pod_out_ ListPodData, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m GetPod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string) (func() (ListPodData, uint64, error), error) {
+	var in struct {
+		Name string `json:"name"`
+	}
+	in.Name = name_in_
+	receive, err := c.Send("io.projectatomic.podman.GetPod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ ListPodData, flags uint64, err error) {
+		var out struct {
+			Pod ListPodData `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type InspectPod_methods struct{}
+
+func InspectPod() InspectPod_methods { return InspectPod_methods{} }
+
+func (m InspectPod_methods) Call(c *varlink.Connection, name_in_ string) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m InspectPod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string) (func() (string, uint64, error), error) {
+	var in struct {
+		Name string `json:"name"`
+	}
+	in.Name = name_in_
+	receive, err := c.Send("io.projectatomic.podman.InspectPod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type KillPod_methods struct{}
+
+func KillPod() KillPod_methods { return KillPod_methods{} }
+
+func (m KillPod_methods) Call(c *varlink.Connection, name_in_ string, signal_in_ int64) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, signal_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m KillPod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, signal_in_ int64) (func() (string, uint64, error), error) {
+	var in struct {
+		Name   string `json:"name"`
+		Signal int64  `json:"signal"`
+	}
+	in.Name = name_in_
+	in.Signal = signal_in_
+	receive, err := c.Send("io.projectatomic.podman.KillPod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type ListPodContainers_methods struct{}
+
+func ListPodContainers() ListPodContainers_methods { return ListPodContainers_methods{} }
+
+func (m ListPodContainers_methods) Call(c *varlink.Connection, name_in_ string) (containers_out_ []ListContainerData, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_)
+	if err_ != nil {
+		return
+	}
+	containers_out_, _, err_ = receive()
+	return
+}
+
+func (m ListPodContainers_methods) Send(c *varlink.Connection, flags uint64, name_in_ string) (func() ([]ListContainerData, uint64, error), error) {
+	var in struct {
+		Name string `json:"name"`
+	}
+	in.Name = name_in_
+	receive, err := c.Send("io.projectatomic.podman.ListPodContainers", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (containers_out_ []ListContainerData, flags uint64, err error) {
+		var out struct {
+			Containers []ListContainerData `json:"containers"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		containers_out_ = []ListContainerData(out.Containers)
+		return
+	}, nil
+}
+
+type ListPods_methods struct{}
+
+func ListPods() ListPods_methods { return ListPods_methods{} }
+
+func (m ListPods_methods) Call(c *varlink.Connection) (pods_out_ []ListPodData, err_ error) {
+	receive, err_ := m.Send(c, 0)
+	if err_ != nil {
+		return
+	}
+	pods_out_, _, err_ = receive()
+	return
+}
+
+func (m ListPods_methods) Send(c *varlink.Connection, flags uint64) (func() ([]ListPodData, uint64, error), error) {
+	receive, err := c.Send("io.projectatomic.podman.ListPods", nil, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pods_out_ []ListPodData, flags uint64, err error) {
+		var out struct {
+			Pods []ListPodData `json:"pods"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pods_out_ = []ListPodData(out.Pods)
+		return
+	}, nil
+}
+
+type RemovePod_methods struct{}
+
+func RemovePod() RemovePod_methods { return RemovePod_methods{} }
+
+func (m RemovePod_methods) Call(c *varlink.Connection, name_in_ string, force_in_ bool) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, force_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m RemovePod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, force_in_ bool) (func() (string, uint64, error), error) {
+	var in struct {
+		Name  string `json:"name"`
+		Force bool   `json:"force"`
+	}
+	in.Name = name_in_
+	in.Force = force_in_
+	receive, err := c.Send("io.projectatomic.podman.RemovePod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type StartPod_methods struct{}
+
+func StartPod() StartPod_methods { return StartPod_methods{} }
+
+func (m StartPod_methods) Call(c *varlink.Connection, name_in_ string) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m StartPod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string) (func() (string, uint64, error), error) {
+	var in struct {
+		Name string `json:"name"`
+	}
+	in.Name = name_in_
+	receive, err := c.Send("io.projectatomic.podman.StartPod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type StopPod_methods struct{}
+
+func StopPod() StopPod_methods { return StopPod_methods{} }
+
+func (m StopPod_methods) Call(c *varlink.Connection, name_in_ string, timeout_in_ int64) (pod_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, timeout_in_)
+	if err_ != nil {
+		return
+	}
+	pod_out_, _, err_ = receive()
+	return
+}
+
+func (m StopPod_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, timeout_in_ int64) (func() (string, uint64, error), error) {
+	var in struct {
+		Name    string `json:"name"`
+		Timeout int64  `json:"timeout"`
+	}
+	in.Name = name_in_
+	in.Timeout = timeout_in_
+	receive, err := c.Send("io.projectatomic.podman.StopPod", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (pod_out_ string, flags uint64, err error) {
+		var out struct {
+			Pod string `json:"pod"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		pod_out_ = out.Pod
+		return
+	}, nil
+}
+
+type CopyFromContainer_methods struct{}
+
+func CopyFromContainer() CopyFromContainer_methods { return CopyFromContainer_methods{} }
+
+func (m CopyFromContainer_methods) Call(c *varlink.Connection, name_in_ string, path_in_ string) (archive_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, path_in_)
+	if err_ != nil {
+		return
+	}
+	archive_out_, _, err_ = receive()
+	return
+}
+
+func (m CopyFromContainer_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, path_in_ string) (func() (string, uint64, error), error) {
+	var in struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+	}
+	in.Name = name_in_
+	in.Path = path_in_
+	receive, err := c.Send("io.projectatomic.podman.CopyFromContainer", in, flags)
+	if err != nil {
+		return nil, err
+	}
+	return func() (archive_out_ string, flags uint64, err error) {
+		var out struct {
+			Archive string `json:"archive"`
+		}
+		flags, err = receive(&out)
+		if err != nil {
+			return
+		}
+		archive_out_ = out.Archive
+		return
+	}, nil
+}
+
+type CopyToContainer_methods struct{}
+
+func CopyToContainer() CopyToContainer_methods { return CopyToContainer_methods{} }
+
+func (m CopyToContainer_methods) Call(c *varlink.Connection, name_in_ string, path_in_ string, archive_in_ string) (container_out_ string, err_ error) {
+	receive, err_ := m.Send(c, 0, name_in_, path_in_, archive_in_)
+	if err_ != nil {
+		return
+	}
+	container_out_, _, err_ = receive()
 	return
 }
 
-func (m Commit_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, image_name_in_ string, changes_in_ []string, author_in_ string, message_in_ string, pause_in_ bool) (func() (string, uint64, error), error) {
+func (m CopyToContainer_methods) Send(c *varlink.Connection, flags uint64, name_in_ string, path_in_ string, archive_in_ string) (func() (string, uint64, error), error) {
 	var in struct {
-		Name       string   `json:"name"`
-		Image_name string   `json:"image_name"`
-		Changes    []string `json:"changes"`
-		Author     string   `json:"author"`
-		Message    string   `json:"message"`
-		Pause      bool     `json:"pause"`
+		Name    string `json:"name"`
+		Path    string `json:"path"`
+		Archive string `json:"archive"`
 	}
 	in.Name = name_in_
-	in.Image_name = image_name_in_
-	in.Changes = []string(changes_in_)
-	in.Author = author_in_
-	in.Message = message_in_
-	in.Pause = pause_in_
-	receive, err := c.Send("io.projectatomic.podman.Commit", in, flags)
+	in.Path = path_in_
+	in.Archive = archive_in_
+	receive, err := c.Send("io.projectatomic.podman.CopyToContainer", in, flags)
 	if err != nil {
 		return nil, err
 	}
-	return func() (image_out_ string, flags uint64, err error) {
+	return func() (container_out_ string, flags uint64, err error) {
 		var out struct {
-			Image string `json:"image"`
+			Container string `json:"container"`
 		}
 		flags, err = receive(&out)
 		if err != nil {
 			return
 		}
-		image_out_ = out.Image
+		container_out_ = out.Container
 		return
 	}, nil
 }
@@ -1684,7 +2121,7 @@ type ioprojectatomicpodmanInterface interface {
 	CreateContainer(c VarlinkCall, create_ Create) error
 	ResizeContainerTty(c VarlinkCall) error
 	StopContainer(c VarlinkCall, name_ string, timeout_ int64) error
-	RenameContainer(c VarlinkCall) error
+	RenameContainer(c VarlinkCall, name_ string, newName_ string) error
 	CreateImage(c VarlinkCall) error
 	ExportContainer(c VarlinkCall, name_ string, path_ string) error
 	GetContainerStats(c VarlinkCall, name_ string) error
@@ -1695,7 +2132,7 @@ type ioprojectatomicpodmanInterface interface {
 	WaitContainer(c VarlinkCall, name_ string) error
 	HistoryImage(c VarlinkCall, name_ string) error
 	RemoveImage(c VarlinkCall, name_ string, force_ bool) error
-	UpdateContainer(c VarlinkCall) error
+	UpdateContainer(c VarlinkCall, name_ string, resources_ CreateResourceConfig) error
 	PauseContainer(c VarlinkCall, name_ string) error
 	TagImage(c VarlinkCall, name_ string, tagged_ string) error
 	GetVersion(c VarlinkCall) error
@@ -1706,6 +2143,17 @@ type ioprojectatomicpodmanInterface interface {
 	Commit(c VarlinkCall, name_ string, image_name_ string, changes_ []string, author_ string, message_ string, pause_ bool) error
 	ExportImage(c VarlinkCall, name_ string, destination_ string, compress_ bool, tags_ []string) error
 	ListContainerProcesses(c VarlinkCall, name_ string, opts_ []string) error
+	CreatePod(c VarlinkCall, create_ PodCreate) error
+	GetPod(c VarlinkCall, name_ string) error
+	InspectPod(c VarlinkCall, name_ string) error
+	KillPod(c VarlinkCall, name_ string, signal_ int64) error
+	ListPodContainers(c VarlinkCall, name_ string) error
+	ListPods(c VarlinkCall) error
+	RemovePod(c VarlinkCall, name_ string, force_ bool) error
+	StartPod(c VarlinkCall, name_ string) error
+	StopPod(c VarlinkCall, name_ string, timeout_ int64) error
+	CopyFromContainer(c VarlinkCall, name_ string, path_ string) error
+	CopyToContainer(c VarlinkCall, name_ string, path_ string, archive_ string) error
 }
 
 // Service object with all methods
@@ -1744,6 +2192,24 @@ func (c *VarlinkCall) ReplyRuntimeError(reason_ string) error {
 	return c.ReplyError("io.projectatomic.podman.RuntimeError", &out)
 }
 
+func (c *VarlinkCall) ReplyPodContainerError(podname_ string, errors_ []PodContainerErrorData) error {
+	var out struct {
+		Podname string                  `json:"podname"`
+		Errors  []PodContainerErrorData `json:"errors"`
+	}
+	out.Podname = podname_
+	out.Errors = []PodContainerErrorData(errors_)
+	return c.ReplyError("io.projectatomic.podman.PodContainerError", &out)
+}
+
+func (c *VarlinkCall) ReplyPodNotFound(name_ string) error {
+	var out struct {
+		Name string `json:"name"`
+	}
+	out.Name = name_
+	return c.ReplyError("io.projectatomic.podman.PodNotFound", &out)
+}
+
 // Reply methods for all varlink methods
 func (c *VarlinkCall) ReplyExportContainer(tarfile_ string) error {
 	var out struct {
@@ -1817,11 +2283,11 @@ func (c *VarlinkCall) ReplyHistoryImage(history_ []ImageHistory) error {
 	return c.Reply(&out)
 }
 
-func (c *VarlinkCall) ReplyUpdateContainer(notimplemented_ NotImplemented) error {
+func (c *VarlinkCall) ReplyUpdateContainer(container_ string) error {
 	var out struct {
-		Notimplemented NotImplemented `json:"notimplemented"`
+		Container string `json:"container"`
 	}
-	out.Notimplemented = notimplemented_
+	out.Container = container_
 	return c.Reply(&out)
 }
 
@@ -2049,11 +2515,11 @@ func (c *VarlinkCall) ReplyStopContainer(container_ string) error {
 	return c.Reply(&out)
 }
 
-func (c *VarlinkCall) ReplyRenameContainer(notimplemented_ NotImplemented) error {
+func (c *VarlinkCall) ReplyRenameContainer(container_ string) error {
 	var out struct {
-		Notimplemented NotImplemented `json:"notimplemented"`
+		Container string `json:"container"`
 	}
-	out.Notimplemented = notimplemented_
+	out.Container = container_
 	return c.Reply(&out)
 }
 
@@ -2065,6 +2531,94 @@ func (c *VarlinkCall) ReplyCreateImage(notimplemented_ NotImplemented) error {
 	return c.Reply(&out)
 }
 
+func (c *VarlinkCall) ReplyCreatePod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyGetPod(pod_ ListPodData) error {
+	var out struct {
+		Pod ListPodData `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyInspectPod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyKillPod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyListPodContainers(containers_ []ListContainerData) error {
+	var out struct {
+		Containers []ListContainerData `json:"containers"`
+	}
+	out.Containers = []ListContainerData(containers_)
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyListPods(pods_ []ListPodData) error {
+	var out struct {
+		Pods []ListPodData `json:"pods"`
+	}
+	out.Pods = []ListPodData(pods_)
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyRemovePod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyStartPod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyStopPod(pod_ string) error {
+	var out struct {
+		Pod string `json:"pod"`
+	}
+	out.Pod = pod_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyCopyFromContainer(archive_ string) error {
+	var out struct {
+		Archive string `json:"archive"`
+	}
+	out.Archive = archive_
+	return c.Reply(&out)
+}
+
+func (c *VarlinkCall) ReplyCopyToContainer(container_ string) error {
+	var out struct {
+		Container string `json:"container"`
+	}
+	out.Container = container_
+	return c.Reply(&out)
+}
+
 // Dummy implementations for all varlink methods
 func (s *VarlinkInterface) ExportContainer(c VarlinkCall, name_ string, path_ string) error {
 	return c.ReplyMethodNotImplemented("io.projectatomic.podman.ExportContainer")
@@ -2102,7 +2656,7 @@ func (s *VarlinkInterface) WaitContainer(c VarlinkCall, name_ string) error {
 	return c.ReplyMethodNotImplemented("io.projectatomic.podman.WaitContainer")
 }
 
-func (s *VarlinkInterface) UpdateContainer(c VarlinkCall) error {
+func (s *VarlinkInterface) UpdateContainer(c VarlinkCall, name_ string, resources_ CreateResourceConfig) error {
 	return c.ReplyMethodNotImplemented("io.projectatomic.podman.UpdateContainer")
 }
 
@@ -2222,10 +2776,54 @@ func (s *VarlinkInterface) StopContainer(c VarlinkCall, name_ string, timeout_ i
 	return c.ReplyMethodNotImplemented("io.projectatomic.podman.StopContainer")
 }
 
-func (s *VarlinkInterface) RenameContainer(c VarlinkCall) error {
+func (s *VarlinkInterface) RenameContainer(c VarlinkCall, name_ string, newName_ string) error {
 	return c.ReplyMethodNotImplemented("io.projectatomic.podman.RenameContainer")
 }
 
+func (s *VarlinkInterface) CreatePod(c VarlinkCall, create_ PodCreate) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.CreatePod")
+}
+
+func (s *VarlinkInterface) GetPod(c VarlinkCall, name_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.GetPod")
+}
+
+func (s *VarlinkInterface) InspectPod(c VarlinkCall, name_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.InspectPod")
+}
+
+func (s *VarlinkInterface) KillPod(c VarlinkCall, name_ string, signal_ int64) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.KillPod")
+}
+
+func (s *VarlinkInterface) ListPodContainers(c VarlinkCall, name_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.ListPodContainers")
+}
+
+func (s *VarlinkInterface) ListPods(c VarlinkCall) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.ListPods")
+}
+
+func (s *VarlinkInterface) RemovePod(c VarlinkCall, name_ string, force_ bool) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.RemovePod")
+}
+
+func (s *VarlinkInterface) StartPod(c VarlinkCall, name_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.StartPod")
+}
+
+func (s *VarlinkInterface) StopPod(c VarlinkCall, name_ string, timeout_ int64) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.StopPod")
+}
+
+func (s *VarlinkInterface) CopyFromContainer(c VarlinkCall, name_ string, path_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.CopyFromContainer")
+}
+
+func (s *VarlinkInterface) CopyToContainer(c VarlinkCall, name_ string, path_ string, archive_ string) error {
+	return c.ReplyMethodNotImplemented("io.projectatomic.podman.CopyToContainer")
+}
+
 // Method call dispatcher
 func (s *VarlinkInterface) VarlinkDispatch(call varlink.Call, methodname string) error {
 	switch methodname {
@@ -2241,7 +2839,15 @@ func (s *VarlinkInterface) VarlinkDispatch(call varlink.Call, methodname string)
 		return s.ioprojectatomicpodmanInterface.TagImage(VarlinkCall{call}, in.Name, in.Tagged)
 
 	case "UpdateContainer":
-		return s.ioprojectatomicpodmanInterface.UpdateContainer(VarlinkCall{call})
+		var in struct {
+			Name      string               `json:"name"`
+			Resources CreateResourceConfig `json:"resources"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.UpdateContainer(VarlinkCall{call}, in.Name, in.Resources)
 
 	case "PauseContainer":
 		var in struct {
@@ -2464,7 +3070,15 @@ func (s *VarlinkInterface) VarlinkDispatch(call varlink.Call, methodname string)
 		return s.ioprojectatomicpodmanInterface.StopContainer(VarlinkCall{call}, in.Name, in.Timeout)
 
 	case "RenameContainer":
-		return s.ioprojectatomicpodmanInterface.RenameContainer(VarlinkCall{call})
+		var in struct {
+			Name    string `json:"name"`
+			NewName string `json:"newName"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.RenameContainer(VarlinkCall{call}, in.Name, in.NewName)
 
 	case "CreateImage":
 		return s.ioprojectatomicpodmanInterface.CreateImage(VarlinkCall{call})
@@ -2560,6 +3174,115 @@ func (s *VarlinkInterface) VarlinkDispatch(call varlink.Call, methodname string)
 		}
 		return s.ioprojectatomicpodmanInterface.UnpauseContainer(VarlinkCall{call}, in.Name)
 
+	case "CreatePod":
+		var in struct {
+			Create PodCreate `json:"create"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.CreatePod(VarlinkCall{call}, in.Create)
+
+	case "GetPod":
+		var in struct {
+			Name string `json:"name"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.GetPod(VarlinkCall{call}, in.Name)
+
+	case "InspectPod":
+		var in struct {
+			Name string `json:"name"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.InspectPod(VarlinkCall{call}, in.Name)
+
+	case "KillPod":
+		var in struct {
+			Name   string `json:"name"`
+			Signal int64  `json:"signal"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.KillPod(VarlinkCall{call}, in.Name, in.Signal)
+
+	case "ListPodContainers":
+		var in struct {
+			Name string `json:"name"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.ListPodContainers(VarlinkCall{call}, in.Name)
+
+	case "ListPods":
+		return s.ioprojectatomicpodmanInterface.ListPods(VarlinkCall{call})
+
+	case "RemovePod":
+		var in struct {
+			Name  string `json:"name"`
+			Force bool   `json:"force"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.RemovePod(VarlinkCall{call}, in.Name, in.Force)
+
+	case "StartPod":
+		var in struct {
+			Name string `json:"name"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.StartPod(VarlinkCall{call}, in.Name)
+
+	case "StopPod":
+		var in struct {
+			Name    string `json:"name"`
+			Timeout int64  `json:"timeout"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.StopPod(VarlinkCall{call}, in.Name, in.Timeout)
+
+	case "CopyFromContainer":
+		var in struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.CopyFromContainer(VarlinkCall{call}, in.Name, in.Path)
+
+	case "CopyToContainer":
+		var in struct {
+			Name    string `json:"name"`
+			Path    string `json:"path"`
+			Archive string `json:"archive"`
+		}
+		err := call.GetParameters(&in)
+		if err != nil {
+			return call.ReplyInvalidParameter("parameters")
+		}
+		return s.ioprojectatomicpodmanInterface.CopyToContainer(VarlinkCall{call}, in.Name, in.Path, in.Archive)
+
 	default:
 		return call.ReplyMethodNotFound(methodname)
 	}
@@ -2865,6 +3588,45 @@ type IDMap (
     size: int
 )
 
+# PodCreate is an input structure for creating pods.  It emulates the options of podman pod create.
+# The infra container holds the namespaces listed in share, which default to ipc, net and uts when
+# share is empty.  Namespaces can only be shared if infra is true.
+type PodCreate (
+    name: string,
+    cgroupParent: string,
+    labels: [string]string,
+    share: []string,
+    infra: bool,
+    infraImage: string,
+    infraCommand: []string
+)
+
+# ListPodContainerInfo is a returned struct for describing containers in a pod.
+type ListPodContainerInfo (
+    name: string,
+    id: string,
+    status: string
+)
+
+# ListPodData is the returned struct for an individual pod
+type ListPodData (
+    id: string,
+    name: string,
+    createdat: string,
+    cgroup: string,
+    status: string,
+    labels: [string]string,
+    numberofcontainers: int,
+    containersinfo: []ListPodContainerInfo
+)
+
+# PodContainerErrorData describes the error of a single container of a pod, as returned
+# in a [PodContainerError](#PodContainerError).
+type PodContainerErrorData (
+    containerid: string,
+    reason: string
+)
+
 # Ping provides a response for developers to ensure their varlink setup is working.
 # #### Example
 # ~~~
@@ -2922,8 +3684,10 @@ method ListContainerProcesses(name: string, opts: []string) -> (container: []str
 
 # GetContainerLogs takes a name or ID of a container and returns the logs of that container.
 # If the container cannot be found, a [ContainerNotFound](#ContainerNotFound) error will be returned.
-# The container logs are returned as an array of strings.  GetContainerLogs will honor the streaming
-# capability of varlink if the client invokes it.
+# The container logs are returned as an array of strings, one for each line of output, holding its
+# timestamp, the stream it was written to, F and the output itself.  Lines the container wrote in
+# parts are returned whole.  GetContainerLogs will honor the streaming capability of varlink if the
+# client invokes it, returning each line as it is written until the container exits.
 method GetContainerLogs(name: string) -> (container: []string)
 
 # ListContainerChanges takes a name or ID of a container and returns changes between the container and
@@ -2937,6 +3701,20 @@ method ListContainerChanges(name: string) -> (container: ContainerChanges)
 # The return value is the written tarfile.
 method ExportContainer(name: string, path: string) -> (tarfile: string)
 
+# CopyFromContainer takes the name or ID of a container and a path in it, and returns a tar archive of that path,
+# encoded as base64.  Symlinks in the path are followed inside the container, and a path ending in "/." archives
+# only the contents of the directory.  CopyFromContainer will honor the streaming capability of varlink if the
+# client invokes it, returning the archive in several parts that must be joined after decoding, followed by a
+# last, empty part.  If the container cannot be found, a [ContainerNotFound](#ContainerNotFound) error will be
+# returned.
+method CopyFromContainer(name: string, path: string) -> (archive: string)
+
+# CopyToContainer takes the name or ID of a container, the path of a directory in it and a tar archive encoded as
+# base64, and extracts the archive into that directory.  The directory must already exist.  Once the archive has
+# been extracted, the ID of the container is returned.  If the container cannot be found, a
+# [ContainerNotFound](#ContainerNotFound) error will be returned.
+method CopyToContainer(name: string, path: string, archive: string) -> (container: string)
+
 # GetContainerStats takes the name or ID of a container and returns a single ContainerStats structure which
 # contains attributes like memory and cpu usage.  If the container cannot be found, a
 # [ContainerNotFound](#ContainerNotFound)  error will be returned.
@@ -2995,11 +3773,18 @@ method RestartContainer(name: string, timeout: int) -> (container: string)
 # [ContainerNotFound](#ContainerNotFound) error is returned. See also [StopContainer](StopContainer).
 method KillContainer(name: string, signal: int) -> (container: string)
 
-# This method has not be implemented yet.
-method UpdateContainer() -> (notimplemented: NotImplemented)
+# UpdateContainer takes the name or ID of a container and changes its resource limits.  Only the cpu_shares,
+# cpu_quota, cpu_period, cpus, cpuset_cpus, cpuset_mems, memory, memory_reservation, memory_swap, blkio_weight and
+# pids_limit of the given [CreateResourceConfig](#CreateResourceConfig) are used, and limits that are 0 are left
+# unchanged.  Running containers are updated immediately, and the new limits are kept when the container is
+# restarted.  Once the container has been updated, its ID is returned.  If the container cannot be found, a
+# [ContainerNotFound](#ContainerNotFound) error is returned.
+method UpdateContainer(name: string, resources: CreateResourceConfig) -> (container: string)
 
-# This method has not be implemented yet.
-method RenameContainer() -> (notimplemented: NotImplemented)
+# RenameContainer takes the name or ID of a container and a new name for it.  The new name must not be in use
+# by another container or pod.  Once the container has been renamed, its ID is returned.  If the container cannot
+# be found, a [ContainerNotFound](#ContainerNotFound) error is returned.
+method RenameContainer(name: string, newName: string) -> (container: string)
 
 # PauseContainer takes the name or ID of container and pauses it.  If the container cannot be found,
 # a [ContainerNotFound](#ContainerNotFound) error will be returned; otherwise the ID of the container is returned.
@@ -3133,6 +3918,73 @@ method ExportImage(name: string, destination: string, compress: bool, tags: []st
 # ~~~
 method PullImage(name: string) -> (id: string)
 
+# CreatePod creates a new empty pod.  It uses a [PodCreate](#PodCreate) type for input.  On success, the ID
+# of the newly created pod will be returned.
+# #### Example
+# ~~~
+# $ varlink call -m unix:/run/podman/io.projectatomic.podman/io.projectatomic.podman.CreatePod '{"create": {"name": "test", "infra": true}}'
+# {
+#   "pod": "b05dee7bd4ccfee688099fe1588a7a898d6ddd6897de9251d4671c9b0feacb2a"
+# }
+# ~~~
+method CreatePod(create: PodCreate) -> (pod: string)
+
+# ListPods returns a list of pods in no particular order.  They are
+# returned as an array of ListPodData structs.  See also [GetPod](#GetPod).
+method ListPods() -> (pods: []ListPodData)
+
+# GetPod takes a name or ID of a pod and returns single [ListPodData](#ListPodData)
+# structure.  A [PodNotFound](#PodNotFound) error will be returned if the pod cannot be found.
+# See also [ListPods](ListPods).
+method GetPod(name: string) -> (pod: ListPodData)
+
+# InspectPod takes the name or ID of a pod and returns the inspection data in string format.
+# You can then serialize the string into JSON.  A [PodNotFound](#PodNotFound) error will be
+# returned if the pod cannot be found.
+method InspectPod(name: string) -> (pod: string)
+
+# StartPod starts containers in a pod.  It takes the name or ID of pod.  If the pod cannot be found, a
+# [PodNotFound](#PodNotFound) error will be returned.  Containers in a pod are started independently.
+# If there is an error starting one container, the ID of those containers will be returned in a list,
+# along with the ID of the pod in a [PodContainerError](#PodContainerError).
+# If the pod was started with no errors, the pod ID is returned.
+# See also [CreatePod](#CreatePod).
+method StartPod(name: string) -> (pod: string)
+
+# StopPod stops containers in a pod.  It takes the name or ID of a pod and a timeout.  The timeout is the time
+# before a container is forcibly stopped; a timeout of -1 uses the stop timeout of each container.
+# Containers are stopped in the reverse order of their dependencies.  If the pod cannot be found, a
+# [PodNotFound](#PodNotFound) error will be returned instead.  If there is an error stopping one container,
+# the ID of those containers will be returned in a list, along with the ID of the pod in a
+# [PodContainerError](#PodContainerError).  If the pod was stopped with no errors, the pod ID is returned.
+# See also [KillPod](KillPod).
+method StopPod(name: string, timeout: int) -> (pod: string)
+
+# KillPod takes the name or ID of a pod as well as a signal to be applied to the pod.  If the pod cannot be found, a
+# [PodNotFound](#PodNotFound) error is returned.  A signal of -1 sends SIGKILL.  If there is an error
+# signalling one container, the ID of those containers will be returned in a list, along with the ID of the
+# pod in a [PodContainerError](#PodContainerError).  If the pod was killed with no errors, the pod ID is
+# returned.  See also [StopPod](StopPod).
+method KillPod(name: string, signal: int) -> (pod: string)
+
+# RemovePod takes the name or ID of a pod as well a boolean representing whether a running
+# container in the pod can be stopped and removed.  If a pod has containers associated with it, and force is not true,
+# an error will occur.  If the pod cannot be found by name or ID, a [PodNotFound](#PodNotFound) error will be
+# returned.  Upon successful removal of the pod, its ID is returned.
+# #### Example
+# ~~~
+# $ varlink call -m unix:/run/podman/io.projectatomic.podman/io.projectatomic.podman.RemovePod '{"name": "62f4fd98cb57", "force": true}'
+# {
+#   "pod": "62f4fd98cb57f529831e8f90610e54bba74bd6f02920ffb485e15376ed365c20"
+# }
+# ~~~
+method RemovePod(name: string, force: bool) -> (pod: string)
+
+# ListPodContainers takes the name or ID of a pod and returns the containers in the pod, including its
+# infra container, as an array of [ListContainerData](#ListContainerData) structs.  If the pod cannot be found,
+# a [PodNotFound](#PodNotFound) error will be returned.
+method ListPodContainers(name: string) -> (containers: []ListContainerData)
+
 
 # ImageNotFound means the image could not be found by the provided name or ID in local storage.
 error ImageNotFound (name: string)
@@ -3140,6 +3992,13 @@ error ImageNotFound (name: string)
 # ContainerNotFound means the container could not be found by the provided name or ID in local storage.
 error ContainerNotFound (name: string)
 
+# PodNotFound means the pod could not be found by the provided name or ID in local storage.
+error PodNotFound (name: string)
+
+# PodContainerError means a container associated with a pod failed to perform an operation. It contains
+# a container ID of the container that failed and the reason for the failure.
+error PodContainerError (podname: string, errors: []PodContainerErrorData)
+
 # ErrorOccurred is a generic error for an error that occurs during the execution.  The actual error message
 # is includes as part of the error's text.
 error ErrorOccurred (reason: string)
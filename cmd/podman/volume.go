@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	volumeDescription = `
+   podman volume
+
+   Manage volumes.
+   Volumes are directories managed by podman that are mounted into
+   containers, and keep their contents when the containers are removed.
+`
+	volumeSubCommands = []cli.Command{
+		volumeCreateCommand,
+		volumeInspectCommand,
+		volumeLsCommand,
+		volumePruneCommand,
+		volumeRmCommand,
+	}
+	volumeCommand = cli.Command{
+		Name:                   "volume",
+		Usage:                  "Manage volumes",
+		Description:            volumeDescription,
+		UseShortOptionHandling: true,
+		Subcommands:            volumeSubCommands,
+	}
+)
+
+// getVolumesFromContext returns the volumes named on the command line, or all
+// volumes if --all was given
+func getVolumesFromContext(c *cli.Context, r *libpod.Runtime) ([]*libpod.Volume, error) {
+	args := c.Args()
+	var vols []*libpod.Volume
+	var lastError error
+	var err error
+
+	if c.Bool("all") {
+		vols, err = r.GetAllVolumes()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get volumes")
+		}
+	}
+
+	for _, i := range args {
+		vol, err := r.GetVolume(i)
+		if err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "unable to find volume %s", i)
+			continue
+		}
+		vols = append(vols, vol)
+	}
+	return vols, lastError
+}
+
+// checkAllOrVolumes verifies that either --all or a list of volumes was given
+func checkAllOrVolumes(c *cli.Context) error {
+	argLen := len(c.Args())
+	if c.Bool("all") && argLen > 0 {
+		return errors.Errorf("no arguments are needed with --all")
+	}
+	if argLen < 1 && !c.Bool("all") {
+		return errors.Errorf("you must provide at least one volume name")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+var (
+	volumeCreateFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "driver",
+			Usage: "Specify volume driver name",
+			Value: libpod.LocalVolumeDriver,
+		},
+		cli.StringSliceFlag{
+			Name:  "label, l",
+			Usage: "Set metadata for a volume (default [])",
+		},
+		cli.StringSliceFlag{
+			Name:  "opt, o",
+			Usage: "Set driver specific options (default [])",
+		},
+	}
+	volumeCreateDescription = `
+   podman volume create
+
+   Creates a new volume. If no name is given, the volume is named with a
+   random ID. The name of the volume is printed to stdout.
+`
+	volumeCreateCommand = cli.Command{
+		Name:                   "create",
+		Usage:                  "Create a new volume",
+		Description:            volumeCreateDescription,
+		Flags:                  volumeCreateFlags,
+		Action:                 volumeCreateCmd,
+		ArgsUsage:              "[VOLUME-NAME]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func volumeCreateCmd(c *cli.Context) error {
+	if err := validateFlags(c, volumeCreateFlags); err != nil {
+		return err
+	}
+	args := c.Args()
+	if len(args) > 1 {
+		return errors.Errorf("too many arguments, create takes at most 1 argument")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	var options []libpod.VolumeCreateOption
+	if len(args) > 0 {
+		options = append(options, libpod.WithVolumeName(args[0]))
+	}
+	options = append(options, libpod.WithVolumeDriver(c.String("driver")))
+
+	labels, err := getAllLabels([]string{}, c.StringSlice("label"))
+	if err != nil {
+		return err
+	}
+	if len(labels) > 0 {
+		options = append(options, libpod.WithVolumeLabels(labels))
+	}
+
+	if len(c.StringSlice("opt")) > 0 {
+		opts := make(map[string]string)
+		for _, opt := range c.StringSlice("opt") {
+			split := strings.SplitN(opt, "=", 2)
+			if len(split) != 2 {
+				return errors.Errorf("invalid option %q, must be of the form KEY=VALUE", opt)
+			}
+			opts[split[0]] = split[1]
+		}
+		options = append(options, libpod.WithVolumeOptions(opts))
+	}
+
+	vol, err := runtime.NewVolume(getContext(), options...)
+	if err != nil {
+		return errors.Wrapf(err, "error creating volume")
+	}
+
+	fmt.Println(vol.Name())
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/urfave/cli"
+)
+
+var (
+	volumeInspectFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "Inspect all volumes",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "Format volume output using Go template",
+		},
+	}
+	volumeInspectDescription = `
+   podman volume inspect
+
+   Displays the configuration of one or more volumes, by default in JSON.
+`
+	volumeInspectCommand = cli.Command{
+		Name:                   "inspect",
+		Usage:                  "Display detailed information on one or more volumes",
+		Description:            volumeInspectDescription,
+		Flags:                  volumeInspectFlags,
+		Action:                 volumeInspectCmd,
+		ArgsUsage:              "[VOLUME-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func volumeInspectCmd(c *cli.Context) error {
+	if err := validateFlags(c, volumeInspectFlags); err != nil {
+		return err
+	}
+	if err := checkAllOrVolumes(c); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	vols, lastError := getVolumesFromContext(c, runtime)
+
+	var inspected []interface{}
+	for _, vol := range vols {
+		data, err := vol.Inspect()
+		if err != nil {
+			return err
+		}
+		inspected = append(inspected, data)
+	}
+
+	var out formats.Writer
+	if format := c.String("format"); format != "" && format != formats.JSONString {
+		out = formats.StdoutTemplateArray{Output: inspected, Template: format}
+	} else {
+		out = formats.JSONStructArray{Output: inspected}
+	}
+	if err := formats.Writer(out).Out(); err != nil {
+		return err
+	}
+	return lastError
+}
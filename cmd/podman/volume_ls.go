@@ -0,0 +1,183 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/formats"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/libpod"
+	"github.com/urfave/cli"
+)
+
+type volumeLsTemplateParams struct {
+	Name       string
+	Driver     string
+	Mountpoint string
+	CreatedAt  string
+	Labels     string
+}
+
+type volumeLsJSONParams struct {
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	Mountpoint string            `json:"mountpoint"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	Labels     map[string]string `json:"labels"`
+	Options    map[string]string `json:"options"`
+}
+
+var (
+	volumeLsFlags = []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "filter, f",
+			Usage: "Filter volume output (driver, label, name)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Format volume output using Go template",
+		},
+		cli.BoolFlag{
+			Name:  "quiet, q",
+			Usage: "Print volume output in quiet mode",
+		},
+	}
+	volumeLsDescription = `
+   podman volume ls
+
+   Lists the volumes, optionally filtered by driver, label or name.
+`
+	volumeLsCommand = cli.Command{
+		Name:                   "ls",
+		Aliases:                []string{"list"},
+		Usage:                  "List volumes",
+		Description:            volumeLsDescription,
+		Flags:                  volumeLsFlags,
+		Action:                 volumeLsCmd,
+		UseShortOptionHandling: true,
+	}
+)
+
+func volumeLsCmd(c *cli.Context) error {
+	if err := validateFlags(c, volumeLsFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) > 0 {
+		return errors.Errorf("'podman volume ls' does not take any arguments")
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	var filterFuncs []libpod.VolumeFilter
+	for _, f := range c.StringSlice("filter") {
+		filterFunc, err := generateVolumeFilterFunc(f)
+		if err != nil {
+			return err
+		}
+		filterFuncs = append(filterFuncs, filterFunc)
+	}
+
+	vols, err := runtime.Volumes(filterFuncs...)
+	if err != nil {
+		return err
+	}
+
+	var out formats.Writer
+	format := c.String("format")
+	switch {
+	case format == formats.JSONString:
+		var params []volumeLsJSONParams
+		for _, vol := range vols {
+			params = append(params, volumeLsJSONParams{
+				Name:       vol.Name(),
+				Driver:     vol.Driver(),
+				Mountpoint: vol.MountPoint(),
+				CreatedAt:  vol.CreatedTime(),
+				Labels:     vol.Labels(),
+				Options:    vol.Options(),
+			})
+		}
+		out = formats.JSONStructArray{Output: volumeLsToGeneric(nil, params)}
+	default:
+		if c.Bool("quiet") {
+			format = "{{.Name}}"
+		} else if format == "" {
+			format = "table {{.Driver}}\t{{.Name}}"
+		}
+		var params []volumeLsTemplateParams
+		for _, vol := range vols {
+			var labels []string
+			for key, value := range vol.Labels() {
+				labels = append(labels, key+"="+value)
+			}
+			params = append(params, volumeLsTemplateParams{
+				Name:       vol.Name(),
+				Driver:     vol.Driver(),
+				Mountpoint: vol.MountPoint(),
+				CreatedAt:  units.HumanDuration(time.Since(vol.CreatedTime())) + " ago",
+				Labels:     strings.Join(labels, ","),
+			})
+		}
+		out = formats.StdoutTemplateArray{
+			Output:   volumeLsToGeneric(params, nil),
+			Template: format,
+			Fields: map[string]string{
+				"Name":       "VOLUME NAME",
+				"Driver":     "DRIVER",
+				"Mountpoint": "MOUNTPOINT",
+				"CreatedAt":  "CREATED",
+				"Labels":     "LABELS",
+			},
+		}
+	}
+	return formats.Writer(out).Out()
+}
+
+// volumeLsToGeneric converts the volume params into a generic array
+func volumeLsToGeneric(templParams []volumeLsTemplateParams, JSONParams []volumeLsJSONParams) (genericParams []interface{}) {
+	if len(templParams) > 0 {
+		for _, v := range templParams {
+			genericParams = append(genericParams, interface{}(v))
+		}
+		return
+	}
+	for _, v := range JSONParams {
+		genericParams = append(genericParams, interface{}(v))
+	}
+	return
+}
+
+// generateVolumeFilterFunc returns the volume filter for a --filter value
+func generateVolumeFilterFunc(filter string) (libpod.VolumeFilter, error) {
+	split := strings.SplitN(filter, "=", 2)
+	if len(split) != 2 {
+		return nil, errors.Errorf("invalid filter %q, must be of the form KEY=VALUE", filter)
+	}
+	key, value := split[0], split[1]
+	switch key {
+	case "name":
+		return func(v *libpod.Volume) bool {
+			return strings.Contains(v.Name(), value)
+		}, nil
+	case "driver":
+		return func(v *libpod.Volume) bool {
+			return v.Driver() == value
+		}, nil
+	case "label":
+		labelSplit := strings.SplitN(value, "=", 2)
+		return func(v *libpod.Volume) bool {
+			labelValue, ok := v.Labels()[labelSplit[0]]
+			if !ok {
+				return false
+			}
+			return len(labelSplit) == 1 || labelValue == labelSplit[1]
+		}, nil
+	}
+	return nil, errors.Errorf("invalid filter %q", key)
+}
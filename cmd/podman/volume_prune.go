@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	volumePruneFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "Do not prompt for confirmation",
+		},
+	}
+	volumePruneDescription = `
+   podman volume prune
+
+   Removes all volumes that are not used by any container. The names of the
+   removed volumes are printed to stdout.
+`
+	volumePruneCommand = cli.Command{
+		Name:                   "prune",
+		Usage:                  "Remove all unused volumes",
+		Description:            volumePruneDescription,
+		Flags:                  volumePruneFlags,
+		Action:                 volumePruneCmd,
+		UseShortOptionHandling: true,
+	}
+)
+
+func volumePruneCmd(c *cli.Context) error {
+	if err := validateFlags(c, volumePruneFlags); err != nil {
+		return err
+	}
+	if len(c.Args()) > 0 {
+		return errors.Errorf("'podman volume prune' does not take any arguments")
+	}
+
+	if !c.Bool("force") {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Print("WARNING! This will remove all volumes not used by at least one container.\nAre you sure you want to continue? [y/N] ")
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return errors.Wrapf(err, "error reading input")
+		}
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			return nil
+		}
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	pruned, err := runtime.PruneVolumes(getContext())
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(pruned))
+	for name := range pruned {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lastError error
+	for _, name := range names {
+		if err := pruned[name]; err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "failed to remove volume %s", name)
+			continue
+		}
+		fmt.Println(name)
+	}
+	return lastError
+}
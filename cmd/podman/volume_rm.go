@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	volumeRmFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "Remove all volumes",
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "Remove a volume in use by first removing the containers using it.  The default is false",
+		},
+	}
+	volumeRmDescription = `
+   podman volume rm
+
+   Removes one or more volumes and their contents. Volumes in use by
+   containers are not removed unless --force is given, in which case the
+   containers using them are removed too.
+`
+	volumeRmCommand = cli.Command{
+		Name:                   "rm",
+		Aliases:                []string{"remove"},
+		Usage:                  "Remove one or more volumes",
+		Description:            volumeRmDescription,
+		Flags:                  volumeRmFlags,
+		Action:                 volumeRmCmd,
+		ArgsUsage:              "[VOLUME-NAME ...]",
+		UseShortOptionHandling: true,
+	}
+)
+
+func volumeRmCmd(c *cli.Context) error {
+	if err := validateFlags(c, volumeRmFlags); err != nil {
+		return err
+	}
+	if err := checkAllOrVolumes(c); err != nil {
+		return err
+	}
+
+	runtime, err := libpodruntime.GetRuntime(c)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.Shutdown(false)
+
+	ctx := getContext()
+
+	// getVolumesFromContext returns an error when a requested volume
+	// isn't found, but the volumes that were found are still removed
+	vols, lastError := getVolumesFromContext(c, runtime)
+
+	for _, vol := range vols {
+		if err := runtime.RemoveVolume(ctx, vol, c.Bool("force")); err != nil {
+			if lastError != nil {
+				logrus.Errorf("%q", lastError)
+			}
+			lastError = errors.Wrapf(err, "failed to remove volume %s", vol.Name())
+		} else {
+			fmt.Println(vol.Name())
+		}
+	}
+	return lastError
+}
@@ -4,7 +4,7 @@ import pkg_resources
 from .client import Client
 from .libs import datetime_format, datetime_parse
 from .libs.errors import (ContainerNotFound, ErrorOccurred, ImageNotFound,
-                          RuntimeError)
+                          PodContainerError, PodNotFound, RuntimeError)
 
 try:
     __version__ = pkg_resources.get_distribution('podman').version
@@ -18,5 +18,7 @@ __all__ = [
     'datetime_parse',
     'ErrorOccurred',
     'ImageNotFound',
+    'PodContainerError',
+    'PodNotFound',
     'RuntimeError',
 ]
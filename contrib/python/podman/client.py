@@ -9,6 +9,7 @@ from .libs import cached_property
 from .libs.containers import Containers
 from .libs.errors import error_factory
 from .libs.images import Images
+from .libs.pods import Pods
 from .libs.system import System
 
 
@@ -84,3 +85,8 @@ class Client(object):
     def containers(self):
         """Manage containers model for libpod."""
         return Containers(self._client)
+
+    @cached_property
+    def pods(self):
+        """Manage pods model for libpod."""
+        return Pods(self._client)
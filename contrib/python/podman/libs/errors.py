@@ -27,6 +27,21 @@ class ImageNotFound(VarlinkErrorProxy):
     pass
 
 
+class PodNotFound(VarlinkErrorProxy):
+    """Raised when Client can not find requested pod."""
+
+    pass
+
+
+class PodContainerError(VarlinkErrorProxy):
+    """Raised when containers of a pod fail an operation.
+
+    See errors() to see the containers and their error text.
+    """
+
+    pass
+
+
 class ErrorOccurred(VarlinkErrorProxy):
     """Raised when an error occurs during the execution.
 
@@ -46,6 +61,8 @@ error_map = {
     'io.projectatomic.podman.ContainerNotFound': ContainerNotFound,
     'io.projectatomic.podman.ErrorOccurred': ErrorOccurred,
     'io.projectatomic.podman.ImageNotFound': ImageNotFound,
+    'io.projectatomic.podman.PodContainerError': PodContainerError,
+    'io.projectatomic.podman.PodNotFound': PodNotFound,
     'io.projectatomic.podman.RuntimeError': RuntimeError,
 }
 
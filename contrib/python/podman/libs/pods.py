@@ -0,0 +1,136 @@
+"""Models for manipulating pods."""
+import collections
+import json
+
+from .containers import Container
+
+
+class Pod(collections.UserDict):
+    """Model for a pod."""
+
+    def __init__(self, client, id, data):
+        """Construct Pod Model."""
+        super(Pod, self).__init__(data)
+
+        self._client = client
+        self._id = id
+
+        with client() as podman:
+            self._refresh(podman)
+
+        assert self._id == self.data['id'],\
+            'Requested pod id({}) does not match store id({})'.format(
+                self._id, self.id
+            )
+
+    def __getitem__(self, key):
+        """Get items from parent dict."""
+        return super().__getitem__(key)
+
+    def _refresh(self, podman):
+        pod = podman.GetPod(self._id)
+        super().update(pod['pod'])
+
+        for k, v in self.data.items():
+            setattr(self, k, v)
+        return self
+
+    def refresh(self):
+        """Refresh status fields for this pod."""
+        with self._client() as podman:
+            return self._refresh(podman)
+
+    def containers(self):
+        """List containers that are members of the pod."""
+        with self._client() as podman:
+            results = podman.ListPodContainers(self.id)
+        for cntr in results['containers']:
+            yield Container(self._client, cntr['id'], cntr)
+
+    def inspect(self):
+        """Retrieve details about pod."""
+        with self._client() as podman:
+            results = podman.InspectPod(self.id)
+        obj = json.loads(results['pod'])
+        return collections.namedtuple('PodInspect', obj.keys())(**obj)
+
+    def start(self):
+        """Start all containers in the pod, return pod on success."""
+        with self._client() as podman:
+            podman.StartPod(self.id)
+            return self._refresh(podman)
+
+    def stop(self, timeout=-1):
+        """Stop all containers in the pod, return pod on success.
+
+        timeout=-1, use each container's default stop timeout.
+        """
+        with self._client() as podman:
+            podman.StopPod(self.id, timeout)
+            return self._refresh(podman)
+
+    def kill(self, signal=-1):
+        """Send signal to all containers in the pod, return pod on success.
+
+        signal=-1, send SIGKILL.
+        """
+        with self._client() as podman:
+            podman.KillPod(self.id, signal)
+            return self._refresh(podman)
+
+    def remove(self, force=False):
+        """Remove pod, return id on success.
+
+        force=True, stop and remove all containers in the pod.
+        """
+        with self._client() as podman:
+            results = podman.RemovePod(self.id, force)
+        return results['pod']
+
+
+class Pods(object):
+    """Model for Pods collection."""
+
+    def __init__(self, client):
+        """Construct model for Pods collection."""
+        self._client = client
+
+    def create(self,
+               name=None,
+               cgroupparent=None,
+               labels=None,
+               share=None,
+               infra=True,
+               infraimage=None,
+               infracommand=None):
+        """Create a new pod, return Pod."""
+        create = {'infra': infra}
+        if name:
+            create['name'] = name
+        if cgroupparent:
+            create['cgroupParent'] = cgroupparent
+        if labels:
+            create['labels'] = labels
+        if share:
+            create['share'] = share
+        if infraimage:
+            create['infraImage'] = infraimage
+        if infracommand:
+            create['infraCommand'] = infracommand
+
+        with self._client() as podman:
+            results = podman.CreatePod(create)
+        return self.get(results['pod'])
+
+    def list(self):
+        """List of pods in the pod store."""
+        with self._client() as podman:
+            results = podman.ListPods()
+        for pod in results['pods']:
+            yield Pod(self._client, pod['id'], pod)
+
+    def get(self, id):
+        """Retrieve pod details from store."""
+        with self._client() as podman:
+            pod = podman.GetPod(id)
+        return Pod(self._client, pod['pod']['id'], pod['pod'])
@@ -0,0 +1,66 @@
+import os
+import unittest
+from test.podman_testcase import PodmanTestCase
+
+import podman
+
+
+class TestPods(PodmanTestCase):
+    @classmethod
+    def setUpClass(cls):
+        super().setUpClass()
+
+    @classmethod
+    def tearDownClass(cls):
+        super().tearDownClass()
+
+    def setUp(self):
+        self.tmpdir = os.environ['TMPDIR']
+        self.host = os.environ['PODMAN_HOST']
+
+        self.pclient = podman.Client(self.host)
+        self.pod = self.pclient.pods.create(name='podman_test_pod')
+
+    def tearDown(self):
+        self.pod.remove(force=True)
+
+    def test_list(self):
+        actual = [p.id for p in self.pclient.pods.list()]
+        self.assertIn(self.pod.id, actual)
+
+    def test_get(self):
+        actual = self.pclient.pods.get(self.pod.name)
+        for k in ['id', 'name', 'cgroup', 'labels']:
+            self.assertEqual(actual[k], self.pod[k])
+
+        with self.assertRaises(podman.PodNotFound):
+            self.pclient.pods.get('bozo')
+
+    def test_inspect(self):
+        actual = self.pod.inspect()
+        self.assertEqual(actual.Config['id'], self.pod.id)
+
+    def test_start_stop(self):
+        self.assertEqual(self.pod.numberofcontainers, 1)
+
+        pod = self.pod.start()
+        self.assertEqual(pod.status, 'Running')
+
+        pod.stop()
+        self.assertIn(pod.status, ['Exited', 'Stopped'])
+
+    def test_containers(self):
+        actual = list(self.pod.containers())
+        self.assertEqual(len(actual), 1)
+
+    def test_remove(self):
+        pod = self.pclient.pods.create()
+        actual = pod.remove()
+        self.assertEqual(actual, pod.id)
+
+        with self.assertRaises(podman.PodNotFound):
+            self.pclient.pods.get(pod.id)
+
+
+if __name__ == '__main__':
+    unittest.main()
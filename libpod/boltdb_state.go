@@ -59,6 +59,12 @@ func NewBoltState(path, lockDir string, runtime *Runtime) (State, error) {
 		if _, err := tx.CreateBucketIfNotExists(allPodsBkt); err != nil {
 			return errors.Wrapf(err, "error creating all pods bucket")
 		}
+		if _, err := tx.CreateBucketIfNotExists(volBkt); err != nil {
+			return errors.Wrapf(err, "error creating volumes bucket")
+		}
+		if _, err := tx.CreateBucketIfNotExists(allVolsBkt); err != nil {
+			return errors.Wrapf(err, "error creating all volumes bucket")
+		}
 		if _, err := tx.CreateBucketIfNotExists(runtimeConfigBkt); err != nil {
 			return errors.Wrapf(err, "error creating runtime-config bucket")
 		}
@@ -180,6 +186,8 @@ func (s *BoltState) Refresh() error {
 			state.ExecSessions = make(map[string]*ExecSession)
 			state.IPs = nil
 			state.Routes = nil
+			state.Interfaces = nil
+			state.NetworkStatus = nil
 			state.BindMounts = make(map[string]string)
 
 			newStateBytes, err := json.Marshal(state)
@@ -193,7 +201,52 @@ func (s *BoltState) Refresh() error {
 
 			return nil
 		})
-		return err
+		if err != nil {
+			return err
+		}
+
+		volsBucket, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		allVolsBucket, err := getAllVolsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		// Volume filesystems are no longer mounted, so clear the
+		// mount counts of all volumes
+		return allVolsBucket.ForEach(func(name, value []byte) error {
+			volBkt := volsBucket.Bucket(name)
+			if volBkt == nil {
+				return errors.Wrapf(ErrInternal, "volume %s is in all volumes bucket but volume not found", string(name))
+			}
+
+			stateBytes := volBkt.Get(stateKey)
+			if stateBytes == nil {
+				return errors.Wrapf(ErrInternal, "volume %s missing state key", string(name))
+			}
+
+			state := new(volumeState)
+
+			if err := json.Unmarshal(stateBytes, state); err != nil {
+				return errors.Wrapf(err, "error unmarshalling state for volume %s", string(name))
+			}
+
+			state.MountCount = 0
+
+			newStateBytes, err := json.Marshal(state)
+			if err != nil {
+				return errors.Wrapf(err, "error marshalling modified state for volume %s", string(name))
+			}
+
+			if err := volBkt.Put(stateKey, newStateBytes); err != nil {
+				return errors.Wrapf(err, "error updating state for volume %s in DB", string(name))
+			}
+
+			return nil
+		})
 	})
 	return err
 }
@@ -538,6 +591,181 @@ func (s *BoltState) SaveContainer(ctr *Container) error {
 	return err
 }
 
+// RenameContainer changes the name of a container
+// The container's name is updated in the name registry, in its configuration,
+// and everywhere it is recorded alongside its ID
+func (s *BoltState) RenameContainer(ctr *Container, newName string) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return ErrCtrRemoved
+	}
+
+	newConfig := *ctr.config
+	newConfig.Name = newName
+	configJSON, err := json.Marshal(&newConfig)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling container %s config to JSON", ctr.ID())
+	}
+
+	ctrID := []byte(ctr.ID())
+	oldName := []byte(ctr.Name())
+	ctrName := []byte(newName)
+	dependsCtrs := ctr.Dependencies()
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		idsBucket, err := getIDBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		namesBucket, err := getNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		allCtrsBucket, err := getAllCtrsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrDB := ctrBucket.Bucket(ctrID)
+		if ctrDB == nil {
+			ctr.valid = false
+			return errors.Wrapf(ErrNoSuchCtr, "no container with ID %s found in DB", ctr.ID())
+		}
+
+		// Pods and containers share the names bucket, so this catches
+		// both
+		if namesBucket.Get(ctrName) != nil {
+			return errors.Wrapf(ErrCtrExists, "name %s is in use", newName)
+		}
+
+		if err := namesBucket.Delete(oldName); err != nil {
+			return errors.Wrapf(err, "error removing container %s old name (%s) from DB", ctr.ID(), ctr.Name())
+		}
+		if err := namesBucket.Put(ctrName, ctrID); err != nil {
+			return errors.Wrapf(err, "error adding container %s name (%s) to DB", ctr.ID(), newName)
+		}
+		if err := idsBucket.Put(ctrID, ctrName); err != nil {
+			return errors.Wrapf(err, "error updating container %s name in ID registry", ctr.ID())
+		}
+		if err := allCtrsBucket.Put(ctrID, ctrName); err != nil {
+			return errors.Wrapf(err, "error updating container %s name in all containers bucket in DB", ctr.ID())
+		}
+		if err := ctrDB.Put(configKey, configJSON); err != nil {
+			return errors.Wrapf(err, "error updating container %s config in DB", ctr.ID())
+		}
+
+		// Update the container's entry in the pod it is part of
+		if podID := ctrDB.Get(podIDKey); podID != nil {
+			podBucket, err := getPodBucket(tx)
+			if err != nil {
+				return err
+			}
+			podDB := podBucket.Bucket(podID)
+			if podDB == nil {
+				return errors.Wrapf(ErrInternal, "container %s is in pod %s which does not exist in DB", ctr.ID(), string(podID))
+			}
+			podCtrs := podDB.Bucket(containersBkt)
+			if podCtrs == nil {
+				return errors.Wrapf(ErrInternal, "pod %s does not have a containers bucket", string(podID))
+			}
+			if err := podCtrs.Put(ctrID, ctrName); err != nil {
+				return errors.Wrapf(err, "error updating container %s name in pod %s", ctr.ID(), string(podID))
+			}
+		}
+
+		// Update the container's entry in the containers it depends on
+		for _, dependsCtr := range dependsCtrs {
+			depCtrBkt := ctrBucket.Bucket([]byte(dependsCtr))
+			if depCtrBkt == nil {
+				return errors.Wrapf(ErrNoSuchCtr, "container %s depends on container %s, but it does not exist in the DB", ctr.ID(), dependsCtr)
+			}
+			depCtrDependsBkt := depCtrBkt.Bucket(dependenciesBkt)
+			if depCtrDependsBkt == nil {
+				return errors.Wrapf(ErrInternal, "container %s does not have a dependencies bucket", dependsCtr)
+			}
+			if err := depCtrDependsBkt.Put(ctrID, ctrName); err != nil {
+				return errors.Wrapf(err, "error updating container %s name as dependency of container %s", ctr.ID(), dependsCtr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctr.config.Name = newName
+
+	return nil
+}
+
+// RewriteContainerConfig replaces the configuration of a container in the
+// database
+func (s *BoltState) RewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return ErrCtrRemoved
+	}
+
+	configJSON, err := json.Marshal(newCfg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling container %s config to JSON", ctr.ID())
+	}
+
+	ctrID := []byte(ctr.ID())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		ctrBucket, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrDB := ctrBucket.Bucket(ctrID)
+		if ctrDB == nil {
+			ctr.valid = false
+			return errors.Wrapf(ErrNoSuchCtr, "no container with ID %s found in DB", ctr.ID())
+		}
+
+		if err := ctrDB.Put(configKey, configJSON); err != nil {
+			return errors.Wrapf(err, "error updating container %s config in DB", ctr.ID())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctr.config = newCfg
+
+	return nil
+}
+
 // ContainerInUse checks if other containers depend on the given container
 // It returns a slice of the IDs of the containers depending on the given
 // container. If the slice is empty, no containers depend on the given container
@@ -1252,6 +1480,21 @@ func (s *BoltState) RemovePodContainers(pod *Pod) error {
 
 			// Dependencies are set, we're clear to remove
 
+			// Get the container's named volumes, so it can be
+			// removed from their users
+			configBytes := ctr.Get(configKey)
+			if configBytes == nil {
+				return errors.Wrapf(ErrInternal, "container %s missing config key in DB", string(id))
+			}
+			ctrToRemove := new(Container)
+			ctrToRemove.config = new(ContainerConfig)
+			if err := json.Unmarshal(configBytes, ctrToRemove.config); err != nil {
+				return errors.Wrapf(err, "error unmarshalling container %s config", string(id))
+			}
+			if err := removeVolumeUser(ctrToRemove, tx); err != nil {
+				return err
+			}
+
 			if err := ctrBkt.DeleteBucket(id); err != nil {
 				return errors.Wrapf(ErrInternal, "error deleting container %s from DB", string(id))
 			}
@@ -1495,3 +1738,435 @@ func (s *BoltState) AllPods() ([]*Pod, error) {
 
 	return pods, nil
 }
+
+// Volume retrieves a volume given its full name
+func (s *BoltState) Volume(name string) (*Volume, error) {
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	volName := []byte(name)
+
+	volume := new(Volume)
+	volume.config = new(VolumeConfig)
+	volume.state = new(volumeState)
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return s.getVolumeFromDB(volName, volume, volBkt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
+// HasVolume checks if a volume with the given name exists in the state
+func (s *BoltState) HasVolume(name string) (bool, error) {
+	if name == "" {
+		return false, ErrEmptyID
+	}
+
+	if !s.valid {
+		return false, ErrDBClosed
+	}
+
+	volName := []byte(name)
+
+	exists := false
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB != nil {
+			exists = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AddVolume adds the given volume to the state
+func (s *BoltState) AddVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	volName := []byte(volume.Name())
+
+	volConfigJSON, err := json.Marshal(volume.config)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s config to JSON", volume.Name())
+	}
+
+	volStateJSON, err := json.Marshal(volume.state)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s state to JSON", volume.Name())
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		allVolsBkt, err := getAllVolsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		// Check if we already have a volume with the given name
+		if volBkt.Bucket(volName) != nil {
+			return errors.Wrapf(ErrVolumeExists, "name %s is in use", volume.Name())
+		}
+
+		// Make a bucket for the volume
+		newVol, err := volBkt.CreateBucket(volName)
+		if err != nil {
+			return errors.Wrapf(err, "error creating bucket for volume %s", volume.Name())
+		}
+
+		// Make a subbucket for the containers using the volume
+		if _, err := newVol.CreateBucket(dependenciesBkt); err != nil {
+			return errors.Wrapf(err, "error creating bucket for volume %s dependencies", volume.Name())
+		}
+
+		if err := newVol.Put(configKey, volConfigJSON); err != nil {
+			return errors.Wrapf(err, "error storing volume %s configuration in DB", volume.Name())
+		}
+
+		if err := newVol.Put(stateKey, volStateJSON); err != nil {
+			return errors.Wrapf(err, "error storing volume %s state JSON in DB", volume.Name())
+		}
+
+		if err := allVolsBkt.Put(volName, volName); err != nil {
+			return errors.Wrapf(err, "error storing volume %s in all volumes bucket in DB", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveVolume removes the given volume from the state
+// Only volumes not used by any container can be removed
+func (s *BoltState) RemoveVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		allVolsBkt, err := getAllVolsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		// Check if the volume exists
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "volume %s does not exist in DB", volume.Name())
+		}
+
+		// Check if containers use the volume
+		// This should never be nil, but if it is, we can assume no
+		// containers use the volume
+		volDepsBkt := volDB.Bucket(dependenciesBkt)
+		if volDepsBkt != nil {
+			var deps []string
+			err = volDepsBkt.ForEach(func(id, value []byte) error {
+				deps = append(deps, string(id))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(deps) != 0 {
+				return errors.Wrapf(ErrVolumeBeingUsed, "volume %s is being used by the following container(s): %s", volume.Name(), strings.Join(deps, ", "))
+			}
+		}
+
+		// Volume is unused, and ready for removal
+		if err := allVolsBkt.Delete(volName); err != nil {
+			return errors.Wrapf(err, "error removing volume %s from all volumes bucket in DB", volume.Name())
+		}
+		if err := volBkt.DeleteBucket(volName); err != nil {
+			return errors.Wrapf(err, "error removing volume %s from DB", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateVolume updates a volume's state from the database
+func (s *BoltState) UpdateVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	newState := new(volumeState)
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found in database", volume.Name())
+		}
+
+		// Get the volume state JSON
+		volStateBytes := volDB.Get(stateKey)
+		if volStateBytes == nil {
+			return errors.Wrapf(ErrInternal, "volume %s is missing state key in DB", volume.Name())
+		}
+
+		if err := json.Unmarshal(volStateBytes, newState); err != nil {
+			return errors.Wrapf(err, "error unmarshalling volume %s state JSON", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	volume.state = newState
+
+	return nil
+}
+
+// SaveVolume saves a volume's state to the database
+func (s *BoltState) SaveVolume(volume *Volume) error {
+	if !s.valid {
+		return ErrDBClosed
+	}
+
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	stateJSON, err := json.Marshal(volume.state)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s state to JSON", volume.Name())
+	}
+
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found in database", volume.Name())
+		}
+
+		// Set the volume state JSON
+		if err := volDB.Put(stateKey, stateJSON); err != nil {
+			return errors.Wrapf(err, "error updating volume %s state in database", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VolumeInUse checks if any container is using the volume
+// It returns a slice of the IDs of the containers using the given volume
+func (s *BoltState) VolumeInUse(volume *Volume) ([]string, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	if !volume.valid {
+		return nil, ErrVolumeRemoved
+	}
+
+	depCtrs := []string{}
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found in database", volume.Name())
+		}
+
+		volDepsBkt := volDB.Bucket(dependenciesBkt)
+		if volDepsBkt == nil {
+			return errors.Wrapf(ErrInternal, "volume %s has no dependencies bucket", volume.Name())
+		}
+
+		// Iterate through and add dependencies
+		err = volDepsBkt.ForEach(func(id, value []byte) error {
+			depCtrs = append(depCtrs, string(id))
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return depCtrs, nil
+}
+
+// AllVolumes returns all volumes present in the state
+func (s *BoltState) AllVolumes() ([]*Volume, error) {
+	if !s.valid {
+		return nil, ErrDBClosed
+	}
+
+	volumes := []*Volume{}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		allVolsBucket, err := getAllVolsBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volBucket, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		err = allVolsBucket.ForEach(func(name, value []byte) error {
+			volExists := volBucket.Bucket(name)
+			// This check can be removed if performance becomes an
+			// issue, but much less helpful errors will be produced
+			if volExists == nil {
+				return errors.Wrapf(ErrInternal, "inconsistency in state - volume %s is in all volumes bucket but volume not found", string(name))
+			}
+
+			volume := new(Volume)
+			volume.config = new(VolumeConfig)
+			volume.state = new(volumeState)
+
+			volumes = append(volumes, volume)
+
+			return s.getVolumeFromDB(name, volume, volBucket)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
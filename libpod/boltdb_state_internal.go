@@ -18,6 +18,8 @@ const (
 	allCtrsName       = "all-ctrs"
 	podName           = "pod"
 	allPodsName       = "allPods"
+	volName           = "vol"
+	allVolsName       = "allVolumes"
 	runtimeConfigName = "runtime-config"
 
 	configName       = "config"
@@ -35,6 +37,8 @@ var (
 	allCtrsBkt       = []byte(allCtrsName)
 	podBkt           = []byte(podName)
 	allPodsBkt       = []byte(allPodsName)
+	volBkt           = []byte(volName)
+	allVolsBkt       = []byte(allVolsName)
 	runtimeConfigBkt = []byte(runtimeConfigName)
 
 	configKey       = []byte(configName)
@@ -55,6 +59,7 @@ func checkRuntimeConfig(db *bolt.DB, runtime *Runtime) error {
 		runRoot         = []byte("run-root")
 		graphRoot       = []byte("graph-root")
 		graphDriverName = []byte("graph-driver-name")
+		volPath         = []byte("volume-path")
 	)
 
 	err := db.Update(func(tx *bolt.Tx) error {
@@ -85,6 +90,11 @@ func checkRuntimeConfig(db *bolt.DB, runtime *Runtime) error {
 			return err
 		}
 
+		if err := validateDBAgainstConfig(configBkt, "volume path",
+			runtime.config.VolumePath, volPath, ""); err != nil {
+			return err
+		}
+
 		return validateDBAgainstConfig(configBkt, "graph driver name",
 			runtime.config.StorageConfig.GraphDriverName,
 			graphDriverName,
@@ -191,6 +201,22 @@ func getAllPodsBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	return bkt, nil
 }
 
+func getVolBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(volBkt)
+	if bkt == nil {
+		return nil, errors.Wrapf(ErrDBBadConfig, "volumes bucket not found in DB")
+	}
+	return bkt, nil
+}
+
+func getAllVolsBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(allVolsBkt)
+	if bkt == nil {
+		return nil, errors.Wrapf(ErrDBBadConfig, "all volumes bucket not found in DB")
+	}
+	return bkt, nil
+}
+
 func getRuntimeConfigBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	bkt := tx.Bucket(runtimeConfigBkt)
 	if bkt == nil {
@@ -288,6 +314,43 @@ func (s *BoltState) getPodFromDB(id []byte, pod *Pod, podBkt *bolt.Bucket) error
 	return nil
 }
 
+func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bucket) error {
+	volDB := volBkt.Bucket(name)
+	if volDB == nil {
+		return errors.Wrapf(ErrNoSuchVolume, "volume with name %s not found", string(name))
+	}
+
+	volConfigBytes := volDB.Get(configKey)
+	if volConfigBytes == nil {
+		return errors.Wrapf(ErrInternal, "volume %s is missing configuration key in DB", string(name))
+	}
+
+	if err := json.Unmarshal(volConfigBytes, volume.config); err != nil {
+		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
+	}
+
+	volStateBytes := volDB.Get(stateKey)
+	if volStateBytes == nil {
+		return errors.Wrapf(ErrInternal, "volume %s is missing state key in DB", string(name))
+	}
+
+	if err := json.Unmarshal(volStateBytes, volume.state); err != nil {
+		return errors.Wrapf(err, "error unmarshalling volume %s state from DB", string(name))
+	}
+
+	// Get the lock
+	lock, err := storage.GetLockfile(volumeLockPath(s.lockDir, string(name)))
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving lockfile for volume %s", string(name))
+	}
+	volume.lock = lock
+
+	volume.runtime = s.runtime
+	volume.valid = true
+
+	return nil
+}
+
 // Add a container to the DB
 // If pod is not nil, the container is added to the pod as well
 func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
@@ -442,6 +505,29 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 			}
 		}
 
+		// Add the container as a user of its named volumes
+		if len(ctr.config.NamedVolumes) > 0 {
+			volBucket, err := getVolBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			for _, namedVol := range ctr.config.NamedVolumes {
+				volDB := volBucket.Bucket([]byte(namedVol.Name))
+				if volDB == nil {
+					return errors.Wrapf(ErrNoSuchVolume, "container %s uses volume %s, but it does not exist in the DB", ctr.ID(), namedVol.Name)
+				}
+
+				volDepsBkt := volDB.Bucket(dependenciesBkt)
+				if volDepsBkt == nil {
+					return errors.Wrapf(ErrInternal, "volume %s does not have a dependencies bucket", namedVol.Name)
+				}
+				if err := volDepsBkt.Put(ctrID, ctrName); err != nil {
+					return errors.Wrapf(err, "error adding container %s as user of volume %s", ctr.ID(), namedVol.Name)
+				}
+			}
+		}
+
 		// Add ctr to pod
 		if pod != nil {
 			if err := podCtrs.Put(ctrID, ctrName); err != nil {
@@ -585,5 +671,40 @@ func removeContainer(ctr *Container, pod *Pod, tx *bolt.Tx) error {
 		}
 	}
 
+	return removeVolumeUser(ctr, tx)
+}
+
+// Remove a container from the users of its named volumes
+func removeVolumeUser(ctr *Container, tx *bolt.Tx) error {
+	if len(ctr.config.NamedVolumes) == 0 {
+		return nil
+	}
+
+	volBucket, err := getVolBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, namedVol := range ctr.config.NamedVolumes {
+		volDB := volBucket.Bucket([]byte(namedVol.Name))
+		if volDB == nil {
+			// The volume cannot be removed while the container
+			// uses it, so the state is inconsistent, but the
+			// container is being removed anyways
+			logrus.Errorf("Volume %s used by container %s is missing from DB", namedVol.Name, ctr.ID())
+			continue
+		}
+
+		volDepsBkt := volDB.Bucket(dependenciesBkt)
+		if volDepsBkt == nil {
+			logrus.Errorf("Volume %s is missing dependencies bucket in DB", namedVol.Name)
+			continue
+		}
+
+		if err := volDepsBkt.Delete([]byte(ctr.ID())); err != nil {
+			return errors.Wrapf(err, "error removing container %s as a user of volume %s", ctr.ID(), namedVol.Name)
+		}
+	}
+
 	return nil
 }
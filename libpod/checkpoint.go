@@ -0,0 +1,416 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// checkpointDir is the directory in the container's bundle that holds
+	// its checkpoint
+	checkpointDir = "checkpoint"
+	// checkpointConfigFile holds the configuration of the container in an
+	// exported checkpoint
+	checkpointConfigFile = "config.dump"
+	// checkpointRootfsDiffFile holds the changes to the container's root
+	// filesystem in an exported checkpoint
+	checkpointRootfsDiffFile = "rootfs-diff.tar"
+)
+
+// Files written to the container's bundle by CRIU while checkpointing and
+// restoring, which are removed unless the checkpoint is to be kept
+var checkpointLogFiles = []string{"dump.log", "stats-dump", "restore.log", "stats-restore"}
+
+// ContainerCheckpointOptions describes how a container is checkpointed
+type ContainerCheckpointOptions struct {
+	// Keep keeps the logs written by CRIU while checkpointing
+	Keep bool
+	// KeepRunning leaves the container running once the checkpoint has
+	// been written
+	KeepRunning bool
+	// TCPEstablished allows checkpointing a container with established
+	// TCP connections
+	TCPEstablished bool
+	// TargetFile is a tarball to export the checkpoint to, together with
+	// the configuration of the container and the changes to its root
+	// filesystem, so it can be imported with ImportCheckpoint
+	TargetFile string
+}
+
+// ContainerRestoreOptions describes how a container is restored
+type ContainerRestoreOptions struct {
+	// Keep keeps the checkpoint, and the logs written by CRIU while
+	// restoring, once the container has been restored
+	Keep bool
+	// TCPEstablished restores the established TCP connections of a
+	// container that was checkpointed with them
+	TCPEstablished bool
+}
+
+// Get the path of the directory holding the container's checkpoint
+func (c *Container) checkpointPath() string {
+	return filepath.Join(c.bundlePath(), checkpointDir)
+}
+
+// Remove the logs written to the container's bundle by CRIU
+func (c *Container) removeCheckpointLogs() {
+	for _, file := range checkpointLogFiles {
+		if err := os.Remove(filepath.Join(c.bundlePath(), file)); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Error removing checkpoint log %s of container %s: %v", file, c.ID(), err)
+		}
+	}
+}
+
+// Checkpoint a container
+// Must be called with the container locked
+func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointOptions) error {
+	if c.state.State != ContainerStateRunning {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s is not running, cannot checkpoint it", c.ID())
+	}
+	if c.hasRunningExecSessions() {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s has active exec sessions, cannot checkpoint it", c.ID())
+	}
+
+	// Remove any previous checkpoint, so a failed checkpoint cannot be
+	// mistaken for a complete one
+	if err := os.RemoveAll(c.checkpointPath()); err != nil {
+		return errors.Wrapf(err, "error removing previous checkpoint of container %s", c.ID())
+	}
+
+	if err := c.runtime.ociRuntime.checkpointContainer(c, options); err != nil {
+		return errors.Wrapf(err, "error checkpointing container %s", c.ID())
+	}
+
+	logrus.Debugf("Checkpointed container %s", c.ID())
+
+	if !options.Keep {
+		c.removeCheckpointLogs()
+	}
+
+	if !options.KeepRunning {
+		// CRIU stopped the container's processes once the checkpoint
+		// was written, so sync to pick up its exit
+		// The state is recorded before exporting the checkpoint, as the
+		// container is stopped whether or not the export succeeds
+		if err := c.runtime.ociRuntime.updateContainerStatus(c); err != nil {
+			return err
+		}
+		c.state.Checkpointed = true
+		// The container must not be restarted by its restart policy
+		c.state.StoppedByUser = true
+		if err := c.save(); err != nil {
+			return err
+		}
+		if err := c.cleanup(); err != nil {
+			return err
+		}
+	}
+
+	if options.TargetFile != "" {
+		return c.exportCheckpoint(options.TargetFile)
+	}
+
+	return nil
+}
+
+// Restore a container from its checkpoint
+// Must be called with the container locked
+func (c *Container) restore(ctx context.Context, options ContainerRestoreOptions) (err error) {
+	if c.state.State != ContainerStateConfigured && c.state.State != ContainerStateStopped {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s must be in Configured or Stopped state to be restored", c.ID())
+	}
+	if c.config.PostConfigureNetNS {
+		return errors.Wrapf(ErrNotImplemented, "containers whose network is configured after they are created cannot be restored")
+	}
+
+	// CRIU's inventory is the minimum a complete checkpoint has
+	if _, err := os.Stat(filepath.Join(c.checkpointPath(), "inventory.img")); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrCtrStateInvalid, "no checkpoint of container %s found, cannot restore it", c.ID())
+		}
+		return errors.Wrapf(err, "error looking for the checkpoint of container %s", c.ID())
+	}
+
+	notRunning, err := c.checkDependenciesRunning()
+	if err != nil {
+		return errors.Wrapf(err, "error checking dependencies for container %s", c.ID())
+	}
+	if len(notRunning) > 0 {
+		depString := strings.Join(notRunning, ",")
+		return errors.Wrapf(ErrCtrStateInvalid, "some dependencies of container %s are not started: %s", c.ID(), depString)
+	}
+
+	if err := c.prepare(); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := c.cleanup(); err2 != nil {
+				logrus.Errorf("error cleaning up container %s: %v", c.ID(), err2)
+			}
+		}
+	}()
+
+	// A stopped container must be removed from the runtime before it can
+	// be restored into it
+	if c.state.State == ContainerStateStopped {
+		if err := c.removeConmonFiles(); err != nil {
+			return err
+		}
+		if err := c.runtime.ociRuntime.deleteContainer(c); err != nil {
+			return errors.Wrapf(err, "error removing container %s from runtime", c.ID())
+		}
+		c.state.State = ContainerStateConfigured
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.makeBindMounts(); err != nil {
+		return err
+	}
+
+	// The spec is generated again, as the container has a new network
+	// namespace
+	spec, err := c.generateSpec(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.saveSpec(spec); err != nil {
+		return err
+	}
+
+	if err := c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, &options); err != nil {
+		return errors.Wrapf(err, "error restoring container %s", c.ID())
+	}
+
+	logrus.Debugf("Restored container %s", c.ID())
+
+	if err := c.runtime.ociRuntime.updateContainerStatus(c); err != nil {
+		return err
+	}
+	c.state.StartedTime = time.Now()
+	c.state.Checkpointed = false
+	c.state.StoppedByUser = false
+	c.state.RestartCount = 0
+	c.resetHealthCheck()
+
+	if !options.Keep {
+		c.removeCheckpointLogs()
+		if err := os.RemoveAll(c.checkpointPath()); err != nil {
+			logrus.Errorf("Error removing checkpoint of container %s: %v", c.ID(), err)
+		}
+	}
+
+	return c.save()
+}
+
+// Export the container's checkpoint, configuration and root filesystem
+// changes to a tarball
+func (c *Container) exportCheckpoint(dest string) error {
+	logrus.Debugf("Exporting checkpoint of container %s to %s", c.ID(), dest)
+
+	configJSON, err := json.Marshal(c.config)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding configuration of container %s", c.ID())
+	}
+	configPath := filepath.Join(c.bundlePath(), checkpointConfigFile)
+	if err := ioutil.WriteFile(configPath, configJSON, 0600); err != nil {
+		return errors.Wrapf(err, "error writing configuration of container %s", c.ID())
+	}
+	defer os.Remove(configPath)
+
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving storage of container %s", c.ID())
+	}
+	uncompressed := archive.Uncompressed
+	diff, err := c.runtime.store.Diff("", storageCtr.LayerID, &storage.DiffOptions{Compression: &uncompressed})
+	if err != nil {
+		return errors.Wrapf(err, "error getting root filesystem changes of container %s", c.ID())
+	}
+	defer diff.Close()
+	diffPath := filepath.Join(c.bundlePath(), checkpointRootfsDiffFile)
+	defer os.Remove(diffPath)
+	if err := writeFile(diffPath, diff); err != nil {
+		return errors.Wrapf(err, "error writing root filesystem changes of container %s", c.ID())
+	}
+
+	tarball, err := archive.TarWithOptions(c.bundlePath(), &archive.TarOptions{
+		Compression:  archive.Gzip,
+		IncludeFiles: []string{checkpointDir, artifactsDir, checkpointConfigFile, checkpointRootfsDiffFile},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error archiving checkpoint of container %s", c.ID())
+	}
+	defer tarball.Close()
+
+	if err := writeFile(dest, tarball); err != nil {
+		return errors.Wrapf(err, "error exporting checkpoint of container %s to %s", c.ID(), dest)
+	}
+	return nil
+}
+
+// Write everything read from the given reader to a new file
+func writeFile(path string, reader io.Reader) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ImportCheckpoint creates a container from a checkpoint exported by
+// Checkpoint, on this or another host
+// The container keeps the name and configuration of the checkpointed
+// container, but gets a new ID. The image of the checkpointed container must
+// be present. The container can then be restored with Restore
+func (r *Runtime) ImportCheckpoint(ctx context.Context, input string) (c *Container, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	config, err := r.readCheckpointConfig(input)
+	if err != nil {
+		return nil, err
+	}
+
+	ctr, err := r.newContainer(ctx, config.Spec, withCheckpointConfig(config))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating container from checkpoint %s", input)
+	}
+	defer func() {
+		if err != nil {
+			if err2 := r.removeContainer(ctr, true); err2 != nil {
+				logrus.Errorf("Error removing partially imported container %s: %v", ctr.ID(), err2)
+			}
+		}
+	}()
+
+	if err := ctr.importCheckpoint(input); err != nil {
+		return nil, err
+	}
+
+	return ctr, nil
+}
+
+// Read the configuration of the checkpointed container from an exported
+// checkpoint
+func (r *Runtime) readCheckpointConfig(input string) (*ContainerConfig, error) {
+	tmpDir, err := ioutil.TempDir(r.config.TmpDir, "checkpoint")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tarball, err := os.Open(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening checkpoint %s", input)
+	}
+	defer tarball.Close()
+
+	if err := archive.Untar(tarball, tmpDir, &archive.TarOptions{IncludeFiles: []string{checkpointConfigFile}}); err != nil {
+		return nil, errors.Wrapf(err, "error extracting checkpoint %s", input)
+	}
+
+	configJSON, err := ioutil.ReadFile(filepath.Join(tmpDir, checkpointConfigFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading container configuration from checkpoint %s", input)
+	}
+	config := new(ContainerConfig)
+	if err := json.Unmarshal(configJSON, config); err != nil {
+		return nil, errors.Wrapf(err, "error decoding container configuration from checkpoint %s", input)
+	}
+	if config.Spec == nil {
+		return nil, errors.Wrapf(ErrInvalidArg, "checkpoint %s has no runtime spec", input)
+	}
+
+	return config, nil
+}
+
+// Extract the checkpoint and root filesystem changes of an exported
+// checkpoint into a newly created container
+func (c *Container) importCheckpoint(input string) error {
+	tarball, err := os.Open(input)
+	if err != nil {
+		return errors.Wrapf(err, "error opening checkpoint %s", input)
+	}
+	defer tarball.Close()
+
+	if err := archive.Untar(tarball, c.bundlePath(), &archive.TarOptions{ExcludePatterns: []string{checkpointConfigFile}}); err != nil {
+		return errors.Wrapf(err, "error extracting checkpoint %s", input)
+	}
+
+	diffPath := filepath.Join(c.bundlePath(), checkpointRootfsDiffFile)
+	defer os.Remove(diffPath)
+	diff, err := os.Open(diffPath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading root filesystem changes from checkpoint %s", input)
+	}
+	defer diff.Close()
+
+	storageCtr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving storage of container %s", c.ID())
+	}
+	if _, err := c.runtime.store.ApplyDiff(storageCtr.LayerID, diff); err != nil {
+		return errors.Wrapf(err, "error applying root filesystem changes to container %s", c.ID())
+	}
+
+	return nil
+}
+
+// withCheckpointConfig gives a container being imported from a checkpoint the
+// configuration of the checkpointed container
+// The ID, creation time and paths of the checkpointed container belong to the
+// host it was checkpointed on, and are not kept
+func withCheckpointConfig(config *ContainerConfig) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if config.Pod != "" {
+			return errors.Wrapf(ErrInvalidArg, "containers in pods cannot be imported from a checkpoint")
+		}
+		checkpointed := &Container{config: config}
+		if len(checkpointed.Dependencies()) > 0 {
+			return errors.Wrapf(ErrInvalidArg, "containers that depend on other containers cannot be imported from a checkpoint")
+		}
+
+		newConfig := *config
+		newConfig.ID = ctr.config.ID
+		newConfig.Spec = ctr.config.Spec
+		newConfig.CreatedTime = ctr.config.CreatedTime
+		newConfig.StaticDir = ""
+		newConfig.LogPath = ""
+		newConfig.ShmDir = ""
+		newConfig.Mounts = nil
+		newConfig.ConmonPidFile = ""
+		// Default cgroup parents are chosen again for this host
+		if newConfig.CgroupParent == CgroupfsDefaultCgroupParent || newConfig.CgroupParent == SystemdDefaultCgroupParent {
+			newConfig.CgroupParent = ""
+		}
+		ctr.config = &newConfig
+
+		return nil
+	}
+}
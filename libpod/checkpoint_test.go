@@ -0,0 +1,81 @@
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeImportedContainer() *Container {
+	return &Container{
+		config: &ContainerConfig{
+			ID:          "newid",
+			Spec:        &spec.Spec{Version: "new"},
+			CreatedTime: time.Now(),
+		},
+	}
+}
+
+func TestWithCheckpointConfig(t *testing.T) {
+	config := &ContainerConfig{
+		ID:            "oldid",
+		Name:          "checkpointed",
+		Spec:          &spec.Spec{Version: "old"},
+		StaticDir:     "/var/lib/containers/storage/overlay-containers/oldid/userdata",
+		LogPath:       "/var/lib/containers/storage/overlay-containers/oldid/userdata/ctr.log",
+		ShmDir:        "/var/lib/containers/storage/overlay-containers/oldid/userdata/shm",
+		Mounts:        []string{"/var/lib/containers/storage/overlay-containers/oldid/userdata/shm"},
+		ConmonPidFile: "/run/oldid.pid",
+		CgroupParent:  CgroupfsDefaultCgroupParent,
+		StopSignal:    15,
+	}
+
+	ctr := makeImportedContainer()
+	assert.NoError(t, withCheckpointConfig(config)(ctr))
+
+	assert.Equal(t, "newid", ctr.config.ID)
+	assert.Equal(t, "new", ctr.config.Spec.Version)
+	assert.Equal(t, "checkpointed", ctr.config.Name)
+	assert.Equal(t, uint(15), ctr.config.StopSignal)
+	assert.Empty(t, ctr.config.StaticDir)
+	assert.Empty(t, ctr.config.LogPath)
+	assert.Empty(t, ctr.config.ShmDir)
+	assert.Empty(t, ctr.config.Mounts)
+	assert.Empty(t, ctr.config.ConmonPidFile)
+	assert.Empty(t, ctr.config.CgroupParent)
+
+	// The configuration of the checkpointed container is not modified
+	assert.Equal(t, "oldid", config.ID)
+	assert.NotEmpty(t, config.StaticDir)
+}
+
+func TestWithCheckpointConfigKeepsCustomCgroupParent(t *testing.T) {
+	config := &ContainerConfig{
+		Spec:         &spec.Spec{},
+		CgroupParent: "/custom",
+	}
+
+	ctr := makeImportedContainer()
+	assert.NoError(t, withCheckpointConfig(config)(ctr))
+	assert.Equal(t, "/custom", ctr.config.CgroupParent)
+}
+
+func TestWithCheckpointConfigRejectsPod(t *testing.T) {
+	config := &ContainerConfig{
+		Spec: &spec.Spec{},
+		Pod:  "mypod",
+	}
+
+	assert.Error(t, withCheckpointConfig(config)(makeImportedContainer()))
+}
+
+func TestWithCheckpointConfigRejectsDependencies(t *testing.T) {
+	config := &ContainerConfig{
+		Spec:     &spec.Spec{},
+		NetNsCtr: "otherctr",
+	}
+
+	assert.Error(t, withCheckpointConfig(config)(makeImportedContainer()))
+}
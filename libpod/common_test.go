@@ -115,6 +115,30 @@ func getTestPod(id, name, locksDir string) (*Pod, error) {
 	return pod, nil
 }
 
+func getTestVolume(name, locksDir string) (*Volume, error) {
+	volume := &Volume{
+		config: &VolumeConfig{
+			Name:       name,
+			Labels:     map[string]string{"a": "b", "c": "d"},
+			MountPoint: filepath.Join("/path/to/volumes", name, "_data"),
+			Driver:     LocalVolumeDriver,
+			Options:    map[string]string{"type": "tmpfs", "device": "tmpfs"},
+		},
+		state: &volumeState{
+			NeedsCopyUp: true,
+		},
+		valid: true,
+	}
+
+	lock, err := storage.GetLockfile(volumeLockPath(locksDir, name))
+	if err != nil {
+		return nil, err
+	}
+	volume.lock = lock
+
+	return volume, nil
+}
+
 func getTestCtrN(n, lockPath string) (*Container, error) {
 	return getTestContainer(strings.Repeat(n, 32), "test"+n, lockPath)
 }
@@ -185,6 +209,26 @@ func testContainersEqual(t *testing.T, a, b *Container) {
 	assert.EqualValues(t, aState, bState)
 }
 
+// Test if volumes are equal
+func testVolumesEqual(t *testing.T, a, b *Volume) {
+	if a == nil && b == nil {
+		return
+	}
+
+	assert.NotNil(t, a)
+	assert.NotNil(t, b)
+
+	assert.NotNil(t, a.config)
+	assert.NotNil(t, b.config)
+	assert.NotNil(t, a.state)
+	assert.NotNil(t, b.state)
+
+	assert.Equal(t, a.valid, b.valid)
+
+	assert.EqualValues(t, a.config, b.config)
+	assert.EqualValues(t, a.state, b.state)
+}
+
 // Test if pods are equal
 func testPodsEqual(t *testing.T, a, b *Pod) {
 	if a == nil && b == nil {
@@ -13,6 +13,8 @@ import (
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/pkg/inspect"
+	"github.com/sirupsen/logrus"
 	"github.com/ulule/deepcopier"
 )
 
@@ -145,8 +147,8 @@ type containerState struct {
 	// Will only be set if config.CreateNetNS is true, or the container was
 	// told to join another container's network namespace
 	NetNS ns.NetNS `json:"-"`
-	// ExecSessions contains active exec sessions for container
-	// Exec session ID is mapped to PID of exec process
+	// ExecSessions contains the exec sessions of the container
+	// Exec session ID is mapped to the exec session
 	ExecSessions map[string]*ExecSession `json:"execSessions,omitempty"`
 	// IPs contains IP addresses assigned to the container
 	// Only populated if we created a network namespace for the container,
@@ -156,6 +158,16 @@ type containerState struct {
 	// Only populated if we created a network namespace for the container,
 	// and the network namespace is currently active
 	Routes []*types.Route `json:"routes,omitempty"`
+	// Interfaces contains the network interfaces created for the container
+	// Only populated if we created a network namespace for the container,
+	// and the network namespace is currently active
+	Interfaces []*cnitypes.Interface `json:"interfaces,omitempty"`
+	// NetworkStatus contains the result of attaching the container to each
+	// of its CNI networks, keyed by network name
+	// IPs, Routes and Interfaces hold the results of all networks
+	// Only populated if we created a network namespace for the container,
+	// and the network namespace is currently active
+	NetworkStatus map[string]*cnitypes.Result `json:"networkStatus,omitempty"`
 	// BindMounts contains files that will be bind-mounted into the
 	// container when it is mounted.
 	// These include /etc/hosts and /etc/resolv.conf
@@ -166,13 +178,50 @@ type containerState struct {
 	// UserNSRoot is the directory used as root for the container when using
 	// user namespaces.
 	UserNSRoot string `json:"userNSRoot,omitempty"`
+
+	// RestartCount is the number of times the container has been restarted
+	// by its restart policy since it was last started by the user
+	RestartCount uint `json:"restartCount,omitempty"`
+	// StoppedByUser indicates that the container was last stopped or
+	// killed by the user, and must not be restarted by its restart policy
+	StoppedByUser bool `json:"stoppedByUser,omitempty"`
+	// Checkpointed indicates that the container was stopped by writing a
+	// checkpoint of it, and can be restored from the checkpoint
+	Checkpointed bool `json:"checkpointed,omitempty"`
+
+	// HealthCheck contains the results of the container's most recent
+	// healthchecks
+	// Only populated if the container has a healthcheck
+	HealthCheck *inspect.HealthCheckResults `json:"healthCheck,omitempty"`
 }
 
-// ExecSession contains information on an active exec session
+// ExecSession contains information on an exec session
 type ExecSession struct {
 	ID      string   `json:"id"`
 	Command []string `json:"command"`
 	PID     int      `json:"pid"`
+	// Config is the configuration the session was created with
+	Config *ExecConfig `json:"config,omitempty"`
+	// State is the state of the session, one of ExecStateCreated,
+	// ExecStateRunning or ExecStateExited
+	State string `json:"state,omitempty"`
+	// Detached indicates that the session was started without attaching
+	// to it
+	Detached bool `json:"detached,omitempty"`
+	// ExitCode is the exit code of the session's process
+	// Only valid once the session has exited
+	ExitCode int `json:"exitCode"`
+	// StartedTime is the time the session was started
+	StartedTime time.Time `json:"startedTime,omitempty"`
+	// FinishedTime is the time the session was found to have exited
+	FinishedTime time.Time `json:"finishedTime,omitempty"`
+	// MonitorPID is the PID of the process that runs the session and
+	// records its exit code
+	MonitorPID int `json:"monitorPid,omitempty"`
+	// MonitorStartTime is the start time of the monitor, in clock ticks
+	// since boot, which tells it apart from a later process reusing its
+	// PID
+	MonitorStartTime uint64 `json:"monitorStartTime,omitempty"`
 }
 
 // ContainerConfig contains all information that was used to create the
@@ -184,6 +233,9 @@ type ContainerConfig struct {
 	Name string     `json:"name"`
 	// Full ID of the pood the container belongs to
 	Pod string `json:"pod,omitempty"`
+	// IsInfra indicates whether the container is the infra container of
+	// its pod
+	IsInfra bool `json:"pause"`
 
 	// TODO consider breaking these subsections up into smaller structs
 
@@ -206,6 +258,11 @@ type ContainerConfig struct {
 	// These include the SHM mount.
 	// These must be unmounted before the container's rootfs is unmounted.
 	Mounts []string `json:"mounts,omitempty"`
+	// NamedVolumes are the named volumes mounted into the container
+	// The volumes must exist in the state when the container is added, and
+	// cannot be removed while the container exists. Bind mounts of their
+	// mount points are added to the container's spec.
+	NamedVolumes []*ContainerNamedVolume `json:"namedVolumes,omitempty"`
 
 	// Security Config
 
@@ -245,9 +302,29 @@ type ContainerConfig struct {
 	// This cannot be set if NetNsCtr is also set
 	CreateNetNS bool `json:"createNetNS"`
 	// PortMappings are the ports forwarded to the container's network
-	// namespace
+	// namespace, through its first network
 	// These are not used unless CreateNetNS is true
 	PortMappings []ocicni.PortMapping `json:"portMappings,omitempty"`
+	// Networks are the names of the CNI networks the container joins, in
+	// the order its interfaces are created
+	// If none are given, the runtime's default network is recorded here
+	// when the container is created. If empty, as for containers created
+	// before it was recorded, the container joins the runtime's default
+	// network
+	// These are not used unless CreateNetNS is true
+	Networks []string `json:"networks,omitempty"`
+	// StaticIP is the IP requested for the container in its first network,
+	// instead of one assigned by the network's IPAM plugin
+	// These are not used unless CreateNetNS is true
+	StaticIP net.IP `json:"staticIP,omitempty"`
+	// StaticMAC is the MAC address requested for the container's interface
+	// in its first network
+	// These are not used unless CreateNetNS is true
+	StaticMAC net.HardwareAddr `json:"staticMAC,omitempty"`
+	// NetworkAliases are names the container can be reached by in its
+	// network, besides its name
+	// These are not used unless CreateNetNS is true
+	NetworkAliases []string `json:"networkAliases,omitempty"`
 	// DNS servers to use in container resolv.conf
 	// Will override servers in host resolv if set
 	DNSServer []net.IP `json:"dnsServer,omitempty"`
@@ -296,11 +373,31 @@ type ContainerConfig struct {
 	CgroupParent string `json:"cgroupParent"`
 	// LogPath log location
 	LogPath string `json:"logPath"`
+	// LogDriver is the driver handling the container's output
+	LogDriver string `json:"logDriver,omitempty"`
+	// LogMaxSize is the size in bytes the container's log file may reach
+	// before it is rotated, or truncated if LogMaxFiles is at most 1
+	// No limit is set if it is 0
+	LogMaxSize int64 `json:"logMaxSize,omitempty"`
+	// LogMaxFiles is the number of log files kept for the container,
+	// including the current one, when its log file is rotated
+	LogMaxFiles uint `json:"logMaxFiles,omitempty"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
 	// TODO log options for log drivers
 
 	PostConfigureNetNS bool `json:"postConfigureNetNS"`
+
+	// RestartPolicy indicates what to do when the container exits
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	// RestartRetries is the maximum number of times the container will be
+	// restarted by the on-failure restart policy
+	// If 0, the container is restarted until it succeeds
+	RestartRetries uint `json:"restartRetries,omitempty"`
+
+	// HealthCheckConfig describes how to check that the container is
+	// healthy
+	HealthCheckConfig *HealthCheckConfig `json:"healthCheck,omitempty"`
 }
 
 // ContainerStatus returns a string representation for users
@@ -360,6 +457,11 @@ func (c *Container) PodID() string {
 	return c.config.Pod
 }
 
+// IsInfra returns whether the container is the infra container of its pod
+func (c *Container) IsInfra() bool {
+	return c.config.IsInfra
+}
+
 // Image returns the ID and name of the image used as the container's rootfs
 func (c *Container) Image() (string, string) {
 	return c.config.RootfsImageID, c.config.RootfsImageName
@@ -386,6 +488,20 @@ func (c *Container) StaticDir() string {
 	return c.config.StaticDir
 }
 
+// NamedVolumes returns the named volumes mounted into the container
+func (c *Container) NamedVolumes() []*ContainerNamedVolume {
+	volumes := make([]*ContainerNamedVolume, 0, len(c.config.NamedVolumes))
+	for _, vol := range c.config.NamedVolumes {
+		newVol := new(ContainerNamedVolume)
+		newVol.Name = vol.Name
+		newVol.Dest = vol.Dest
+		newVol.Options = append([]string{}, vol.Options...)
+		volumes = append(volumes, newVol)
+	}
+
+	return volumes
+}
+
 // Privileged returns whether the container is privileged
 func (c *Container) Privileged() bool {
 	return c.config.Privileged
@@ -422,7 +538,7 @@ func (c *Container) Dependencies() []string {
 		dependsCtrs[c.config.NetNsCtr] = true
 	}
 	if c.config.PIDNsCtr != "" {
-		dependsCtrs[c.config.NetNsCtr] = true
+		dependsCtrs[c.config.PIDNsCtr] = true
 	}
 	if c.config.UserNsCtr != "" {
 		dependsCtrs[c.config.UserNsCtr] = true
@@ -463,6 +579,39 @@ func (c *Container) PortMappings() []ocicni.PortMapping {
 	return c.config.PortMappings
 }
 
+// Networks returns the names of the CNI networks the container joins
+// If empty, the container joins the default network
+// If NewNetNS() is false, this value is unused
+func (c *Container) Networks() []string {
+	networks := make([]string, len(c.config.Networks))
+	copy(networks, c.config.Networks)
+	return networks
+}
+
+// StaticIP returns the IP requested for the container in its first network
+// If nil, the network assigns the container an IP
+// If NewNetNS() is false, this value is unused
+func (c *Container) StaticIP() net.IP {
+	return c.config.StaticIP
+}
+
+// StaticMAC returns the MAC address requested for the container's interface in
+// its first network
+// If nil, the network chooses the MAC address
+// If NewNetNS() is false, this value is unused
+func (c *Container) StaticMAC() net.HardwareAddr {
+	return c.config.StaticMAC
+}
+
+// NetworkAliases returns the names the container can be reached by in its
+// network, besides its name
+// If NewNetNS() is false, this value is unused
+func (c *Container) NetworkAliases() []string {
+	aliases := make([]string, len(c.config.NetworkAliases))
+	copy(aliases, c.config.NetworkAliases)
+	return aliases
+}
+
 // DNSServers returns DNS servers that will be used in the container's
 // resolv.conf
 // If empty, DNS server from the host's resolv.conf will be used instead
@@ -569,6 +718,43 @@ func (c *Container) LogPath() string {
 	return c.config.LogPath
 }
 
+// LogDriver returns the driver handling the container's output
+func (c *Container) LogDriver() string {
+	if c.config.LogDriver == "" {
+		return KubernetesLogging
+	}
+	return c.config.LogDriver
+}
+
+// LogRotation returns the size the container's log file may reach before it
+// is rotated, and the number of log files kept for the container
+// A size of 0 means the log file is not limited
+func (c *Container) LogRotation() (int64, uint) {
+	return c.config.LogMaxSize, c.config.LogMaxFiles
+}
+
+// RestartPolicy returns the container's restart policy
+func (c *Container) RestartPolicy() string {
+	return c.config.RestartPolicy
+}
+
+// RestartRetries returns the maximum number of times the container will be
+// restarted by the on-failure restart policy
+// If 0, the container will be restarted until it exits successfully
+func (c *Container) RestartRetries() uint {
+	return c.config.RestartRetries
+}
+
+// HealthCheckConfig returns the container's healthcheck, or nil if it has none
+func (c *Container) HealthCheckConfig() *HealthCheckConfig {
+	if c.config.HealthCheckConfig == nil {
+		return nil
+	}
+	returnConfig := *c.config.HealthCheckConfig
+	returnConfig.Test = append([]string{}, c.config.HealthCheckConfig.Test...)
+	return &returnConfig
+}
+
 // RuntimeName returns the name of the runtime
 func (c *Container) RuntimeName() string {
 	return c.runtime.ociRuntime.name
@@ -583,6 +769,16 @@ func (c *Container) Hostname() string {
 		return c.config.Spec.Hostname
 	}
 
+	// Containers joining another container's UTS namespace share its
+	// hostname
+	if c.config.UTSNsCtr != "" && c.runtime != nil {
+		utsCtr, err := c.runtime.state.Container(c.config.UTSNsCtr)
+		if err == nil {
+			return utsCtr.Hostname()
+		}
+		logrus.Debugf("Unable to retrieve UTS namespace container %s of container %s: %v", c.config.UTSNsCtr, c.ID(), err)
+	}
+
 	if len(c.ID()) < 11 {
 		return c.ID()
 	}
@@ -681,6 +877,52 @@ func (c *Container) OOMKilled() (bool, error) {
 	return c.state.OOMKilled, nil
 }
 
+// RestartCount returns the number of times the container has been restarted
+// by its restart policy since it was last started by the user
+func (c *Container) RestartCount() (uint, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.RestartCount, nil
+}
+
+// Checkpointed returns whether the container was stopped by checkpointing it,
+// and has not been restored or started since
+func (c *Container) Checkpointed() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return false, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.Checkpointed, nil
+}
+
+// HealthCheckStatus returns the health of the container, as one of
+// HealthCheckHealthy, HealthCheckUnhealthy and HealthCheckStarting
+// If the container has no healthcheck, "" will be returned
+func (c *Container) HealthCheckStatus() (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	if c.config.HealthCheckConfig == nil {
+		return "", nil
+	}
+	if c.state.HealthCheck == nil {
+		return HealthCheckStarting, nil
+	}
+	return c.state.HealthCheck.Status, nil
+}
+
 // PID returns the PID of the container
 // If the container is not running, a pid of 0 will be returned. No error will
 // occur.
@@ -697,7 +939,7 @@ func (c *Container) PID() (int, error) {
 	return c.state.PID, nil
 }
 
-// ExecSessions retrieves active exec sessions running in the container
+// ExecSessions retrieves the IDs of the exec sessions of the container
 func (c *Container) ExecSessions() ([]string, error) {
 	if !c.batched {
 		c.lock.Lock()
@@ -716,8 +958,8 @@ func (c *Container) ExecSessions() ([]string, error) {
 	return ids, nil
 }
 
-// ExecSession retrieves detailed information on a single active exec session in
-// a container
+// ExecSession retrieves detailed information on a single exec session in a
+// container
 func (c *Container) ExecSession(id string) (*ExecSession, error) {
 	if !c.batched {
 		c.lock.Lock()
@@ -730,13 +972,15 @@ func (c *Container) ExecSession(id string) (*ExecSession, error) {
 
 	session, ok := c.state.ExecSessions[id]
 	if !ok {
-		return nil, errors.Wrapf(ErrNoSuchCtr, "no exec session with ID %s found in container %s", id, c.ID())
+		return nil, errors.Wrapf(ErrNoSuchExecSession, "no exec session with ID %s found in container %s", id, c.ID())
 	}
 
 	returnSession := new(ExecSession)
-	returnSession.ID = session.ID
-	returnSession.Command = session.Command
-	returnSession.PID = session.PID
+	*returnSession = *session
+	returnSession.Command = append([]string{}, session.Command...)
+	if session.Config != nil {
+		returnSession.Config = session.Config.copy()
+	}
 
 	return returnSession, nil
 }
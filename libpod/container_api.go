@@ -4,14 +4,11 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
-	"strconv"
 	"strings"
-	"time"
 
-	"github.com/docker/docker/daemon/caps"
-	"github.com/docker/docker/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/projectatomic/libpod/libpod/driver"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/projectatomic/libpod/pkg/inspect"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -21,6 +18,10 @@ import (
 // Init creates a container in the OCI runtime
 func (c *Container) Init(ctx context.Context) (err error) {
 	if !c.batched {
+		if err := c.startPodInfraContainer(ctx); err != nil {
+			return err
+		}
+
 		c.lock.Lock()
 		defer c.lock.Unlock()
 
@@ -64,6 +65,10 @@ func (c *Container) Init(ctx context.Context) (err error) {
 // Init()
 func (c *Container) Start(ctx context.Context) (err error) {
 	if !c.batched {
+		if err := c.startPodInfraContainer(ctx); err != nil {
+			return err
+		}
+
 		c.lock.Lock()
 		defer c.lock.Unlock()
 
@@ -111,6 +116,11 @@ func (c *Container) Start(ctx context.Context) (err error) {
 		}
 	}
 
+	// The user started the container, so its restart policy applies
+	// afresh
+	c.state.StoppedByUser = false
+	c.state.RestartCount = 0
+
 	// Start the container
 	return c.start()
 }
@@ -127,6 +137,10 @@ func (c *Container) Start(ctx context.Context) (err error) {
 // sent
 func (c *Container) StartAndAttach(ctx context.Context, streams *AttachStreams, keys string, resize <-chan remotecommand.TerminalSize) (attachResChan <-chan error, err error) {
 	if !c.batched {
+		if err := c.startPodInfraContainer(ctx); err != nil {
+			return nil, err
+		}
+
 		c.lock.Lock()
 		defer c.lock.Unlock()
 
@@ -174,6 +188,11 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *AttachStreams,
 		}
 	}
 
+	// The user started the container, so its restart policy applies
+	// afresh
+	c.state.StoppedByUser = false
+	c.state.RestartCount = 0
+
 	attachChan := make(chan error)
 
 	// Attach to the container before starting it
@@ -218,7 +237,14 @@ func (c *Container) Stop() error {
 		return ErrCtrStopped
 	}
 
-	return c.stop(c.config.StopTimeout)
+	if err := c.stop(c.config.StopTimeout); err != nil {
+		return err
+	}
+
+	// Do not let the restart policy bring the container back up
+	c.state.StoppedByUser = true
+
+	return c.save()
 }
 
 // StopWithTimeout is a version of Stop that allows a timeout to be specified
@@ -244,7 +270,14 @@ func (c *Container) StopWithTimeout(timeout uint) error {
 		return ErrCtrStopped
 	}
 
-	return c.stop(timeout)
+	if err := c.stop(timeout); err != nil {
+		return err
+	}
+
+	// Do not let the restart policy bring the container back up
+	c.state.StoppedByUser = true
+
+	return c.save()
 }
 
 // Kill sends a signal to a container
@@ -262,147 +295,20 @@ func (c *Container) Kill(signal uint) error {
 		return errors.Wrapf(ErrCtrStateInvalid, "can only kill running containers")
 	}
 
-	return c.runtime.ociRuntime.killContainer(c, signal)
-}
-
-// Exec starts a new process inside the container
-// TODO allow specifying streams to attach to
-// TODO investigate allowing exec without attaching
-func (c *Container) Exec(tty, privileged bool, env, cmd []string, user string) error {
-	var capList []string
-
-	locked := false
-	if !c.batched {
-		locked = true
-
-		c.lock.Lock()
-		defer func() {
-			if locked {
-				c.lock.Unlock()
-			}
-		}()
-
-		if err := c.syncContainer(); err != nil {
-			return err
-		}
-	}
-
-	conState := c.state.State
-
-	// TODO can probably relax this once we track exec sessions
-	if conState != ContainerStateRunning {
-		return errors.Errorf("cannot exec into container that is not running")
-	}
-	if privileged || c.config.Privileged {
-		capList = caps.GetAllCapabilities()
-	}
-
-	// Generate exec session ID
-	// Ensure we don't conflict with an existing session ID
-	sessionID := stringid.GenerateNonCryptoID()
-	found := true
-	// This really ought to be a do-while, but Go doesn't have those...
-	for found {
-		found = false
-		for id := range c.state.ExecSessions {
-			if id == sessionID {
-				found = true
-				break
-			}
-		}
-		if found == true {
-			sessionID = stringid.GenerateNonCryptoID()
-		}
-	}
-
-	logrus.Debugf("Creating new exec session in container %s with session id %s", c.ID(), sessionID)
-
-	execCmd, err := c.runtime.ociRuntime.execContainer(c, cmd, capList, env, tty, user, sessionID)
-	if err != nil {
-		return errors.Wrapf(err, "error creating exec command for container %s", c.ID())
-	}
-
-	if err := execCmd.Start(); err != nil {
-		return errors.Wrapf(err, "error starting exec command for container %s", c.ID())
-	}
-
-	pidFile := c.execPidPath(sessionID)
-	const pidWaitTimeout = 250
-
-	// Wait until the runtime makes the pidfile
-	// TODO: If runtime errors before the PID file is created, we have to
-	// wait for timeout here
-	if err := WaitForFile(pidFile, pidWaitTimeout*time.Millisecond); err != nil {
-		logrus.Debugf("Timed out waiting for pidfile from runtime for container %s exec", c.ID())
-
-		// Check if an error occurred in the process before we made a pidfile
-		// TODO: Wait() here is a poor choice - is there a way to see if
-		// a process has finished, instead of waiting for it to finish?
-		if err := execCmd.Wait(); err != nil {
-			return err
-		}
-
-		return errors.Wrapf(err, "timed out waiting for runtime to create pidfile for exec session in container %s", c.ID())
-	}
-
-	// Pidfile exists, read it
-	contents, err := ioutil.ReadFile(pidFile)
-	if err != nil {
-		// We don't know the PID of the exec session
-		// However, it may still be alive
-		// TODO handle this better
-		return errors.Wrapf(err, "could not read pidfile for exec session %s in container %s", sessionID, c.ID())
-	}
-	pid, err := strconv.ParseInt(string(contents), 10, 32)
-	if err != nil {
-		// As above, we don't have a valid PID, but the exec session is likely still alive
-		// TODO handle this better
-		return errors.Wrapf(err, "error parsing PID of exec session %s in container %s", sessionID, c.ID())
-	}
-
-	// We have the PID, add it to state
-	if c.state.ExecSessions == nil {
-		c.state.ExecSessions = make(map[string]*ExecSession)
-	}
-	session := new(ExecSession)
-	session.ID = sessionID
-	session.Command = cmd
-	session.PID = int(pid)
-	c.state.ExecSessions[sessionID] = session
-	if err := c.save(); err != nil {
-		// Now we have a PID but we can't save it in the DB
-		// TODO handle this better
-		return errors.Wrapf(err, "error saving exec sessions %s for container %s", sessionID, c.ID())
-	}
-
-	logrus.Debugf("Successfully started exec session %s in container %s", sessionID, c.ID())
-
-	// Unlock so other processes can use the container
-	if !c.batched {
-		c.lock.Unlock()
-		locked = false
-	}
-
-	waitErr := execCmd.Wait()
-
-	// Lock again
-	if !c.batched {
-		locked = true
-		c.lock.Lock()
+	if err := c.runtime.ociRuntime.killContainer(c, signal); err != nil {
+		return err
 	}
 
-	// Sync the container again to pick up changes in state
-	if err := c.syncContainer(); err != nil {
-		return errors.Wrapf(err, "error syncing container %s state to remove exec session %s", c.ID(), sessionID)
-	}
+	c.newKillEvent(signal)
 
-	// Remove the exec session from state
-	delete(c.state.ExecSessions, sessionID)
-	if err := c.save(); err != nil {
-		logrus.Errorf("Error removing exec session %s from container %s state: %v", sessionID, c.ID(), err)
+	// Do not let the restart policy bring the container back up if the
+	// signal is meant to stop it
+	if !c.signalStopsContainer(signal) {
+		return nil
 	}
+	c.state.StoppedByUser = true
 
-	return waitErr
+	return c.save()
 }
 
 // Attach attaches to a container
@@ -459,7 +365,7 @@ func (c *Container) Unmount() error {
 	}
 
 	// Check if we have active exec sessions
-	if len(c.state.ExecSessions) != 0 {
+	if c.hasRunningExecSessions() {
 		return errors.Wrapf(ErrCtrStateInvalid, "container %s has active exec sessions, refusing to clean up", c.ID())
 	}
 
@@ -490,6 +396,7 @@ func (c *Container) Pause() error {
 	logrus.Debugf("Paused container %s", c.ID())
 
 	c.state.State = ContainerStatePaused
+	c.newContainerEvent(events.Pause)
 
 	return c.save()
 }
@@ -515,6 +422,7 @@ func (c *Container) Unpause() error {
 	logrus.Debugf("Unpaused container %s", c.ID())
 
 	c.state.State = ContainerStateRunning
+	c.newContainerEvent(events.Unpause)
 
 	return c.save()
 }
@@ -630,7 +538,7 @@ func (c *Container) Cleanup() error {
 	}
 
 	// Check if we have active exec sessions
-	if len(c.state.ExecSessions) != 0 {
+	if c.hasRunningExecSessions() {
 		return errors.Wrapf(ErrCtrStateInvalid, "container %s has active exec sessions, refusing to clean up", c.ID())
 	}
 
@@ -703,6 +611,13 @@ func (c *Container) Sync() error {
 		}
 	}
 
+	// Record the exit codes of exec sessions and remove stale ones
+	if c.syncExecSessions() {
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -757,5 +672,44 @@ func (c *Container) RestartWithTimeout(ctx context.Context, timeout uint) error
 		}
 	}
 
+	c.state.StoppedByUser = false
+	c.state.RestartCount = 0
+
 	return c.start()
 }
+
+// Checkpoint writes a checkpoint of the running container's processes to
+// disk, from which the container can be restored with Restore
+// Unless options.KeepRunning is set, the container is stopped once the
+// checkpoint has been written
+func (c *Container) Checkpoint(ctx context.Context, options ContainerCheckpointOptions) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.checkpoint(ctx, options)
+}
+
+// Restore restores the processes of a configured or stopped container from
+// the checkpoint written by Checkpoint, leaving the container running
+func (c *Container) Restore(ctx context.Context, options ContainerRestoreOptions) error {
+	if !c.batched {
+		if err := c.startPodInfraContainer(ctx); err != nil {
+			return err
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.restore(ctx, options)
+}
@@ -0,0 +1,412 @@
+package libpod
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/chrootarchive"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// maxSymlinkFollows is the number of symlinks that may be followed while
+// resolving a single path in a container
+const maxSymlinkFollows = 255
+
+// copyMount is a file or directory from the host that is bind mounted into a
+// container, and which paths may be copied to and from
+type copyMount struct {
+	source      string
+	destination string
+	readOnly    bool
+}
+
+// CopyFrom writes a tar archive of a path in the container to the given writer
+// Symlinks in the path are followed as the container would follow them, and
+// never lead out of its root filesystem or the volume they are in. A symlink
+// at the end of the path is archived as a symlink. The archive holds the file
+// or directory under its own name, or only the contents of the directory if
+// the path ends in "/.". Files are owned in the archive by the UIDs and GIDs
+// they have in the container.
+// The container may be running or stopped. Its storage is mounted for the copy
+// if it is not mounted already.
+func (c *Container) CopyFrom(path string, w io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.withCopyRootfs(func(mountPoint string) error {
+		return c.copyFrom(mountPoint, path, w)
+	})
+}
+
+// CopyTo extracts a tar archive into a directory in the container
+// The directory must already exist. Symlinks in its path are followed as in
+// CopyFrom. The UIDs and GIDs in the archive are those the files will have in
+// the container. Existing directories are not replaced by other files, nor
+// other files by directories.
+// The container may be running or stopped. Its storage is mounted for the copy
+// if it is not mounted already.
+func (c *Container) CopyTo(path string, input io.Reader) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.withCopyRootfs(func(mountPoint string) error {
+		return c.copyTo(mountPoint, path, input)
+	})
+}
+
+// StatPath returns information about a path in the container
+// Symlinks in the path, including at its end, are followed as in CopyFrom
+func (c *Container) StatPath(path string) (os.FileInfo, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	var info os.FileInfo
+	err := c.withCopyRootfs(func(mountPoint string) error {
+		mounts := c.copyMounts()
+		resolved, err := resolveContainerPath(mountPoint, mounts, path)
+		if err != nil {
+			return err
+		}
+		if resolved == "/" {
+			info, err = os.Lstat(mountPoint)
+			return err
+		}
+		dir, name, err := openContainerParent(mountPoint, mounts, resolved)
+		if err != nil {
+			return err
+		}
+		defer dir.Close()
+		info, err = os.Lstat(filepath.Join(procFDPath(dir), name))
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting information about %q in container %s", path, c.ID())
+	}
+	return info, nil
+}
+
+// Run the given function with the container's root filesystem mounted
+// Storage that was not mounted before is unmounted again afterwards
+// Must be called with the container locked
+func (c *Container) withCopyRootfs(fn func(mountPoint string) error) error {
+	mountPoint := c.state.Mountpoint
+	if !c.state.Mounted {
+		mount, err := c.runtime.store.Mount(c.ID(), c.config.MountLabel)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting container %q", c.ID())
+		}
+		mountPoint = mount
+		defer func() {
+			if err := c.runtime.store.Unmount(c.ID()); err != nil {
+				logrus.Errorf("error unmounting container %q: %v", c.ID(), err)
+			}
+		}()
+	}
+
+	return fn(mountPoint)
+}
+
+// Archive a path in the container
+// Must be called with the container locked and its storage mounted
+func (c *Container) copyFrom(mountPoint, path string, w io.Writer) error {
+	mounts := c.copyMounts()
+
+	// Resolve everything but the last element of the path, so a symlink
+	// there is archived rather than followed
+	dir, base := archive.SplitPathDirEntry(filepath.Join("/", path))
+	resolvedDir, err := resolveContainerPath(mountPoint, mounts, dir)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving %q in container %s", path, c.ID())
+	}
+	resolved := filepath.Join(resolvedDir, base)
+	if base == "." || resolved == "/" {
+		base = "."
+		resolved = resolvedDir
+	}
+
+	hostPath, _ := containerHostPath(mountPoint, mounts, resolved)
+	if _, err := os.Lstat(hostPath); err != nil {
+		return errors.Wrapf(err, "error copying %q from container %s", path, c.ID())
+	}
+
+	// The directory archived from is opened rather than walked by path, so
+	// it stays the one that was resolved even if a symlink is swapped into
+	// its path
+	// The last element may be a mount whose source has another name, so
+	// the archive is rebased onto the name it has in the container
+	var (
+		sourceDir  *os.File
+		sourceBase = "."
+	)
+	if base != "." {
+		sourceDir, sourceBase, err = openContainerParent(mountPoint, mounts, resolved)
+	} else {
+		sourceDir, err = openContainerDir(mountPoint, mounts, resolved)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error copying %q from container %s", path, c.ID())
+	}
+	defer sourceDir.Close()
+	options := &archive.TarOptions{
+		Compression:      archive.Uncompressed,
+		IncludeFiles:     []string{sourceBase},
+		IncludeSourceDir: true,
+		UIDMaps:          c.config.IDMappings.UIDMap,
+		GIDMaps:          c.config.IDMappings.GIDMap,
+	}
+	if base != "." {
+		options.RebaseNames = map[string]string{
+			sourceBase: base,
+		}
+	}
+
+	logrus.Debugf("Copying %q from container %s (%q)", path, c.ID(), hostPath)
+
+	input, err := archive.TarWithOptions(procFDPath(sourceDir), options)
+	if err != nil {
+		return errors.Wrapf(err, "error archiving %q in container %s", path, c.ID())
+	}
+	defer input.Close()
+
+	if _, err := io.Copy(w, input); err != nil {
+		return errors.Wrapf(err, "error copying %q from container %s", path, c.ID())
+	}
+	return nil
+}
+
+// Extract an archive into a directory in the container
+// Must be called with the container locked and its storage mounted
+func (c *Container) copyTo(mountPoint, path string, input io.Reader) error {
+	mounts := c.copyMounts()
+
+	resolved, err := resolveContainerPath(mountPoint, mounts, path)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving %q in container %s", path, c.ID())
+	}
+	hostPath, mount := containerHostPath(mountPoint, mounts, resolved)
+
+	readOnly := c.config.Spec != nil && c.config.Spec.Root != nil && c.config.Spec.Root.Readonly
+	if mount != nil {
+		readOnly = mount.readOnly
+	}
+	if readOnly {
+		return errors.Wrapf(ErrInvalidArg, "cannot copy to %q in container %s as it is read-only", path, c.ID())
+	}
+
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		return errors.Wrapf(err, "error copying to %q in container %s", path, c.ID())
+	}
+	if !info.IsDir() {
+		return errors.Wrapf(ErrInvalidArg, "cannot copy to %q in container %s as it is not a directory", path, c.ID())
+	}
+
+	// Extract into the directory that was resolved, even if a symlink is
+	// swapped into its path
+	dir, err := openContainerDir(mountPoint, mounts, resolved)
+	if err != nil {
+		return errors.Wrapf(err, "error copying to %q in container %s", path, c.ID())
+	}
+	defer dir.Close()
+
+	logrus.Debugf("Copying to %q in container %s (%q)", path, c.ID(), hostPath)
+
+	options := &archive.TarOptions{
+		UIDMaps:              c.config.IDMappings.UIDMap,
+		GIDMaps:              c.config.IDMappings.GIDMap,
+		NoOverwriteDirNonDir: true,
+	}
+	if err := chrootarchive.Untar(input, procFDPath(dir), options); err != nil {
+		return errors.Wrapf(err, "error copying to %q in container %s", path, c.ID())
+	}
+	return nil
+}
+
+// Get the bind mounts of the container that are copied to and from in place
+// of its root filesystem
+func (c *Container) copyMounts() []copyMount {
+	var mounts []copyMount
+	var specMounts []spec.Mount
+	if c.config.Spec != nil {
+		specMounts = c.config.Spec.Mounts
+	}
+	for _, m := range specMounts {
+		if m.Type != "bind" {
+			continue
+		}
+		mount := copyMount{
+			source:      m.Source,
+			destination: filepath.Clean(m.Destination),
+		}
+		for _, opt := range m.Options {
+			if opt == "ro" {
+				mount.readOnly = true
+			}
+		}
+		mounts = append(mounts, mount)
+	}
+	// Files libpod mounts into the container, unless the user overrode them
+	for dest, src := range c.state.BindMounts {
+		if MountExists(specMounts, dest) {
+			continue
+		}
+		mounts = append(mounts, copyMount{
+			source:      src,
+			destination: filepath.Clean(dest),
+		})
+	}
+	return mounts
+}
+
+// Get the path on the host of a resolved path in a container, and the mount
+// it is in, if any
+func containerHostPath(mountPoint string, mounts []copyMount, path string) (string, *copyMount) {
+	var found *copyMount
+	for i := range mounts {
+		dest := mounts[i].destination
+		if path != dest && !strings.HasPrefix(path, strings.TrimSuffix(dest, "/")+"/") {
+			continue
+		}
+		if found == nil || len(dest) > len(found.destination) {
+			found = &mounts[i]
+		}
+	}
+	if found == nil {
+		return filepath.Join(mountPoint, path), nil
+	}
+	return filepath.Join(found.source, strings.TrimPrefix(path, found.destination)), found
+}
+
+// Resolve all symlinks in a path in a container, returning the path they lead
+// to in the container
+// Symlinks are read from the container's root filesystem, or from the mounts
+// the path passes through, and absolute symlinks are followed from the root
+// of the container, so the path can never lead out of the container.
+// Elements of the path that do not exist are kept as they are.
+func resolveContainerPath(mountPoint string, mounts []copyMount, path string) (string, error) {
+	resolved := "/"
+	remaining := filepath.Join("/", path)
+	followed := 0
+
+	for {
+		remaining = strings.TrimLeft(remaining, "/")
+		if remaining == "" {
+			return resolved, nil
+		}
+
+		elem := remaining
+		remaining = ""
+		if i := strings.Index(elem, "/"); i >= 0 {
+			elem, remaining = elem[:i], elem[i:]
+		}
+
+		next := filepath.Join(resolved, elem)
+		hostPath, _ := containerHostPath(mountPoint, mounts, next)
+		info, err := os.Lstat(hostPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.Join(next, remaining), nil
+			}
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		followed++
+		if followed > maxSymlinkFollows {
+			return "", errors.Wrapf(ErrInvalidArg, "too many levels of symbolic links in %q", path)
+		}
+		target, err := os.Readlink(hostPath)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			resolved = "/"
+		}
+		remaining = target + "/" + remaining
+	}
+}
+
+// Open a directory in a container, given its resolved path, without following
+// symlinks
+// The path is opened one element at a time, so a symlink swapped into it after
+// it was resolved makes the open fail rather than lead out of the container.
+// The directory is opened with O_PATH, to be accessed through procFDPath.
+func openContainerDir(mountPoint string, mounts []copyMount, resolved string) (*os.File, error) {
+	root, rel := mountPoint, resolved
+	if _, mount := containerHostPath(mountPoint, mounts, resolved); mount != nil {
+		root, rel = mount.source, strings.TrimPrefix(resolved, mount.destination)
+	}
+	hostPath := filepath.Join(root, rel)
+
+	fd, err := unix.Open(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: root, Err: err}
+	}
+	for _, elem := range strings.Split(rel, "/") {
+		if elem == "" {
+			continue
+		}
+		next, err := unix.Openat(fd, elem, unix.O_PATH|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		unix.Close(fd)
+		if err != nil {
+			return nil, &os.PathError{Op: "open", Path: hostPath, Err: err}
+		}
+		fd = next
+	}
+
+	return os.NewFile(uintptr(fd), hostPath), nil
+}
+
+// Open the directory holding a resolved path in a container, as in
+// openContainerDir, and get the name of the path in that directory
+// A path that is a mount is found in the directory of the mount's source.
+func openContainerParent(mountPoint string, mounts []copyMount, resolved string) (*os.File, string, error) {
+	if _, mount := containerHostPath(mountPoint, mounts, resolved); mount != nil && mount.destination == resolved {
+		fd, err := unix.Open(filepath.Dir(mount.source), unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return nil, "", &os.PathError{Op: "open", Path: filepath.Dir(mount.source), Err: err}
+		}
+		return os.NewFile(uintptr(fd), filepath.Dir(mount.source)), filepath.Base(mount.source), nil
+	}
+
+	dir, err := openContainerDir(mountPoint, mounts, filepath.Dir(resolved))
+	if err != nil {
+		return nil, "", err
+	}
+	return dir, filepath.Base(resolved), nil
+}
+
+// Get a path to an open directory through procfs
+// The path leads to the directory for as long as it is open, whatever happens
+// to the path it was opened by, and can be used by processes podman starts.
+func procFDPath(dir *os.File) string {
+	return fmt.Sprintf("/proc/%d/fd/%d/.", os.Getpid(), dir.Fd())
+}
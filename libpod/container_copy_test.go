@@ -0,0 +1,145 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Make a root filesystem and a volume to resolve paths in
+func getCopyTestDirs(t *testing.T) (string, []copyMount, func()) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	assert.NoError(t, err)
+
+	rootfs := filepath.Join(tmpDir, "rootfs")
+	volume := filepath.Join(tmpDir, "volume")
+	for _, dir := range []string{
+		filepath.Join(rootfs, "etc"),
+		filepath.Join(rootfs, "usr", "lib"),
+		filepath.Join(rootfs, "data"),
+		filepath.Join(volume, "sub"),
+	} {
+		assert.NoError(t, os.MkdirAll(dir, 0755))
+	}
+	assert.NoError(t, os.Symlink("usr/lib", filepath.Join(rootfs, "lib")))
+	assert.NoError(t, os.Symlink("../../../..", filepath.Join(rootfs, "usr", "lib", "up")))
+	assert.NoError(t, os.Symlink("/etc", filepath.Join(volume, "sub", "etc")))
+	assert.NoError(t, os.Symlink("loop2", filepath.Join(rootfs, "loop1")))
+	assert.NoError(t, os.Symlink("loop1", filepath.Join(rootfs, "loop2")))
+
+	mounts := []copyMount{
+		{
+			source:      volume,
+			destination: "/data",
+		},
+	}
+
+	return rootfs, mounts, func() { os.RemoveAll(tmpDir) }
+}
+
+func TestResolveContainerPathRelativeSymlink(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	resolved, err := resolveContainerPath(rootfs, mounts, "/lib/file")
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/lib/file", resolved)
+}
+
+func TestResolveContainerPathRelativePath(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	resolved, err := resolveContainerPath(rootfs, mounts, "lib")
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/lib", resolved)
+}
+
+func TestResolveContainerPathSymlinkCannotLeaveRoot(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	resolved, err := resolveContainerPath(rootfs, mounts, "/usr/lib/up/etc")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc", resolved)
+
+	resolved, err = resolveContainerPath(rootfs, mounts, "/../../etc")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc", resolved)
+}
+
+func TestResolveContainerPathAbsoluteSymlinkInMount(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	resolved, err := resolveContainerPath(rootfs, mounts, "/data/sub/etc/passwd")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/passwd", resolved)
+}
+
+func TestResolveContainerPathSymlinkLoopFails(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	_, err := resolveContainerPath(rootfs, mounts, "/loop1/file")
+	assert.Error(t, err)
+}
+
+func TestContainerHostPathUsesMount(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	hostPath, mount := containerHostPath(rootfs, mounts, "/data/sub")
+	assert.Equal(t, filepath.Join(mounts[0].source, "sub"), hostPath)
+	assert.Equal(t, &mounts[0], mount)
+
+	hostPath, mount = containerHostPath(rootfs, mounts, "/database")
+	assert.Equal(t, filepath.Join(rootfs, "database"), hostPath)
+	assert.Nil(t, mount)
+}
+
+func TestOpenContainerDir(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	dir, err := openContainerDir(rootfs, mounts, "/data/sub")
+	assert.NoError(t, err)
+	defer dir.Close()
+	info, err := os.Stat(procFDPath(dir))
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(procFDPath(dir), "file"), []byte("test"), 0644))
+	_, err = os.Stat(filepath.Join(mounts[0].source, "sub", "file"))
+	assert.NoError(t, err)
+}
+
+func TestOpenContainerDirRefusesSymlink(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	// A symlink swapped in after the path was resolved
+	_, err := openContainerDir(rootfs, mounts, "/lib")
+	assert.Error(t, err)
+	_, err = openContainerDir(rootfs, mounts, "/lib/up")
+	assert.Error(t, err)
+}
+
+func TestOpenContainerParent(t *testing.T) {
+	rootfs, mounts, cleanup := getCopyTestDirs(t)
+	defer cleanup()
+
+	dir, name, err := openContainerParent(rootfs, mounts, "/usr/lib")
+	assert.NoError(t, err)
+	defer dir.Close()
+	assert.Equal(t, "lib", name)
+	assert.Equal(t, filepath.Join(rootfs, "usr"), dir.Name())
+
+	mountDir, name, err := openContainerParent(rootfs, mounts, "/data")
+	assert.NoError(t, err)
+	defer mountDir.Close()
+	assert.Equal(t, filepath.Base(mounts[0].source), name)
+	assert.Equal(t, filepath.Dir(mounts[0].source), mountDir.Name())
+}
@@ -0,0 +1,561 @@
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/daemon/caps"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/pkg/inspect"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ExecStateCreated indicates that an exec session has been created but
+	// not yet started
+	ExecStateCreated = "created"
+	// ExecStateRunning indicates that an exec session is running
+	ExecStateRunning = "running"
+	// ExecStateExited indicates that an exec session has exited, and its
+	// exit code is known
+	ExecStateExited = "exited"
+)
+
+const (
+	// execMonitorCommand is the name the exec monitor of an exec session
+	// runs under
+	execMonitorCommand = "libpod-exec-monitor"
+	// execMonitorShell is the shell running the exec monitor script
+	execMonitorShell = "/bin/sh"
+	// execMonitorScript runs the OCI runtime to execute the process of an
+	// exec session, and writes the exit code of the session to its exit
+	// file once it exits
+	// The exit file is written atomically, so it is never read half
+	// written. Its arguments are the exit file and the runtime command
+	// line. It is run by the shell rather than by re-executing the current
+	// program, so programs using libpod need no special handling of their
+	// own arguments
+	execMonitorScript = `exit_file="$1"
+shift
+"$@"
+echo "$?" > "$exit_file.tmp" && mv -f "$exit_file.tmp" "$exit_file"`
+	// execStartTimeout is how long to wait for the OCI runtime to start an
+	// exec session
+	execStartTimeout = 5 * time.Second
+)
+
+// ExecConfig contains the configuration of an exec session
+type ExecConfig struct {
+	// Command is the command to run and its arguments
+	Command []string `json:"command"`
+	// Env contains environment variables, in KEY=VALUE form, to set in
+	// addition to those of the container
+	Env []string `json:"env,omitempty"`
+	// Tty indicates that the command is given a pseudo-TTY
+	Tty bool `json:"tty,omitempty"`
+	// Privileged indicates that the command is given all capabilities
+	Privileged bool `json:"privileged,omitempty"`
+	// User is the user, and optionally the group, to run the command as
+	// If not set, the user of the container is used
+	User string `json:"user,omitempty"`
+	// WorkDir is the working directory of the command
+	// If not set, the working directory of the container is used
+	WorkDir string `json:"workDir,omitempty"`
+	// PreserveFDs is the number of file descriptors after the standard
+	// streams, starting at 3, that are passed on to the command
+	PreserveFDs uint `json:"preserveFds,omitempty"`
+}
+
+// Make a copy of an exec configuration
+func (e *ExecConfig) copy() *ExecConfig {
+	newConfig := new(ExecConfig)
+	*newConfig = *e
+	newConfig.Command = append([]string{}, e.Command...)
+	newConfig.Env = append([]string{}, e.Env...)
+	return newConfig
+}
+
+// ExecCreate creates a new exec session in the container and returns its ID
+// The container must be running. The session runs nothing until it is started
+// with ExecStart or ExecStartAndAttach.
+func (c *Container) ExecCreate(config *ExecConfig) (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.execCreate(config)
+}
+
+// ExecStart starts an exec session without attaching to it
+// It returns once the session's process is running. The session's input and
+// output are discarded, and it cannot be given a TTY. Its exit code is
+// recorded in the session when it exits.
+// Exec sessions are run through /bin/sh, which must exist on the host.
+func (c *Container) ExecStart(id string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	session, err := c.execSessionToStart(id)
+	if err != nil {
+		return err
+	}
+	if session.Config.Tty {
+		return errors.Wrapf(ErrInvalidArg, "cannot allocate a TTY for exec session %s as it is not attached", id)
+	}
+
+	execCmd, err := c.startExecSession(session, nil, true)
+	if err != nil {
+		return err
+	}
+
+	// Reap the monitor if it exits while we are still around; otherwise
+	// it is reparented
+	go execCmd.Wait()
+
+	return nil
+}
+
+// ExecStartAndAttach starts an exec session attached to the given streams, or
+// to the standard input and output of the current process if streams is nil,
+// and waits for it to exit
+// The exit code of the session is returned, and also recorded in the session.
+func (c *Container) ExecStartAndAttach(id string, streams *AttachStreams) (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return -1, err
+		}
+	}
+
+	return c.execStartAndAttach(id, streams)
+}
+
+// Exec runs a command inside the container and waits for it to exit
+// The command is attached to the given streams, or to the standard input and
+// output of the current process if streams is nil. Its exit code is returned.
+// The exec session used to run it is removed once the command exits.
+// Exec sessions are run through /bin/sh, which must exist on the host.
+func (c *Container) Exec(config *ExecConfig, streams *AttachStreams) (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return -1, err
+		}
+	}
+
+	id, err := c.execCreate(config)
+	if err != nil {
+		return -1, err
+	}
+
+	exitCode, err := c.execStartAndAttach(id, streams)
+
+	if _, ok := c.state.ExecSessions[id]; ok {
+		if rmErr := c.execRemove(id, false); rmErr != nil {
+			logrus.Errorf("Error removing exec session %s from container %s: %v", id, c.ID(), rmErr)
+		}
+	}
+
+	return exitCode, err
+}
+
+// ExecRemove removes an exec session from the container
+// A running exec session is only removed if force is set, in which case its
+// process is killed
+func (c *Container) ExecRemove(id string, force bool) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.execRemove(id, force)
+}
+
+// InspectExecSession returns the inspect information of an exec session
+func (c *Container) InspectExecSession(id string) (*inspect.ExecSessionInspectData, error) {
+	session, err := c.ExecSession(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &inspect.ExecSessionInspectData{
+		ID:          session.ID,
+		ContainerID: c.ID(),
+		State:       session.State,
+		Running:     session.State == ExecStateRunning,
+		ExitCode:    session.ExitCode,
+		Pid:         session.PID,
+		Detached:    session.Detached,
+		StartedAt:   session.StartedTime,
+		FinishedAt:  session.FinishedTime,
+		ProcessConfig: inspect.ExecProcessConfig{
+			Command: session.Command,
+		},
+	}
+	if session.Config != nil {
+		data.ProcessConfig.Tty = session.Config.Tty
+		data.ProcessConfig.Privileged = session.Config.Privileged
+		data.ProcessConfig.User = session.Config.User
+		data.ProcessConfig.WorkingDir = session.Config.WorkDir
+		data.ProcessConfig.PreserveFDs = session.Config.PreserveFDs
+	}
+
+	return data, nil
+}
+
+// Create an exec session
+// Must be called with the container locked
+func (c *Container) execCreate(config *ExecConfig) (string, error) {
+	if config == nil || len(config.Command) == 0 {
+		return "", errors.Wrapf(ErrInvalidArg, "must provide a command to execute")
+	}
+	if c.state.State != ContainerStateRunning {
+		return "", errors.Wrapf(ErrCtrStateInvalid, "cannot exec into container %s as it is not running", c.ID())
+	}
+
+	// Generate exec session ID
+	// Ensure we don't conflict with an existing session ID
+	sessionID := stringid.GenerateNonCryptoID()
+	for {
+		if _, ok := c.state.ExecSessions[sessionID]; !ok {
+			break
+		}
+		sessionID = stringid.GenerateNonCryptoID()
+	}
+
+	session := new(ExecSession)
+	session.ID = sessionID
+	session.Config = config.copy()
+	session.Command = session.Config.Command
+	session.State = ExecStateCreated
+
+	if c.state.ExecSessions == nil {
+		c.state.ExecSessions = make(map[string]*ExecSession)
+	}
+	c.state.ExecSessions[sessionID] = session
+	if err := c.save(); err != nil {
+		delete(c.state.ExecSessions, sessionID)
+		return "", errors.Wrapf(err, "error saving exec session %s for container %s", sessionID, c.ID())
+	}
+
+	logrus.Debugf("Created exec session %s in container %s", sessionID, c.ID())
+
+	return sessionID, nil
+}
+
+// Start an exec session attached to the given streams and wait for it to exit
+// Must be called with the container locked
+// The lock is released while the session runs
+func (c *Container) execStartAndAttach(id string, streams *AttachStreams) (int, error) {
+	session, err := c.execSessionToStart(id)
+	if err != nil {
+		return -1, err
+	}
+
+	execCmd, err := c.startExecSession(session, streams, false)
+	if err != nil {
+		return -1, err
+	}
+
+	// Unlock so other processes can use the container
+	if !c.batched {
+		c.lock.Unlock()
+	}
+
+	waitErr := execCmd.Wait()
+
+	// Lock again
+	if !c.batched {
+		c.lock.Lock()
+	}
+
+	if waitErr != nil {
+		logrus.Debugf("Monitor of exec session %s in container %s failed: %v", id, c.ID(), waitErr)
+	}
+
+	// Sync the container again to pick up the exit code
+	if err := c.syncContainer(); err != nil {
+		return -1, errors.Wrapf(err, "error syncing container %s state to get exit code of exec session %s", c.ID(), id)
+	}
+
+	session, ok := c.state.ExecSessions[id]
+	if !ok || session.State != ExecStateExited {
+		return -1, errors.Wrapf(ErrInternal, "exec session %s in container %s exited without recording an exit code", id, c.ID())
+	}
+
+	return session.ExitCode, nil
+}
+
+// Get an exec session that can be started
+// Must be called with the container locked
+func (c *Container) execSessionToStart(id string) (*ExecSession, error) {
+	if c.state.State != ContainerStateRunning {
+		return nil, errors.Wrapf(ErrCtrStateInvalid, "cannot exec into container %s as it is not running", c.ID())
+	}
+
+	session, ok := c.state.ExecSessions[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchExecSession, "no exec session with ID %s found in container %s", id, c.ID())
+	}
+	if session.State != ExecStateCreated {
+		return nil, errors.Wrapf(ErrCtrStateInvalid, "exec session %s in container %s has already been started", id, c.ID())
+	}
+
+	return session, nil
+}
+
+// Start the process of an exec session and record it in the session
+// Must be called with the container locked
+func (c *Container) startExecSession(session *ExecSession, streams *AttachStreams, detach bool) (*exec.Cmd, error) {
+	var capList []string
+	if session.Config.Privileged || c.config.Privileged {
+		capList = caps.GetAllCapabilities()
+	}
+
+	// Clear out anything left by a previous session with the same ID
+	c.removeExecFiles(session.ID)
+
+	execCmd, err := c.runtime.ociRuntime.execContainer(c, session.ID, session.Config, capList, streams, detach)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating exec command for container %s", c.ID())
+	}
+
+	if err := execCmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "error starting exec command for container %s", c.ID())
+	}
+
+	pid, err := c.waitForExecPID(session.ID)
+	if err != nil {
+		// We cannot track the session, so make sure it does not run
+		if err := execCmd.Process.Kill(); err != nil {
+			logrus.Errorf("Error killing monitor of exec session %s in container %s: %v", session.ID, c.ID(), err)
+		}
+		execCmd.Wait()
+		return nil, err
+	}
+
+	session.PID = pid
+	session.MonitorPID = execCmd.Process.Pid
+	session.MonitorStartTime, err = processStartTime(execCmd.Process.Pid)
+	if err != nil {
+		logrus.Warnf("Error getting start time of monitor of exec session %s in container %s: %v", session.ID, c.ID(), err)
+	}
+	session.State = ExecStateRunning
+	session.Detached = detach
+	session.StartedTime = time.Now()
+	if err := c.save(); err != nil {
+		// Now we have a PID but we can't save it in the DB
+		// TODO handle this better
+		return nil, errors.Wrapf(err, "error saving exec session %s for container %s", session.ID, c.ID())
+	}
+
+	logrus.Debugf("Successfully started exec session %s in container %s", session.ID, c.ID())
+
+	c.newExecEvent(session)
+
+	return execCmd, nil
+}
+
+// Wait for the OCI runtime to write the PID file of an exec session
+// If the session exits before the PID file is written, 0 is returned
+func (c *Container) waitForExecPID(sessionID string) (int, error) {
+	pidFile := c.execPidPath(sessionID)
+	exitFile := c.execExitPath(sessionID)
+	timeout := time.After(execStartTimeout)
+
+	for {
+		contents, err := ioutil.ReadFile(pidFile)
+		if err == nil {
+			pid, err := strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 32)
+			if err != nil {
+				return 0, errors.Wrapf(err, "error parsing PID of exec session %s in container %s", sessionID, c.ID())
+			}
+			return int(pid), nil
+		}
+		if _, err := os.Stat(exitFile); err == nil {
+			return 0, nil
+		}
+
+		select {
+		case <-timeout:
+			return 0, errors.Wrapf(ErrInternal, "timed out waiting for runtime to start exec session %s in container %s", sessionID, c.ID())
+		case <-time.After(25 * time.Millisecond):
+		}
+	}
+}
+
+// Remove an exec session
+// Must be called with the container locked
+func (c *Container) execRemove(id string, force bool) error {
+	session, ok := c.state.ExecSessions[id]
+	if !ok {
+		return errors.Wrapf(ErrNoSuchExecSession, "no exec session with ID %s found in container %s", id, c.ID())
+	}
+
+	if session.State == ExecStateRunning {
+		if !force {
+			return errors.Wrapf(ErrCtrStateInvalid, "exec session %s in container %s is running, refusing to remove it", id, c.ID())
+		}
+		if session.PID > 0 {
+			if err := unix.Kill(session.PID, unix.SIGKILL); err != nil && err != unix.ESRCH {
+				return errors.Wrapf(err, "error killing exec session %s in container %s", id, c.ID())
+			}
+		}
+	}
+
+	delete(c.state.ExecSessions, id)
+	c.removeExecFiles(id)
+
+	if err := c.save(); err != nil {
+		return errors.Wrapf(err, "error removing exec session %s from container %s", id, c.ID())
+	}
+
+	logrus.Debugf("Removed exec session %s from container %s", id, c.ID())
+
+	return nil
+}
+
+// Update the state of the container's running exec sessions
+// Sessions that have exited get their exit code recorded. Sessions that exited
+// without recording one, for example because podman was killed while attached
+// to them, are stale and are removed.
+// Returns whether any session changed
+// Must be called with the container locked
+func (c *Container) syncExecSessions() bool {
+	changed := false
+
+	for id, session := range c.state.ExecSessions {
+		if session.State != ExecStateRunning {
+			continue
+		}
+
+		// The monitor exits only after it recorded the exit code, so the
+		// session has exited once the exit file is there
+		exitCode, err := readExecExitFile(c.execExitPath(id))
+		if os.IsNotExist(err) && execMonitorRunning(session) {
+			continue
+		}
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logrus.Errorf("Error reading exit code of exec session %s in container %s: %v", id, c.ID(), err)
+			}
+			logrus.Debugf("Removing stale exec session %s from container %s", id, c.ID())
+			delete(c.state.ExecSessions, id)
+		} else {
+			logrus.Debugf("Exec session %s in container %s exited with code %d", id, c.ID(), exitCode)
+			session.State = ExecStateExited
+			session.ExitCode = exitCode
+			session.FinishedTime = time.Now()
+		}
+		c.removeExecFiles(id)
+		changed = true
+	}
+
+	return changed
+}
+
+// Determine whether the monitor of an exec session is still running
+// A process that reused the monitor's PID is told apart by its start time
+func execMonitorRunning(session *ExecSession) bool {
+	if session.MonitorPID <= 0 {
+		return false
+	}
+	if err := unix.Kill(session.MonitorPID, 0); err == unix.ESRCH {
+		return false
+	}
+	// Sessions started before start times were recorded
+	if session.MonitorStartTime == 0 {
+		return true
+	}
+	startTime, err := processStartTime(session.MonitorPID)
+	if err != nil {
+		return !os.IsNotExist(errors.Cause(err))
+	}
+	return startTime == session.MonitorStartTime
+}
+
+// Get the start time of a process, in clock ticks since boot
+func processStartTime(pid int) (uint64, error) {
+	contents, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+	// The command name may contain spaces and parentheses, so fields are
+	// counted from the end of it
+	stat := string(contents)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	// The start time is the 22nd field, and the fields after the command
+	// name begin with the 3rd
+	if len(fields) < 20 {
+		return 0, errors.Wrapf(ErrInternal, "error parsing status of process %d", pid)
+	}
+	startTime, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error parsing start time of process %d", pid)
+	}
+	return startTime, nil
+}
+
+// Determine whether the container has running exec sessions
+func (c *Container) hasRunningExecSessions() bool {
+	for _, session := range c.state.ExecSessions {
+		if session.State == ExecStateRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the path of the file the exit code of an exec session is written to
+func (c *Container) execExitPath(sessionID string) string {
+	return filepath.Join(c.state.RunDir, "exec_exit_"+sessionID)
+}
+
+// Remove the PID and exit files of an exec session
+func (c *Container) removeExecFiles(sessionID string) {
+	for _, path := range []string{c.execPidPath(sessionID), c.execExitPath(sessionID)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Error removing %s for exec session %s in container %s: %v", path, sessionID, c.ID(), err)
+		}
+	}
+}
+
+// Read the exit code of an exec session from its exit file
+func readExecExitFile(path string) (int, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return -1, err
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return -1, errors.Wrapf(err, "error parsing exit code in %s", path)
+	}
+	return exitCode, nil
+}
@@ -0,0 +1,117 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Get the PID of a process that has exited
+func getExitedPID(t *testing.T) int {
+	cmd := exec.Command("true")
+	assert.NoError(t, cmd.Run())
+	return cmd.Process.Pid
+}
+
+func TestSyncExecSessions(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	startTime, err := processStartTime(os.Getpid())
+	assert.NoError(t, err)
+
+	ctr := &Container{
+		config: &ContainerConfig{ID: "ctr"},
+		state: &containerState{
+			RunDir: tmpDir,
+			ExecSessions: map[string]*ExecSession{
+				"created":   {ID: "created", State: ExecStateCreated},
+				"running":   {ID: "running", State: ExecStateRunning, MonitorPID: os.Getpid()},
+				"started":   {ID: "started", State: ExecStateRunning, MonitorPID: os.Getpid(), MonitorStartTime: startTime},
+				"exited":    {ID: "exited", State: ExecStateRunning, MonitorPID: getExitedPID(t)},
+				"exiting":   {ID: "exiting", State: ExecStateRunning, MonitorPID: os.Getpid(), MonitorStartTime: startTime},
+				"stale":     {ID: "stale", State: ExecStateRunning, MonitorPID: getExitedPID(t)},
+				"reusedPID": {ID: "reusedPID", State: ExecStateRunning, MonitorPID: os.Getpid(), MonitorStartTime: startTime + 1},
+			},
+		},
+	}
+	assert.NoError(t, ioutil.WriteFile(ctr.execExitPath("exited"), []byte("3"), 0644))
+	assert.NoError(t, ioutil.WriteFile(ctr.execExitPath("exiting"), []byte("0"), 0644))
+	assert.NoError(t, ioutil.WriteFile(ctr.execPidPath("exited"), []byte("1234"), 0644))
+
+	assert.True(t, ctr.syncExecSessions())
+
+	assert.Equal(t, ExecStateCreated, ctr.state.ExecSessions["created"].State)
+	assert.Equal(t, ExecStateRunning, ctr.state.ExecSessions["running"].State)
+	assert.Equal(t, ExecStateRunning, ctr.state.ExecSessions["started"].State)
+	assert.Equal(t, ExecStateExited, ctr.state.ExecSessions["exiting"].State)
+	assert.NotContains(t, ctr.state.ExecSessions, "reusedPID")
+	assert.Equal(t, ExecStateExited, ctr.state.ExecSessions["exited"].State)
+	assert.Equal(t, 3, ctr.state.ExecSessions["exited"].ExitCode)
+	assert.False(t, ctr.state.ExecSessions["exited"].FinishedTime.IsZero())
+	assert.NotContains(t, ctr.state.ExecSessions, "stale")
+
+	_, err = os.Stat(ctr.execExitPath("exited"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(ctr.execPidPath("exited"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.True(t, ctr.hasRunningExecSessions())
+	assert.False(t, ctr.syncExecSessions())
+}
+
+func TestReadExecExitFile(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "exit")
+	_, err = readExecExitFile(path)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("137\n"), 0644))
+	exitCode, err := readExecExitFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 137, exitCode)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("bogus"), 0644))
+	_, err = readExecExitFile(path)
+	assert.Error(t, err)
+}
+
+func TestExecMonitorScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "exit")
+
+	runMonitor := func(args ...string) int {
+		args = append([]string{"-c", execMonitorScript, execMonitorCommand, path}, args...)
+		assert.NoError(t, exec.Command(execMonitorShell, args...).Run())
+		exitCode, err := readExecExitFile(path)
+		assert.NoError(t, err)
+		return exitCode
+	}
+
+	assert.Equal(t, 0, runMonitor("true"))
+	assert.Equal(t, 42, runMonitor("sh", "-c", "exit 42"))
+	assert.Equal(t, 137, runMonitor("sh", "-c", "kill -9 $$"))
+	assert.Equal(t, 127, runMonitor("/does/not/exist"))
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestProcessStartTime(t *testing.T) {
+	startTime, err := processStartTime(os.Getpid())
+	assert.NoError(t, err)
+	assert.NotEqual(t, uint64(0), startTime)
+
+	_, err = processStartTime(getExitedPID(t))
+	assert.True(t, os.IsNotExist(err))
+}
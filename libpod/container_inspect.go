@@ -8,6 +8,49 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Get the container's configuration in one of its networks
+// The static IP only applies to the container's first network
+func (c *Container) getEndpointSettings(network string) *inspect.EndpointSettings {
+	endpoint := &inspect.EndpointSettings{
+		Aliases: c.NetworkAliases(),
+	}
+
+	if c.config.StaticIP != nil && network == c.runtime.containerNetworks(c)[0] {
+		endpoint.IPAMConfig = new(inspect.EndpointIPAMConfig)
+		if c.config.StaticIP.To4() != nil {
+			endpoint.IPAMConfig.IPv4Address = c.config.StaticIP.String()
+		} else {
+			endpoint.IPAMConfig.IPv6Address = c.config.StaticIP.String()
+		}
+	}
+
+	result, ok := c.state.NetworkStatus[network]
+	if !ok {
+		return endpoint
+	}
+
+	for _, ip := range result.IPs {
+		if ip.Version != "4" {
+			continue
+		}
+		endpoint.IPAddress = ip.Address.IP.String()
+		endpoint.IPPrefixLen, _ = ip.Address.Mask.Size()
+		if ip.Gateway != nil {
+			endpoint.Gateway = ip.Gateway.String()
+		}
+		break
+	}
+
+	for _, iface := range result.Interfaces {
+		if iface.Sandbox != "" {
+			endpoint.MacAddress = iface.Mac
+			break
+		}
+	}
+
+	return endpoint
+}
+
 func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data) (*inspect.ContainerInspectData, error) {
 	config := c.config
 	runtimeInfo := c.state
@@ -76,6 +119,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 		StaticDir:       config.StaticDir,
 		LogPath:         config.LogPath,
 		Name:            config.Name,
+		RestartCount:    int32(runtimeInfo.RestartCount),
 		Driver:          driverData.Name,
 		MountLabel:      config.MountLabel,
 		ProcessLabel:    spec.Process.SelinuxLabel,
@@ -105,6 +149,18 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 		},
 	}
 
+	data.State.Checkpointed = runtimeInfo.Checkpointed
+
+	// Report the container's health if it has a healthcheck
+	if config.HealthCheckConfig != nil {
+		data.State.Healthcheck = &inspect.HealthCheckResults{
+			Status: HealthCheckStarting,
+		}
+		if runtimeInfo.HealthCheck != nil {
+			data.State.Healthcheck = runtimeInfo.HealthCheck
+		}
+	}
+
 	// Copy port mappings into network settings
 	if config.PortMappings != nil {
 		data.NetworkSettings.Ports = config.PortMappings
@@ -129,6 +185,15 @@ func (c *Container) getContainerInspectData(size bool, driverData *inspect.Data)
 		data.NetworkSettings.SandboxKey = runtimeInfo.NetNS.Path()
 	}
 
+	if config.CreateNetNS {
+		networks := c.runtime.containerNetworks(c)
+		data.NetworkSettings.Networks = make(map[string]*inspect.EndpointSettings)
+		for _, network := range networks {
+			data.NetworkSettings.Networks[network] = c.getEndpointSettings(network)
+		}
+		data.NetworkSettings.MacAddress = data.NetworkSettings.Networks[networks[0]].MacAddress
+	}
+
 	if size {
 		rootFsSize, err := c.rootFsSize()
 		if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	crioAnnotations "github.com/projectatomic/libpod/pkg/annotations"
 	"github.com/projectatomic/libpod/pkg/chrootuser"
 	"github.com/projectatomic/libpod/pkg/hooks"
@@ -157,6 +158,13 @@ func (c *Container) syncContainer() error {
 		return errors.Wrapf(ErrCtrRemoved, "container %s is not valid", c.ID())
 	}
 
+	// Record the exit codes of exec sessions and remove stale ones
+	if c.syncExecSessions() {
+		if err := c.save(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -466,6 +474,48 @@ func (c *Container) checkDependenciesRunning() ([]string, error) {
 	return notRunning, nil
 }
 
+// Start the infra container of the container's pod if it is not running, so
+// the container can join the namespaces it holds
+// Must be called without the container locked
+func (c *Container) startPodInfraContainer(ctx context.Context) error {
+	if c.config.Pod == "" || c.config.IsInfra {
+		return nil
+	}
+
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving pod %s of container %s", c.config.Pod, c.ID())
+	}
+	if !pod.HasInfraContainer() {
+		return nil
+	}
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		return err
+	}
+	if infraID == "" || (c.config.NetNsCtr != infraID && c.config.IPCNsCtr != infraID && c.config.UTSNsCtr != infraID) {
+		return nil
+	}
+
+	infra, err := c.runtime.state.Container(infraID)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving infra container %s of pod %s", infraID, pod.ID())
+	}
+
+	infra.lock.Lock()
+	defer infra.lock.Unlock()
+
+	if err := infra.syncContainer(); err != nil {
+		return err
+	}
+
+	if err := infra.initAndStart(ctx); err != nil {
+		return errors.Wrapf(err, "error starting infra container %s of pod %s", infraID, pod.ID())
+	}
+
+	return nil
+}
+
 // Check if a container's dependencies are running
 // Returns a []string containing the IDs of dependencies that are not running
 // Assumes depencies are already locked, and will be passed in
@@ -521,14 +571,26 @@ func (c *Container) init(ctx context.Context) error {
 		return err
 	}
 
+	// Start with a new log file if the previous run filled it
+	if err := c.checkLogRotation(); err != nil {
+		return err
+	}
+	if err := c.rotateLog(); err != nil {
+		return err
+	}
+
 	// With the spec complete, do an OCI create
-	if err := c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent); err != nil {
+	if err := c.runtime.ociRuntime.createContainer(c, c.config.CgroupParent, nil); err != nil {
 		return err
 	}
 
 	logrus.Debugf("Created container %s in OCI runtime", c.ID())
 
 	c.state.State = ContainerStateCreated
+	// Exec sessions from a previous run of the container are no longer
+	// needed
+	c.state.ExecSessions = make(map[string]*ExecSession)
+	c.newContainerEvent(events.Init)
 
 	if err := c.save(); err != nil {
 		return err
@@ -640,8 +702,21 @@ func (c *Container) start() error {
 	logrus.Debugf("Started container %s", c.ID())
 
 	c.state.State = ContainerStateRunning
+	// Starting a container afresh discards the processes it was
+	// checkpointed with
+	c.state.Checkpointed = false
+	c.resetHealthCheck()
+	c.newContainerEvent(events.Start)
 
-	return c.save()
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if err := c.runtime.updateHostsFiles(c); err != nil {
+		logrus.Errorf("Error updating hosts files of peers of container %s: %v", c.ID(), err)
+	}
+
+	return nil
 }
 
 // Internal, non-locking function to stop container
@@ -657,6 +732,12 @@ func (c *Container) stop(timeout uint) error {
 		return err
 	}
 
+	c.newContainerEvent(events.Stop)
+
+	if err := c.runtime.updateHostsFiles(c); err != nil {
+		logrus.Errorf("Error updating hosts files of peers of container %s: %v", c.ID(), err)
+	}
+
 	return c.cleanupStorage()
 }
 
@@ -697,6 +778,14 @@ func (c *Container) mountStorage() (err error) {
 	if err != nil {
 		return errors.Wrapf(err, "error mounting storage for container %s", c.ID())
 	}
+
+	if err := c.mountNamedVolumes(mountPoint); err != nil {
+		if err2 := c.runtime.storageService.UnmountContainerImage(c.ID()); err2 != nil {
+			logrus.Errorf("Error unmounting storage for container %s: %v", c.ID(), err2)
+		}
+		return err
+	}
+
 	c.state.Mounted = true
 	c.state.Mountpoint = mountPoint
 	if c.state.UserNSRoot == "" {
@@ -718,6 +807,52 @@ func (c *Container) mountStorage() (err error) {
 	return c.save()
 }
 
+// Mount the named volumes of the container, whose root filesystem is mounted
+// at mountPoint
+// Either all volumes are mounted, or none are
+func (c *Container) mountNamedVolumes(mountPoint string) (err error) {
+	var mounted []*Volume
+	defer func() {
+		if err != nil {
+			for _, vol := range mounted {
+				if err2 := vol.unmountForContainer(); err2 != nil {
+					logrus.Errorf("Error unmounting volume %s for container %s: %v", vol.Name(), c.ID(), err2)
+				}
+			}
+		}
+	}()
+
+	for _, namedVol := range c.config.NamedVolumes {
+		vol, err := c.runtime.state.Volume(namedVol.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving volume %s for container %s", namedVol.Name, c.ID())
+		}
+
+		if err := vol.mountForContainer(c, mountPoint, namedVol); err != nil {
+			return err
+		}
+		mounted = append(mounted, vol)
+	}
+
+	return nil
+}
+
+// Unmount the named volumes of the container
+// Errors are logged, as the container's storage is unmounted regardless
+func (c *Container) unmountNamedVolumes() {
+	for _, namedVol := range c.config.NamedVolumes {
+		vol, err := c.runtime.state.Volume(namedVol.Name)
+		if err != nil {
+			logrus.Errorf("Error retrieving volume %s for container %s: %v", namedVol.Name, c.ID(), err)
+			continue
+		}
+
+		if err := vol.unmountForContainer(); err != nil {
+			logrus.Errorf("Error unmounting volume %s for container %s: %v", vol.Name(), c.ID(), err)
+		}
+	}
+}
+
 // prepare mounts the container and sets up other required resources like net
 // namespaces
 func (c *Container) prepare() (err error) {
@@ -781,7 +916,17 @@ func (c *Container) cleanupNetwork() error {
 	c.state.NetNS = nil
 	c.state.IPs = nil
 	c.state.Routes = nil
-	return c.save()
+	c.state.Interfaces = nil
+	c.state.NetworkStatus = nil
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if err := c.runtime.updateHostsFiles(c); err != nil {
+		logrus.Errorf("Error updating hosts files of peers of container %s: %v", c.ID(), err)
+	}
+
+	return nil
 }
 
 // cleanupStorage unmounts and cleans up the container's root filesystem
@@ -807,12 +952,16 @@ func (c *Container) cleanupStorage() error {
 		// state
 		if err == storage.ErrNotAContainer || err == storage.ErrContainerUnknown {
 			logrus.Errorf("Storage for container %s has been removed", c.ID())
+			c.unmountNamedVolumes()
 			return nil
 		}
 
 		return errors.Wrapf(err, "error unmounting container %s root filesystem", c.ID())
 	}
 
+	// Named volumes are no longer used by the container
+	c.unmountNamedVolumes()
+
 	c.state.Mountpoint = ""
 	c.state.Mounted = false
 
@@ -1045,6 +1194,39 @@ func (r resolvConf) ToString() string {
 
 // generateHosts creates a containers hosts file
 func (c *Container) generateHosts() (string, error) {
+	ctrs, err := c.runtime.hostsContainers(c)
+	if err != nil {
+		return "", err
+	}
+	hosts, err := c.hostsFileContents(ctrs)
+	if err != nil {
+		return "", err
+	}
+	return c.writeStringToRundir("hosts", hosts)
+}
+
+// rewriteHosts rewrites the hosts file of a container that has already been
+// created, to reflect changes to the container and its peers, given the
+// containers that may be its peers
+// The file is rewritten in place, so running containers see the change
+func (c *Container) rewriteHosts(ctrs []*Container) error {
+	if _, ok := c.state.BindMounts["/etc/hosts"]; !ok {
+		return nil
+	}
+	hosts, err := c.hostsFileContents(ctrs)
+	if err != nil {
+		return err
+	}
+	hostsPath := filepath.Join(c.state.RunDir, "hosts")
+	if err := ioutil.WriteFile(hostsPath, []byte(hosts), 0644); err != nil {
+		return errors.Wrapf(err, "error updating hosts file for container %s", c.ID())
+	}
+	return nil
+}
+
+// Get the contents of a container's hosts file, given the containers that may
+// be its peers
+func (c *Container) hostsFileContents(ctrs []*Container) (string, error) {
 	orig, err := ioutil.ReadFile("/etc/hosts")
 	if err != nil {
 		return "", errors.Wrapf(err, "unable to read /etc/hosts")
@@ -1057,7 +1239,12 @@ func (c *Container) generateHosts() (string, error) {
 			hosts += fmt.Sprintf("%s %s\n", fields[1], fields[0])
 		}
 	}
-	return c.writeStringToRundir("hosts", hosts)
+	// Let the container resolve its own names, and those of the containers
+	// sharing a network or pod with it
+	for _, entry := range hostsEntries(c, ctrs, c.runtime.defaultNetworkName()) {
+		hosts += entry + "\n"
+	}
+	return hosts, nil
 }
 
 // Generate spec for a container
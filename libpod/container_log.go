@@ -0,0 +1,767 @@
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/logs"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// Log drivers supported for containers
+const (
+	// KubernetesLogging is the log driver writing the container's output to
+	// its log file, in the format of the Kubernetes CRI
+	KubernetesLogging = "k8s-file"
+	// JournaldLogging is the log driver sending the container's output to
+	// the systemd journal
+	JournaldLogging = "journald"
+	// NoLogging is the log driver discarding the container's output
+	NoLogging = "none"
+)
+
+const (
+	// Journal priorities conmon records the container's stdout and stderr
+	// with
+	journalPriorityStdout = "6"
+	journalPriorityStderr = "3"
+
+	// logPollInterval is how often logs that cannot be watched for changes
+	// are checked for new lines when following them
+	logPollInterval = 250 * time.Millisecond
+)
+
+// Log reads the logs of the given containers and sends their lines to the log
+// channel, which is closed when Log returns
+// The lines the containers wrote so far are interleaved by their timestamps.
+// When following, new lines are sent as they are written, until all of the
+// containers have exited, Until has passed or the context is cancelled.
+func (r *Runtime) Log(ctx context.Context, ctrs []*Container, options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	defer close(logChannel)
+
+	r.lock.RLock()
+	valid := r.valid
+	r.lock.RUnlock()
+	if !valid {
+		return ErrRuntimeStopped
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	histories := make(chan []*logs.LogLine, len(ctrs))
+	release := make(chan struct{})
+	errChan := make(chan error, len(ctrs))
+	var wg sync.WaitGroup
+	for _, ctr := range ctrs {
+		wg.Add(1)
+		go func(ctr *Container) {
+			defer wg.Done()
+			errChan <- ctr.readLog(ctx, options, histories, release, logChannel)
+		}(ctr)
+	}
+
+	// Each container first hands over the lines it wrote so far, which
+	// are sent in order before any container continues following
+	var history []*logs.LogLine
+	for range ctrs {
+		history = append(history, <-histories...)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Time.Before(history[j].Time)
+	})
+	for _, line := range history {
+		select {
+		case logChannel <- line:
+		case <-ctx.Done():
+		}
+	}
+	close(release)
+
+	wg.Wait()
+	close(errChan)
+	var lastError error
+	for err := range errChan {
+		if err != nil {
+			if lastError != nil {
+				logrus.Errorf("%v", lastError)
+			}
+			lastError = err
+		}
+	}
+	return lastError
+}
+
+// Read the container's logs
+// The lines the container wrote so far are sent to the history channel, and
+// reading continues once release is closed; new lines are then sent to the log
+// channel
+func (c *Container) readLog(ctx context.Context, options *logs.LogOptions, history chan<- []*logs.LogLine, release <-chan struct{}, logChannel chan<- *logs.LogLine) (err error) {
+	historySent := false
+	defer func() {
+		if !historySent {
+			history <- nil
+		}
+	}()
+
+	var follower *logFollower
+	if options.Follow {
+		follower, err = c.newLogFollower(options)
+		if err != nil {
+			return err
+		}
+		defer follower.close()
+	}
+
+	reader, err := c.logReader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if f, ok := reader.(*logFileReader); ok && options.Tail > 0 {
+		offset, err := logs.TailOffset(f, f.size(), options.Tail)
+		if err != nil {
+			return errors.Wrapf(err, "error reading log file of container %s", c.ID())
+		}
+		if err := f.seek(offset); err != nil {
+			return errors.Wrapf(err, "error reading log file of container %s", c.ID())
+		}
+	}
+
+	bufReader := bufio.NewReader(reader)
+	// Text of a log line that is still being written
+	incomplete := ""
+	// Partial lines of output by stream, until the line is complete
+	partials := make(map[string]*logs.LogLine)
+	var lines []*logs.LogLine
+	for {
+		text, err := bufReader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return errors.Wrapf(err, "error reading logs of container %s", c.ID())
+			}
+			incomplete += text
+
+			if !historySent {
+				if options.Tail > 0 && uint64(len(lines)) > options.Tail {
+					lines = lines[uint64(len(lines))-options.Tail:]
+				}
+				var matching []*logs.LogLine
+				for _, line := range lines {
+					if options.Matches(line) {
+						matching = append(matching, line)
+					}
+				}
+				history <- matching
+				historySent = true
+				lines = nil
+
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+
+			if follower == nil || !follower.wait(ctx) {
+				return nil
+			}
+			continue
+		}
+
+		text = incomplete + text
+		incomplete = ""
+		line, err := logs.NewLogLine(text)
+		if err != nil {
+			logrus.Warnf("Skipping malformed line in logs of container %s: %v", c.ID(), err)
+			continue
+		}
+		line.CID = c.ID()
+
+		// Reassemble lines of output the container wrote in parts
+		if partial, ok := partials[line.Device]; ok {
+			partial.Msg += line.Msg
+			partial.ParseLogType = line.ParseLogType
+			line = partial
+		}
+		if line.Partial() {
+			partials[line.Device] = line
+			continue
+		}
+		delete(partials, line.Device)
+
+		if !historySent {
+			lines = append(lines, line)
+			continue
+		}
+		if !options.Matches(line) {
+			continue
+		}
+		select {
+		case logChannel <- line:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// logFollower waits for new lines in a container's logs until the container
+// exits
+type logFollower struct {
+	ctr     *Container
+	options *logs.LogOptions
+	watcher *fsnotify.Watcher
+	exited  bool
+	done    bool
+}
+
+// Create a follower for the container's logs
+// It watches the log file for writes and rotation, and the exits directory for the exit of
+// the container; if the container is not running, following stops once the
+// logs have been read.
+func (c *Container) newLogFollower(options *logs.LogOptions) (*logFollower, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating watcher for logs of container %s", c.ID())
+	}
+	f := &logFollower{
+		ctr:     c,
+		options: options,
+		watcher: watcher,
+	}
+
+	exitsDir := c.runtime.ociRuntime.exitsDir
+	if err := watcher.Add(exitsDir); err != nil {
+		watcher.Close()
+		return nil, errors.Wrapf(err, "error watching %s for exit of container %s", exitsDir, c.ID())
+	}
+	if c.LogDriver() == KubernetesLogging {
+		// The directory is watched, as the log file may not exist yet
+		logDir := filepath.Dir(c.LogPath())
+		if err := watcher.Add(logDir); err != nil {
+			watcher.Close()
+			return nil, errors.Wrapf(err, "error watching %s for logs of container %s", logDir, c.ID())
+		}
+	}
+
+	// The state is checked once watching, so an exit is not missed
+	state, err := c.State()
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	if state != ContainerStateRunning && state != ContainerStatePaused {
+		f.exited = true
+	}
+
+	return f, nil
+}
+
+// Wait for new lines to be written
+// Returns false if following should stop instead. Once the container has
+// exited, true is returned once more, so the lines it wrote last are read.
+func (f *logFollower) wait(ctx context.Context) bool {
+	if f.done {
+		return false
+	}
+	if f.exited {
+		f.done = true
+		return true
+	}
+
+	var poll <-chan time.Time
+	if f.ctr.LogDriver() != KubernetesLogging {
+		poll = time.After(logPollInterval)
+	}
+	var until <-chan time.Time
+	if !f.options.Until.IsZero() {
+		if !time.Now().Before(f.options.Until) {
+			return false
+		}
+		timer := time.NewTimer(time.Until(f.options.Until))
+		defer timer.Stop()
+		until = timer.C
+	}
+
+	for {
+		select {
+		case event := <-f.watcher.Events:
+			if event.Name == filepath.Join(f.ctr.runtime.ociRuntime.exitsDir, f.ctr.ID()) && event.Op&fsnotify.Create == fsnotify.Create {
+				f.exited = true
+				return f.wait(ctx)
+			}
+			// Lines may still be written to the log file after
+			// it was rotated, until conmon reopens it
+			if event.Name == f.ctr.LogPath() || event.Name == f.ctr.rotatedLogPath(1) {
+				return true
+			}
+		case err := <-f.watcher.Errors:
+			logrus.Errorf("Error watching logs of container %s: %v", f.ctr.ID(), err)
+		case <-poll:
+			return true
+		case <-until:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (f *logFollower) close() {
+	if err := f.watcher.Close(); err != nil {
+		logrus.Errorf("Error closing watcher for logs of container %s: %v", f.ctr.ID(), err)
+	}
+}
+
+// Return a reader of the container's logs, as lines in the format of the
+// k8s-file log driver
+// Once all logs recorded so far were read, the reader returns io.EOF; reading
+// again returns the logs recorded since, so the logs can be followed
+func (c *Container) logReader() (io.ReadCloser, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch c.LogDriver() {
+	case NoLogging:
+		return nil, errors.Wrapf(ErrNoLogs, "container %s uses the %s log driver", c.ID(), NoLogging)
+	case JournaldLogging:
+		return &journaldLogReader{ctrID: c.ID()}, nil
+	}
+
+	reader, err := c.openLogFiles()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read log file of container %s", c.ID())
+	}
+	// A container that was never started has no logs yet
+	if len(reader.files) == 0 && c.state.State != ContainerStateConfigured {
+		reader.Close()
+		return nil, errors.Errorf("unable to read log file of container %s: %s does not exist", c.ID(), c.LogPath())
+	}
+	return reader, nil
+}
+
+// logFileReader reads a container's log file after the files it was rotated
+// to, oldest first, as if they were a single file
+// Once the last file has been read, reading continues with the log file if it
+// was rotated since, so rotated logs can be followed
+type logFileReader struct {
+	path  string
+	files []*os.File
+	// Sizes of the files when they were opened, for reading at an offset
+	sizes []int64
+	// Index of the file being read
+	cur int
+}
+
+// Open the container's log file and the files it was rotated to
+// Must be called with the container locked, so the files are not rotated while
+// they are opened
+func (c *Container) openLogFiles() (_ *logFileReader, err error) {
+	r := &logFileReader{path: c.LogPath()}
+	defer func() {
+		if err != nil {
+			r.Close()
+		}
+	}()
+
+	paths := []string{c.LogPath()}
+	for i := uint(1); i < c.config.LogMaxFiles; i++ {
+		paths = append([]string{c.rotatedLogPath(i)}, paths...)
+	}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			// Not every log file exists until the log was rotated
+			// often enough
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		r.files = append(r.files, f)
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		r.sizes = append(r.sizes, info.Size())
+	}
+	return r, nil
+}
+
+// Total size of the files when they were opened
+func (r *logFileReader) size() int64 {
+	var size int64
+	for _, s := range r.sizes {
+		size += s
+	}
+	return size
+}
+
+// ReadAt reads the files at the given offset, as far as they extended when they
+// were opened
+func (r *logFileReader) ReadAt(p []byte, off int64) (int, error) {
+	n := 0
+	var start int64
+	for i, f := range r.files {
+		end := start + r.sizes[i]
+		if off+int64(n) < end && n < len(p) {
+			want := p[n:]
+			if int64(len(want)) > end-off-int64(n) {
+				want = want[:end-off-int64(n)]
+			}
+			read, err := f.ReadAt(want, off+int64(n)-start)
+			n += read
+			if err != nil && err != io.EOF {
+				return n, err
+			}
+			if read < len(want) {
+				return n, io.ErrUnexpectedEOF
+			}
+		}
+		start = end
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Continue reading at the given offset
+// Must be called before reading
+func (r *logFileReader) seek(offset int64) error {
+	var start int64
+	for i, f := range r.files {
+		if offset < start+r.sizes[i] || i == len(r.files)-1 {
+			r.cur = i
+			_, err := f.Seek(offset-start, io.SeekStart)
+			return err
+		}
+		start += r.sizes[i]
+	}
+	return nil
+}
+
+func (r *logFileReader) Read(p []byte) (int, error) {
+	for {
+		if r.cur < len(r.files) {
+			n, err := r.files[r.cur].Read(p)
+			if n > 0 || (err != nil && err != io.EOF) {
+				return n, err
+			}
+			if r.cur < len(r.files)-1 {
+				r.cur++
+				continue
+			}
+		}
+
+		rotated, err := r.openRotated()
+		if err != nil {
+			return 0, err
+		}
+		if !rotated {
+			return 0, io.EOF
+		}
+	}
+}
+
+// Open the log file if the last file read was rotated away from it, or the log
+// file did not exist yet
+// The files that were read are closed
+func (r *logFileReader) openRotated() (bool, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if len(r.files) > 0 {
+		last, err := r.files[len(r.files)-1].Stat()
+		if err != nil {
+			return false, err
+		}
+		if os.SameFile(info, last) {
+			return false, nil
+		}
+	}
+
+	f, err := os.Open(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	r.Close()
+	r.files = []*os.File{f}
+	r.sizes = []int64{0}
+	r.cur = 0
+	return true, nil
+}
+
+func (r *logFileReader) Close() error {
+	var lastErr error
+	for _, f := range r.files {
+		if err := f.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// Path the container's log file is renamed to once it was rotated the given
+// number of times
+func (c *Container) rotatedLogPath(n uint) string {
+	return fmt.Sprintf("%s.%d", c.LogPath(), n)
+}
+
+// Whether the container's log file is rotated, rather than truncated by
+// conmon, once it reaches its maximum size
+func (c *Container) rotatesLog() bool {
+	return c.LogDriver() == KubernetesLogging && c.config.LogMaxSize > 0 && c.config.LogMaxFiles > 1
+}
+
+// Check that the container's log file will be rotated while the container runs
+// conmon truncates the log file once it reaches its maximum size, dropping its
+// contents, and only Supervise rotates it before that, so a container whose
+// log file is rotated cannot run without it
+func (c *Container) checkLogRotation() error {
+	if !c.rotatesLog() {
+		return nil
+	}
+	supervised, err := c.runtime.superviseRunning()
+	if err != nil {
+		return err
+	}
+	if !supervised {
+		return errors.Wrapf(ErrNotSupervised, "log file of container %s is rotated by podman supervise, which must be running to start it", c.ID())
+	}
+	return nil
+}
+
+// Whether the container's log file is rotated and is about to reach its
+// maximum size
+// It is rotated a little before, as conmon truncates it at its maximum size.
+func (c *Container) logNeedsRotation() (bool, error) {
+	if !c.rotatesLog() {
+		return false, nil
+	}
+	info, err := os.Stat(c.LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error reading log file of container %s", c.ID())
+	}
+	return info.Size() >= c.config.LogMaxSize*logRotationPercent/100, nil
+}
+
+// Rotate the container's log file if it has reached its maximum size
+// The log file gets the suffix .1, the suffixes of the files it was rotated to
+// before are incremented, and the oldest file is removed once there are more
+// files than are kept. If conmon is running, it is told to reopen the log file.
+// Must be called with the container locked and its state synced
+func (c *Container) rotateLog() error {
+	rotate, err := c.logNeedsRotation()
+	if err != nil || !rotate {
+		return err
+	}
+
+	oldest := c.rotatedLogPath(c.config.LogMaxFiles - 1)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing log file %s of container %s", oldest, c.ID())
+	}
+	for i := c.config.LogMaxFiles - 1; i > 1; i-- {
+		if err := os.Rename(c.rotatedLogPath(i-1), c.rotatedLogPath(i)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error rotating log file of container %s", c.ID())
+		}
+	}
+	if err := os.Rename(c.LogPath(), c.rotatedLogPath(1)); err != nil {
+		return errors.Wrapf(err, "error rotating log file of container %s", c.ID())
+	}
+	logrus.Debugf("Rotated log file of container %s", c.ID())
+
+	switch c.state.State {
+	case ContainerStateCreated, ContainerStateRunning, ContainerStatePaused:
+		return c.reopenLog()
+	}
+	return nil
+}
+
+// Tell conmon to reopen the container's log file after it was rotated
+func (c *Container) reopenLog() error {
+	controlFile, err := os.OpenFile(c.ControlSocketPath(), unix.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "error opening control file of container %s", c.ID())
+	}
+	defer controlFile.Close()
+
+	if _, err := fmt.Fprintf(controlFile, "%d %d %d\n", 2, 0, 0); err != nil {
+		return errors.Wrapf(err, "error telling conmon to reopen log file of container %s", c.ID())
+	}
+	return nil
+}
+
+// Rotate the log files of containers that have reached their maximum size
+func (r *Runtime) rotateLogs() {
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		logrus.Errorf("Error retrieving containers to rotate their logs: %v", err)
+		return
+	}
+
+	for _, ctr := range ctrs {
+		// The log file is checked before locking the container, so
+		// containers are only locked to be rotated
+		rotate, err := ctr.logNeedsRotation()
+		if err != nil {
+			logrus.Errorf("%v", err)
+			continue
+		}
+		if !rotate {
+			continue
+		}
+		if err := ctr.rotateLogLocked(); err != nil && errors.Cause(err) != ErrCtrRemoved {
+			logrus.Errorf("Error rotating log file of container %s: %v", ctr.ID(), err)
+		}
+	}
+}
+
+// Rotate the container's log file if needed
+// Must be called without the container locked
+func (c *Container) rotateLogLocked() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	return c.rotateLog()
+}
+
+// journaldLogReader reads the logs conmon sent to the journal for a container
+// Each read that finds no buffered logs queries the journal for the entries
+// recorded after the last one read
+type journaldLogReader struct {
+	ctrID  string
+	cursor string
+	buf    bytes.Buffer
+}
+
+// journalEntry is an entry of the journal, as printed by journalctl -o json
+type journalEntry struct {
+	Cursor            string          `json:"__CURSOR"`
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Priority          string          `json:"PRIORITY"`
+	Message           json.RawMessage `json:"MESSAGE"`
+	PartialMessage    string          `json:"CONTAINER_PARTIAL_MESSAGE"`
+}
+
+func (r *journaldLogReader) Read(p []byte) (int, error) {
+	if r.buf.Len() == 0 {
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+		if r.buf.Len() == 0 {
+			return 0, io.EOF
+		}
+	}
+	return r.buf.Read(p)
+}
+
+func (r *journaldLogReader) Close() error {
+	return nil
+}
+
+// Query the journal for new entries of the container, and buffer them
+func (r *journaldLogReader) fill() error {
+	args := []string{"--no-pager", "--output", "json", "CONTAINER_ID_FULL=" + r.ctrID}
+	if r.cursor != "" {
+		args = append(args, "--after-cursor", r.cursor)
+	}
+	output, err := exec.Command("journalctl", args...).Output()
+	if err != nil {
+		return errors.Wrapf(err, "error reading journal of container %s", r.ctrID)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		entry := new(journalEntry)
+		if err := json.Unmarshal(line, entry); err != nil {
+			logrus.Warnf("Skipping malformed journal entry of container %s: %v", r.ctrID, err)
+			continue
+		}
+		r.cursor = entry.Cursor
+		logLine, err := entry.toLogLine()
+		if err != nil {
+			logrus.Warnf("Skipping malformed journal entry of container %s: %v", r.ctrID, err)
+			continue
+		}
+		r.buf.WriteString(logLine)
+	}
+	return scanner.Err()
+}
+
+// Convert a journal entry into a line of the k8s-file log format
+func (e *journalEntry) toLogLine() (string, error) {
+	usec, err := strconv.ParseInt(e.RealtimeTimestamp, 10, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid timestamp %q", e.RealtimeTimestamp)
+	}
+
+	// journalctl prints messages that are not valid UTF-8 as arrays of
+	// bytes
+	var message string
+	if err := json.Unmarshal(e.Message, &message); err != nil {
+		var raw []byte
+		var ints []int
+		if err2 := json.Unmarshal(e.Message, &ints); err2 != nil {
+			return "", errors.Wrapf(err, "invalid message")
+		}
+		for _, i := range ints {
+			raw = append(raw, byte(i))
+		}
+		message = string(raw)
+	}
+
+	line := &logs.LogLine{
+		Device:       "stdout",
+		ParseLogType: logs.FullLogType,
+		Time:         time.Unix(0, usec*int64(time.Microsecond)),
+		Msg:          message,
+	}
+	if e.Priority == journalPriorityStderr {
+		line.Device = "stderr"
+	}
+	if e.PartialMessage == "true" {
+		line.ParseLogType = logs.PartialLogType
+	}
+
+	return line.String() + "\n", nil
+}
@@ -0,0 +1,253 @@
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/logs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestJournalEntryToLogLine(t *testing.T) {
+	when := time.Date(2018, 9, 20, 10, 41, 9, 123456000, time.Local)
+	usec := strconv.FormatInt(when.UnixNano()/int64(time.Microsecond), 10)
+
+	entry := new(journalEntry)
+	assert.NoError(t, json.Unmarshal([]byte(`{"__CURSOR":"c1","__REALTIME_TIMESTAMP":"`+usec+`","PRIORITY":"3","MESSAGE":"hello world"}`), entry))
+	assert.Equal(t, "c1", entry.Cursor)
+	line, err := entry.toLogLine()
+	assert.NoError(t, err)
+	assert.Equal(t, when.Format(logs.LogTimeFormat)+" stderr F hello world\n", line)
+
+	// Messages that are not valid UTF-8 are arrays of bytes, and partial
+	// lines are tagged with P
+	entry = &journalEntry{
+		RealtimeTimestamp: usec,
+		Priority:          journalPriorityStdout,
+		Message:           json.RawMessage(`[104,105,255]`),
+		PartialMessage:    "true",
+	}
+	line, err = entry.toLogLine()
+	assert.NoError(t, err)
+	assert.Equal(t, when.Format(logs.LogTimeFormat)+" stdout P hi\xff\n", line)
+
+	entry.RealtimeTimestamp = "bogus"
+	_, err = entry.toLogLine()
+	assert.Error(t, err)
+}
+
+// Make a container whose k8s-file log holds the given lines
+func getTestLogContainer(t *testing.T, dir, id string, lines []string) *Container {
+	logPath := filepath.Join(dir, id+".log")
+	assert.NoError(t, ioutil.WriteFile(logPath, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+	ctr := &Container{
+		config: &ContainerConfig{
+			ID:      id,
+			LogPath: logPath,
+		},
+		state:   &containerState{State: ContainerStateStopped},
+		batched: true,
+	}
+	return ctr
+}
+
+// Read all log lines of the given containers
+func readAllLogLines(t *testing.T, ctrs []*Container, options *logs.LogOptions) []*logs.LogLine {
+	runtime := &Runtime{valid: true}
+	logChannel := make(chan *logs.LogLine)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- runtime.Log(context.Background(), ctrs, options, logChannel)
+	}()
+
+	var read []*logs.LogLine
+	for line := range logChannel {
+		read = append(read, line)
+	}
+	assert.NoError(t, <-errChan)
+	return read
+}
+
+func TestRuntimeLog(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_log_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr1 := getTestLogContainer(t, tmpDir, "ctr1", []string{
+		"2018-09-20T10:41:01-05:00 stdout F a1",
+		"2018-09-20T10:41:03-05:00 stdout P a",
+		"2018-09-20T10:41:04-05:00 stderr F a3",
+		"2018-09-20T10:41:05-05:00 stdout F 2",
+	})
+	ctr2 := getTestLogContainer(t, tmpDir, "ctr2", []string{
+		"2018-09-20T10:41:02-05:00 stdout F b1",
+		"not a log line",
+		"2018-09-20T10:41:06-05:00 stdout F b2",
+	})
+
+	// Lines of both containers are interleaved, and partial lines are
+	// reassembled, keeping the time they were started
+	read := readAllLogLines(t, []*Container{ctr1, ctr2}, &logs.LogOptions{})
+	var msgs []string
+	for _, line := range read {
+		msgs = append(msgs, line.CID+":"+line.Msg)
+	}
+	assert.Equal(t, []string{"ctr1:a1", "ctr2:b1", "ctr1:a2", "ctr1:a3", "ctr2:b2"}, msgs)
+	assert.Equal(t, logs.FullLogType, read[2].ParseLogType)
+
+	read = readAllLogLines(t, []*Container{ctr1}, &logs.LogOptions{Tail: 2})
+	assert.Len(t, read, 2)
+	assert.Equal(t, "a2", read[0].Msg)
+	assert.Equal(t, "a3", read[1].Msg)
+
+	since, err := time.Parse(logs.LogTimeFormat, "2018-09-20T10:41:02-05:00")
+	assert.NoError(t, err)
+	until, err := time.Parse(logs.LogTimeFormat, "2018-09-20T10:41:04-05:00")
+	assert.NoError(t, err)
+	read = readAllLogLines(t, []*Container{ctr1, ctr2}, &logs.LogOptions{Since: since, Until: until})
+	assert.Len(t, read, 3)
+	assert.Equal(t, "b1", read[0].Msg)
+	assert.Equal(t, "a2", read[1].Msg)
+	assert.Equal(t, "a3", read[2].Msg)
+}
+
+func TestRotateLogAndReadRotatedFiles(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_log_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr := getTestLogContainer(t, tmpDir, "ctr", []string{
+		"2018-09-20T10:41:01-05:00 stdout F l1",
+		"2018-09-20T10:41:02-05:00 stdout F l2",
+	})
+	ctr.config.LogMaxSize = 100
+	ctr.config.LogMaxFiles = 3
+	writeLog := func(lines ...string) {
+		assert.NoError(t, ioutil.WriteFile(ctr.LogPath(), []byte(strings.Join(lines, "\n")+"\n"), 0600))
+	}
+	readMsgs := func(options *logs.LogOptions) []string {
+		var msgs []string
+		for _, line := range readAllLogLines(t, []*Container{ctr}, options) {
+			msgs = append(msgs, line.Msg)
+		}
+		return msgs
+	}
+
+	// The log file is only rotated once it reaches its maximum size
+	assert.NoError(t, ctr.rotateLog())
+	_, err = os.Stat(ctr.rotatedLogPath(1))
+	assert.True(t, os.IsNotExist(err))
+
+	ctr.config.LogMaxSize = 10
+	assert.NoError(t, ctr.rotateLog())
+	_, err = os.Stat(ctr.LogPath())
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []string{"l1", "l2"}, readMsgs(&logs.LogOptions{}))
+
+	writeLog("2018-09-20T10:41:03-05:00 stdout F l3")
+	assert.NoError(t, ctr.rotateLog())
+	writeLog("2018-09-20T10:41:04-05:00 stdout F l4", "2018-09-20T10:41:05-05:00 stdout F l5")
+	assert.Equal(t, []string{"l1", "l2", "l3", "l4", "l5"}, readMsgs(&logs.LogOptions{}))
+	assert.Equal(t, []string{"l2", "l3", "l4", "l5"}, readMsgs(&logs.LogOptions{Tail: 4}))
+
+	// Only the given number of log files is kept
+	assert.NoError(t, ctr.rotateLog())
+	_, err = os.Stat(ctr.rotatedLogPath(3))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []string{"l3", "l4", "l5"}, readMsgs(&logs.LogOptions{}))
+}
+
+func TestLogNeedsRotationBeforeMaximumSize(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_log_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr := getTestLogContainer(t, tmpDir, "ctr", nil)
+	ctr.config.LogMaxSize = 100
+	ctr.config.LogMaxFiles = 2
+
+	assert.NoError(t, ioutil.WriteFile(ctr.LogPath(), make([]byte, 89), 0600))
+	rotate, err := ctr.logNeedsRotation()
+	assert.NoError(t, err)
+	assert.False(t, rotate)
+
+	assert.NoError(t, ioutil.WriteFile(ctr.LogPath(), make([]byte, 90), 0600))
+	rotate, err = ctr.logNeedsRotation()
+	assert.NoError(t, err)
+	assert.True(t, rotate)
+}
+
+func TestCheckLogRotationRequiresSupervise(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_log_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr := getTestLogContainer(t, tmpDir, "ctr", nil)
+	ctr.runtime = &Runtime{config: &RuntimeConfig{TmpDir: tmpDir}}
+	ctr.config.LogMaxSize = 100
+
+	// With a single log file, conmon truncates it at its maximum size
+	// and nothing rotates it
+	ctr.config.LogMaxFiles = 1
+	assert.NoError(t, ioutil.WriteFile(ctr.LogPath(), make([]byte, 100), 0600))
+	rotate, err := ctr.logNeedsRotation()
+	assert.NoError(t, err)
+	assert.False(t, rotate)
+	assert.NoError(t, ctr.checkLogRotation())
+
+	// Otherwise the log file would be truncated, dropping its contents,
+	// unless Supervise rotates it first
+	ctr.config.LogMaxFiles = 2
+	assert.Equal(t, ErrNotSupervised, errors.Cause(ctr.checkLogRotation()))
+
+	// Hold the supervise lock as Supervise does
+	lockFile, err := os.OpenFile(ctr.runtime.superviseLockPath(), os.O_RDWR|os.O_CREATE, 0600)
+	assert.NoError(t, err)
+	defer lockFile.Close()
+	assert.NoError(t, unix.Flock(int(lockFile.Fd()), unix.LOCK_SH))
+	assert.NoError(t, ctr.checkLogRotation())
+
+	assert.NoError(t, unix.Flock(int(lockFile.Fd()), unix.LOCK_UN))
+	assert.Error(t, ctr.checkLogRotation())
+}
+
+func TestLogFileReaderFollowsRotation(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_log_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctr := getTestLogContainer(t, tmpDir, "ctr", []string{"a"})
+	ctr.config.LogMaxSize = 1
+	ctr.config.LogMaxFiles = 2
+	reader, err := ctr.openLogFiles()
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	read, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\n", string(read))
+
+	// Lines written to the log file before it is reopened are read before
+	// the new log file
+	f, err := os.OpenFile(ctr.LogPath(), os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, ctr.rotateLog())
+	_, err = f.WriteString("b\n")
+	assert.NoError(t, err)
+	f.Close()
+	assert.NoError(t, ioutil.WriteFile(ctr.LogPath(), []byte("c\n"), 0600))
+
+	read, err = ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "b\nc\n", string(read))
+}
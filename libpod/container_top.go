@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
@@ -115,3 +116,89 @@ func fieldsASCII(s string) []string {
 	}
 	return strings.FieldsFunc(s, fn)
 }
+
+// GetPodPidInformation calls ps with the appropriate options on the processes
+// of all running containers in the pod, and returns the results as a []string
+// Each process row is prefixed with the name of the container it belongs to,
+// under a CONTAINER header
+func (p *Pod) GetPodPidInformation(args []string) ([]string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the pids of all running containers to the container they belong
+	// to
+	var pids []string
+	pidOwners := make(map[string]string)
+	for _, ctr := range allCtrs {
+		ctr.lock.Lock()
+		if err := ctr.syncContainer(); err != nil {
+			ctr.lock.Unlock()
+			return nil, errors.Wrapf(err, "error updating container %s state", ctr.ID())
+		}
+		if ctr.state.State != ContainerStateRunning {
+			ctr.lock.Unlock()
+			continue
+		}
+		ctrPids, err := ctr.getContainerPids()
+		ctr.lock.Unlock()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to obtain pids for %s", ctr.ID())
+		}
+		for _, pid := range ctrPids {
+			pidOwners[pid] = ctr.Name()
+		}
+		pids = append(pids, ctrPids...)
+	}
+	if len(pids) == 0 {
+		return nil, errors.Wrapf(ErrCtrStateInvalid, "no containers in pod %s are running", p.ID())
+	}
+
+	args = append(args, "-p", strings.Join(pids, ","))
+	logrus.Debug("Executing: ", strings.Join(args, " "))
+	results, err := utils.ExecCmd("ps", args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to obtain information about pids")
+	}
+
+	filteredOutput, err := filterPids(results, pids)
+	if err != nil {
+		return nil, err
+	}
+
+	return prefixPidOwners(filteredOutput, pidOwners), nil
+}
+
+// Prefix each row of filtered ps output with the container owning its pid
+// filterPids ensures the header is the first row and contains a PID field
+func prefixPidOwners(psOutput []string, pidOwners map[string]string) []string {
+	header := "CONTAINER"
+	width := len(header)
+	for _, owner := range pidOwners {
+		if len(owner) > width {
+			width = len(owner)
+		}
+	}
+
+	pidIndex := 0
+	for i, field := range fieldsASCII(psOutput[0]) {
+		if field == "PID" {
+			pidIndex = i
+		}
+	}
+
+	output := []string{fmt.Sprintf("%-*s %s", width, header, psOutput[0])}
+	for _, line := range psOutput[1:] {
+		owner := pidOwners[fieldsASCII(line)[pidIndex]]
+		output = append(output, fmt.Sprintf("%-*s %s", width, owner, line))
+	}
+	return output
+}
@@ -0,0 +1,25 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixPidOwners(t *testing.T) {
+	psOutput := []string{
+		"  PID COMMAND",
+		"    1 pause",
+		"   12 top",
+	}
+	owners := map[string]string{
+		"1":  "0123456789ab-infra",
+		"12": "top",
+	}
+	expected := []string{
+		"CONTAINER            PID COMMAND",
+		"0123456789ab-infra     1 pause",
+		"top                   12 top",
+	}
+	assert.Equal(t, expected, prefixPidOwners(psOutput, owners))
+}
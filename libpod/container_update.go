@@ -0,0 +1,183 @@
+package libpod
+
+import (
+	"encoding/json"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Update changes the resource limits of the container
+// The CPU shares, quota, period and cpuset, the memory limit, reservation
+// and swap limit, the blkio weight and the pids limit can be changed; any
+// other resources given are ignored, as are limits that are not set.
+// Created, running and paused containers are updated live through the OCI
+// runtime. The new limits are saved in the container's spec, so they also
+// apply whenever the container is started again.
+func (c *Container) Update(resources *spec.LinuxResources) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.update(resources)
+}
+
+// Update the resource limits of a container
+// Must be called with the container locked
+func (c *Container) update(resources *spec.LinuxResources) error {
+	if resources == nil {
+		return errors.Wrapf(ErrInvalidArg, "must provide resources to update")
+	}
+	update := updatableResources(resources)
+
+	newSpec, err := copySpec(c.config.Spec)
+	if err != nil {
+		return errors.Wrapf(err, "error copying spec of container %s", c.ID())
+	}
+	if newSpec.Linux == nil {
+		newSpec.Linux = new(spec.Linux)
+	}
+	if newSpec.Linux.Resources == nil {
+		newSpec.Linux.Resources = new(spec.LinuxResources)
+	}
+	mergeResources(newSpec.Linux.Resources, update)
+	if err := validateMemoryResources(newSpec.Linux.Resources.Memory); err != nil {
+		return err
+	}
+
+	switch c.state.State {
+	case ContainerStateCreated, ContainerStateRunning, ContainerStatePaused:
+		if err := c.runtime.ociRuntime.updateContainer(c, update); err != nil {
+			return err
+		}
+	case ContainerStateConfigured, ContainerStateStopped:
+		// The new limits apply when the container is next started
+	default:
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s is in an invalid state and cannot be updated", c.ID())
+	}
+
+	newConfig := new(ContainerConfig)
+	*newConfig = *c.config
+	newConfig.Spec = newSpec
+	if err := c.runtime.state.RewriteContainerConfig(c, newConfig); err != nil {
+		return errors.Wrapf(err, "error saving updated resources of container %s", c.ID())
+	}
+
+	logrus.Debugf("Updated resources of container %s", c.ID())
+
+	return nil
+}
+
+// Get the resources that can be updated from the given resources
+func updatableResources(resources *spec.LinuxResources) *spec.LinuxResources {
+	update := new(spec.LinuxResources)
+	if resources.CPU != nil {
+		update.CPU = &spec.LinuxCPU{
+			Shares: resources.CPU.Shares,
+			Quota:  resources.CPU.Quota,
+			Period: resources.CPU.Period,
+			Cpus:   resources.CPU.Cpus,
+			Mems:   resources.CPU.Mems,
+		}
+	}
+	if resources.Memory != nil {
+		update.Memory = &spec.LinuxMemory{
+			Limit:       resources.Memory.Limit,
+			Reservation: resources.Memory.Reservation,
+			Swap:        resources.Memory.Swap,
+		}
+	}
+	if resources.BlockIO != nil && resources.BlockIO.Weight != nil {
+		update.BlockIO = &spec.LinuxBlockIO{
+			Weight: resources.BlockIO.Weight,
+		}
+	}
+	if resources.Pids != nil {
+		update.Pids = &spec.LinuxPids{
+			Limit: resources.Pids.Limit,
+		}
+	}
+	return update
+}
+
+// Apply the limits set in update to the given resources
+func mergeResources(resources, update *spec.LinuxResources) {
+	if update.CPU != nil {
+		if resources.CPU == nil {
+			resources.CPU = new(spec.LinuxCPU)
+		}
+		if update.CPU.Shares != nil {
+			resources.CPU.Shares = update.CPU.Shares
+		}
+		if update.CPU.Quota != nil {
+			resources.CPU.Quota = update.CPU.Quota
+		}
+		if update.CPU.Period != nil {
+			resources.CPU.Period = update.CPU.Period
+		}
+		if update.CPU.Cpus != "" {
+			resources.CPU.Cpus = update.CPU.Cpus
+		}
+		if update.CPU.Mems != "" {
+			resources.CPU.Mems = update.CPU.Mems
+		}
+	}
+	if update.Memory != nil {
+		if resources.Memory == nil {
+			resources.Memory = new(spec.LinuxMemory)
+		}
+		if update.Memory.Limit != nil {
+			resources.Memory.Limit = update.Memory.Limit
+		}
+		if update.Memory.Reservation != nil {
+			resources.Memory.Reservation = update.Memory.Reservation
+		}
+		if update.Memory.Swap != nil {
+			resources.Memory.Swap = update.Memory.Swap
+		}
+	}
+	if update.BlockIO != nil {
+		if resources.BlockIO == nil {
+			resources.BlockIO = new(spec.LinuxBlockIO)
+		}
+		resources.BlockIO.Weight = update.BlockIO.Weight
+	}
+	if update.Pids != nil {
+		resources.Pids = &spec.LinuxPids{
+			Limit: update.Pids.Limit,
+		}
+	}
+}
+
+// Check that the memory limits of a container are consistent with each other
+func validateMemoryResources(memory *spec.LinuxMemory) error {
+	if memory == nil || memory.Limit == nil || *memory.Limit <= 0 {
+		return nil
+	}
+	if memory.Reservation != nil && *memory.Reservation > *memory.Limit {
+		return errors.Wrapf(ErrInvalidArg, "memory reservation %d must not be larger than memory limit %d", *memory.Reservation, *memory.Limit)
+	}
+	if memory.Swap != nil && *memory.Swap > 0 && *memory.Swap < *memory.Limit {
+		return errors.Wrapf(ErrInvalidArg, "memory+swap limit %d must not be smaller than memory limit %d", *memory.Swap, *memory.Limit)
+	}
+	return nil
+}
+
+// Make a deep copy of a spec
+func copySpec(s *spec.Spec) (*spec.Spec, error) {
+	specJSON, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	newSpec := new(spec.Spec)
+	if err := json.Unmarshal(specJSON, newSpec); err != nil {
+		return nil, err
+	}
+	return newSpec, nil
+}
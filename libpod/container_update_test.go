@@ -0,0 +1,95 @@
+package libpod
+
+import (
+	"testing"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatableResources(t *testing.T) {
+	shares := uint64(512)
+	rtRuntime := int64(1000)
+	limit := int64(64 * 1024 * 1024)
+	kernel := int64(32 * 1024 * 1024)
+	weight := uint16(500)
+	resources := &spec.LinuxResources{
+		CPU: &spec.LinuxCPU{
+			Shares:          &shares,
+			RealtimeRuntime: &rtRuntime,
+		},
+		Memory: &spec.LinuxMemory{
+			Limit:  &limit,
+			Kernel: &kernel,
+		},
+		BlockIO: &spec.LinuxBlockIO{
+			Weight:       &weight,
+			WeightDevice: []spec.LinuxWeightDevice{{Weight: &weight}},
+		},
+		Devices: []spec.LinuxDeviceCgroup{{Allow: true}},
+	}
+
+	update := updatableResources(resources)
+	assert.Equal(t, &shares, update.CPU.Shares)
+	assert.Nil(t, update.CPU.RealtimeRuntime)
+	assert.Equal(t, &limit, update.Memory.Limit)
+	assert.Nil(t, update.Memory.Kernel)
+	assert.Equal(t, &weight, update.BlockIO.Weight)
+	assert.Empty(t, update.BlockIO.WeightDevice)
+	assert.Empty(t, update.Devices)
+	assert.Nil(t, update.Pids)
+}
+
+func TestMergeResources(t *testing.T) {
+	oldShares := uint64(1024)
+	quota := int64(50000)
+	oldLimit := int64(64 * 1024 * 1024)
+	swap := int64(128 * 1024 * 1024)
+	resources := &spec.LinuxResources{
+		CPU: &spec.LinuxCPU{
+			Shares: &oldShares,
+			Quota:  &quota,
+			Cpus:   "0",
+		},
+		Memory: &spec.LinuxMemory{
+			Limit: &oldLimit,
+			Swap:  &swap,
+		},
+	}
+
+	newShares := uint64(512)
+	newLimit := int64(96 * 1024 * 1024)
+	update := &spec.LinuxResources{
+		CPU: &spec.LinuxCPU{
+			Shares: &newShares,
+		},
+		Memory: &spec.LinuxMemory{
+			Limit: &newLimit,
+		},
+		Pids: &spec.LinuxPids{
+			Limit: 100,
+		},
+	}
+
+	mergeResources(resources, update)
+	assert.Equal(t, newShares, *resources.CPU.Shares)
+	assert.Equal(t, quota, *resources.CPU.Quota)
+	assert.Equal(t, "0", resources.CPU.Cpus)
+	assert.Equal(t, newLimit, *resources.Memory.Limit)
+	assert.Equal(t, swap, *resources.Memory.Swap)
+	assert.Equal(t, int64(100), resources.Pids.Limit)
+	assert.Nil(t, resources.BlockIO)
+}
+
+func TestValidateMemoryResources(t *testing.T) {
+	limit := int64(64 * 1024 * 1024)
+	smaller := int64(32 * 1024 * 1024)
+	larger := int64(128 * 1024 * 1024)
+	unlimited := int64(-1)
+
+	assert.NoError(t, validateMemoryResources(nil))
+	assert.NoError(t, validateMemoryResources(&spec.LinuxMemory{Limit: &limit, Reservation: &smaller, Swap: &larger}))
+	assert.NoError(t, validateMemoryResources(&spec.LinuxMemory{Limit: &limit, Swap: &unlimited}))
+	assert.Error(t, validateMemoryResources(&spec.LinuxMemory{Limit: &limit, Reservation: &larger}))
+	assert.Error(t, validateMemoryResources(&spec.LinuxMemory{Limit: &limit, Swap: &smaller}))
+}
@@ -11,6 +11,13 @@ var (
 	ErrNoSuchPod = errors.New("no such pod")
 	// ErrNoSuchImage indicates the requested image does not exist
 	ErrNoSuchImage = errors.New("no such image")
+	// ErrNoSuchExecSession indicates the requested exec session does not
+	// exist
+	ErrNoSuchExecSession = errors.New("no such exec session")
+	// ErrNoSuchVolume indicates the requested volume does not exist
+	ErrNoSuchVolume = errors.New("no such volume")
+	// ErrNoSuchNetwork indicates the requested network does not exist
+	ErrNoSuchNetwork = errors.New("no such network")
 
 	// ErrCtrExists indicates a container with the same name or ID already
 	// exists
@@ -19,11 +26,30 @@ var (
 	ErrPodExists = errors.New("pod already exists")
 	// ErrImageExists indicated an image with the same ID already exists
 	ErrImageExists = errors.New("image already exists")
+	// ErrVolumeExists indicates a volume with the same name already exists
+	ErrVolumeExists = errors.New("volume already exists")
+	// ErrNetworkExists indicates a network with the same name already
+	// exists
+	ErrNetworkExists = errors.New("network already exists")
+
+	// ErrVolumeBeingUsed indicates that a volume is being used by at least
+	// one container
+	ErrVolumeBeingUsed = errors.New("volume is being used")
+	// ErrNetworkBeingUsed indicates that a network is being used by at
+	// least one container
+	ErrNetworkBeingUsed = errors.New("network is being used")
 
 	// ErrCtrStateInvalid indicates a container is in an improper state for
 	// the requested operation
 	ErrCtrStateInvalid = errors.New("container state improper")
 
+	// ErrNotSupervised indicates that an operation requires podman
+	// supervise to be running, and it is not
+	ErrNotSupervised = errors.New("containers are not supervised")
+	// ErrCtrNoHealthCheck indicates that a healthcheck was requested for a
+	// container that does not have one
+	ErrCtrNoHealthCheck = errors.New("container has no healthcheck")
+
 	// ErrRuntimeFinalized indicates that the runtime has already been
 	// created and cannot be modified
 	ErrRuntimeFinalized = errors.New("runtime has been finalized")
@@ -33,6 +59,9 @@ var (
 	// ErrPodFinalized indicates that the pod has already been created and
 	// cannot be modified
 	ErrPodFinalized = errors.New("pod has been finalized")
+	// ErrVolumeFinalized indicates that the volume has already been created
+	// and cannot be modified
+	ErrVolumeFinalized = errors.New("volume has been finalized")
 
 	// ErrInvalidArg indicates that an invalid argument was passed
 	ErrInvalidArg = errors.New("invalid argument")
@@ -55,6 +84,9 @@ var (
 	// ErrPodRemoved indicates that the pod has already been removed and no
 	// further operations can be performed on it
 	ErrPodRemoved = errors.New("pod has already been removed")
+	// ErrVolumeRemoved indicates that the volume has already been removed
+	// and no further operations can be performed on it
+	ErrVolumeRemoved = errors.New("volume has already been removed")
 
 	// ErrDBClosed indicates that the connection to the state database has
 	// already been closed
@@ -63,6 +95,18 @@ var (
 	// was created by a libpod with a different config
 	ErrDBBadConfig = errors.New("database configuration mismatch")
 
+	// ErrNoLogs indicates that the logs of a container were requested,
+	// but its log driver does not record them
+	ErrNoLogs = errors.New("container does not record logs")
+
+	// ErrPortInUse indicates that a host port requested for a container is
+	// already used by another container or by a process on the host
+	ErrPortInUse = errors.New("host port is already in use")
+
+	// ErrNetworkUnsupported indicates that the network plugins do not
+	// support the network configuration requested for a container
+	ErrNetworkUnsupported = errors.New("network configuration not supported by CNI plugins")
+
 	// ErrNotImplemented indicates that the requested functionality is not
 	// yet present
 	ErrNotImplemented = errors.New("not yet implemented")
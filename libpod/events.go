@@ -0,0 +1,116 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/projectatomic/libpod/libpod/events"
+	"github.com/sirupsen/logrus"
+)
+
+// Events reads events from the event log, sending those matching the given
+// options to their event channel
+// The event log holds the events of all libpod processes using the same
+// configuration. With Follow set, Events waits for new events until the
+// context is cancelled.
+func (r *Runtime) Events(ctx context.Context, options events.ReadOptions) error {
+	r.lock.RLock()
+	if !r.valid {
+		r.lock.RUnlock()
+		close(options.EventChannel)
+		return ErrRuntimeStopped
+	}
+	eventer := r.eventer
+	r.lock.RUnlock()
+
+	return eventer.Read(ctx, options)
+}
+
+// SubscribeEvents returns a channel that receives the events that happen in
+// this process from now on, and a function that ends the subscription
+// Events of other libpod processes are not received; use Events to follow
+// the event log for those.
+func (r *Runtime) SubscribeEvents() (<-chan events.Event, func(), error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, nil, ErrRuntimeStopped
+	}
+
+	ch, cancel := r.eventer.Subscribe()
+	return ch, cancel, nil
+}
+
+// Record an event
+// Failing to record an event does not fail the operation it is for, so errors
+// are only logged
+func (r *Runtime) writeEvent(event events.Event) {
+	if r.eventer == nil {
+		return
+	}
+	if err := r.eventer.Write(event); err != nil {
+		logrus.Errorf("Unable to record %s %s event: %v", event.Type, event.Status, err)
+	}
+}
+
+// Create an event for the container
+func (c *Container) newEvent(status events.Status) events.Event {
+	e := events.NewEvent(events.Container, status)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	if c.config.Pod != "" {
+		e.Attributes = map[string]string{
+			"pod": c.config.Pod,
+		}
+	}
+	return e
+}
+
+// Record an event for the container
+func (c *Container) newContainerEvent(status events.Status) {
+	c.runtime.writeEvent(c.newEvent(status))
+}
+
+// Record that the container's process exited with the given exit code
+func (c *Container) newContainerExitedEvent(exitCode int32) {
+	e := c.newEvent(events.Died)
+	e.ContainerExitCode = int(exitCode)
+	c.runtime.writeEvent(e)
+}
+
+// Record that the container was sent a signal
+func (c *Container) newKillEvent(signal uint) {
+	e := c.newEvent(events.Kill)
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string)
+	}
+	e.Attributes["signal"] = fmt.Sprintf("%d", signal)
+	c.runtime.writeEvent(e)
+}
+
+// Record that an exec session was started in the container
+func (c *Container) newExecEvent(session *ExecSession) {
+	e := c.newEvent(events.Exec)
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string)
+	}
+	e.Attributes["execID"] = session.ID
+	c.runtime.writeEvent(e)
+}
+
+// Record an event for the volume
+func (v *Volume) newVolumeEvent(status events.Status) {
+	e := events.NewEvent(events.Volume, status)
+	e.Name = v.Name()
+	v.runtime.writeEvent(e)
+}
+
+// Record an event for the pod
+func (p *Pod) newPodEvent(status events.Status) {
+	e := events.NewEvent(events.Pod, status)
+	e.ID = p.ID()
+	e.Name = p.Name()
+	p.runtime.writeEvent(e)
+}
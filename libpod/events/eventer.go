@@ -0,0 +1,327 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// followPollInterval is how often the event log is checked for new
+	// events when following it
+	followPollInterval = 250 * time.Millisecond
+	// subscriberBuffer is the number of events that are buffered for a
+	// subscriber before further events are dropped
+	subscriberBuffer = 100
+	// DefaultMaxLogSize is the size, in bytes, the event log may reach
+	// before it is rotated
+	DefaultMaxLogSize = 1024 * 1024
+)
+
+// Eventer records events in an event log file, from which they can be read
+// by any process, and passes them on to subscribers in the same process
+// Once the event log reaches its maximum size, it is rotated to a file with
+// the suffix .1, replacing the events previously rotated there.
+type Eventer struct {
+	logPath     string
+	maxSize     int64
+	lock        sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// ReadOptions are the options for reading events from the event log
+type ReadOptions struct {
+	// Filters are applied to each event; only events that all filters
+	// match are read
+	Filters []Filter
+	// Since, if set, skips events that happened before it
+	Since time.Time
+	// Until, if set, skips events that happened after it, and stops
+	// following the event log once it has passed
+	Until time.Time
+	// Follow waits for new events once all events in the log have been
+	// read, until the context is cancelled or Until has passed
+	Follow bool
+	// EventChannel receives the events that are read
+	// It is closed once reading is done
+	EventChannel chan *Event
+}
+
+// NewEventer creates an Eventer that records events in the given file, which
+// is rotated once it reaches maxSize bytes
+// If maxSize is not positive, the event log is never rotated.
+func NewEventer(logPath string, maxSize int64) *Eventer {
+	return &Eventer{
+		logPath:     logPath,
+		maxSize:     maxSize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// LogPath returns the path of the event log
+func (e *Eventer) LogPath() string {
+	return e.logPath
+}
+
+// Path the event log is rotated to
+func (e *Eventer) rotatedLogPath() string {
+	return e.logPath + ".1"
+}
+
+// Write records an event in the event log and passes it to all subscribers
+func (e *Eventer) Write(event Event) error {
+	e.publish(event)
+
+	line, err := event.ToJSONString()
+	if err != nil {
+		return errors.Wrapf(err, "error encoding %s %s event", event.Type, event.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.logPath), 0700); err != nil {
+		return errors.Wrapf(err, "error creating directory for event log %s", e.logPath)
+	}
+	f, err := os.OpenFile(e.logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error opening event log %s", e.logPath)
+	}
+	defer f.Close()
+
+	// Write the event in a single append, so events written by several
+	// processes at once are not interleaved
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		return errors.Wrapf(err, "error writing to event log %s", e.logPath)
+	}
+	return e.rotate(f)
+}
+
+// Rotate the event log if it has reached its maximum size
+// f is the event log as it was opened to write an event. It is locked while
+// rotating, so of several processes writing to it only one rotates it.
+func (e *Eventer) rotate(f *os.File) error {
+	if e.maxSize <= 0 {
+		return nil
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "error getting size of event log %s", e.logPath)
+	}
+	if info.Size() < e.maxSize {
+		return nil
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		return errors.Wrapf(err, "error locking event log %s", e.logPath)
+	}
+	defer unix.Flock(int(f.Fd()), unix.LOCK_UN)
+
+	// Another process may have rotated it while we waited for the lock
+	current, err := os.Stat(e.logPath)
+	if err != nil || !os.SameFile(info, current) {
+		return nil
+	}
+	if err := os.Rename(e.logPath, e.rotatedLogPath()); err != nil {
+		return errors.Wrapf(err, "error rotating event log %s", e.logPath)
+	}
+	logrus.Debugf("Rotated event log %s", e.logPath)
+	return nil
+}
+
+// Determine whether the event log was rotated away from an open file, which
+// then receives no further events
+func (e *Eventer) rotatedFrom(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(e.logPath)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	return !os.SameFile(info, current)
+}
+
+// Subscribe returns a channel that receives all events written by this
+// Eventer from now on, and a function that ends the subscription
+// Events written by other processes are not received; follow the event log
+// with Read to see those. If the subscriber falls too far behind, further
+// events are dropped for it until it catches up.
+func (e *Eventer) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	e.lock.Lock()
+	e.subscribers[ch] = struct{}{}
+	e.lock.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			e.lock.Lock()
+			delete(e.subscribers, ch)
+			e.lock.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Pass an event to all subscribers without blocking
+func (e *Eventer) publish(event Event) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for ch := range e.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logrus.Warnf("Dropping %s %s event for a subscriber that is not keeping up", event.Type, event.Status)
+		}
+	}
+}
+
+// Read reads events from the event log and sends those matching the given
+// options to their event channel, which is closed when Read returns
+// Events the event log was last rotated away from are read first. Without
+// Follow, Read returns once the end of the event log is reached.
+func (e *Eventer) Read(ctx context.Context, options ReadOptions) error {
+	defer close(options.EventChannel)
+
+	rotated, err := os.Open(e.rotatedLogPath())
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error opening event log %s", e.rotatedLogPath())
+	}
+	if err == nil {
+		done, err := e.readEvents(ctx, rotated, options)
+		rotated.Close()
+		if err != nil || done {
+			return err
+		}
+	}
+
+	for {
+		f, err := e.openLog(ctx, options)
+		if err != nil || f == nil {
+			return err
+		}
+		done, err := e.readEvents(ctx, f, options)
+		f.Close()
+		if err != nil || done {
+			return err
+		}
+		// The event log was rotated, carry on with the new one
+	}
+}
+
+// Read the events in an open event log file and send those matching the given
+// options to their event channel
+// Once the end of the file is reached, events are waited for if following
+// and the event log was not rotated away from the file.
+// Returns whether reading is done, rather than to continue in the file the
+// event log was rotated to
+func (e *Eventer) readEvents(ctx context.Context, f *os.File, options ReadOptions) (bool, error) {
+	reader := bufio.NewReader(f)
+	partial := ""
+	rotated := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				return true, errors.Wrapf(err, "error reading event log %s", f.Name())
+			}
+			// Keep a partly written event until the rest of it
+			// arrives
+			partial += line
+			if rotated {
+				return false, nil
+			}
+			if e.rotatedFrom(f) {
+				// Read to the end once more, as events may have
+				// been written just before the rotation
+				rotated = true
+				continue
+			}
+			if !e.waitForEvents(ctx, options) {
+				return true, nil
+			}
+			continue
+		}
+		line = strings.TrimSpace(partial + line)
+		partial = ""
+		if line == "" {
+			continue
+		}
+
+		event, err := newEventFromJSONString(line)
+		if err != nil {
+			logrus.Warnf("Skipping malformed event in event log %s: %v", f.Name(), err)
+			continue
+		}
+		if !options.matches(event) {
+			continue
+		}
+		select {
+		case options.EventChannel <- event:
+		case <-ctx.Done():
+			return true, nil
+		}
+	}
+}
+
+// Open the event log for reading
+// When following, wait for the event log to be created if it does not exist
+// yet; otherwise a missing event log holds no events, and nil is returned.
+func (e *Eventer) openLog(ctx context.Context, options ReadOptions) (*os.File, error) {
+	for {
+		f, err := os.Open(e.logPath)
+		if err == nil {
+			return f, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "error opening event log %s", e.logPath)
+		}
+		if !e.waitForEvents(ctx, options) {
+			return nil, nil
+		}
+	}
+}
+
+// Wait for more events to be written when following the event log
+// Returns false if reading should stop instead
+func (e *Eventer) waitForEvents(ctx context.Context, options ReadOptions) bool {
+	if !options.Follow {
+		return false
+	}
+	if !options.Until.IsZero() && time.Now().After(options.Until) {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(followPollInterval):
+		return true
+	}
+}
+
+// Determine whether an event matches the read options
+func (options *ReadOptions) matches(event *Event) bool {
+	if !options.Since.IsZero() && event.Time.Before(options.Since) {
+		return false
+	}
+	if !options.Until.IsZero() && event.Time.After(options.Until) {
+		return false
+	}
+	for _, filter := range options.Filters {
+		if !filter(event) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Type is the type of object an event happened to
+type Type string
+
+// Status is the action that took place in an event
+type Status string
+
+const (
+	// Container is the type of events that happen to containers
+	Container Type = "container"
+	// Pod is the type of events that happen to pods
+	Pod Type = "pod"
+	// Image is the type of events that happen to images
+	Image Type = "image"
+	// Volume is the type of events that happen to volumes
+	Volume Type = "volume"
+
+	// Create is the status of a container, pod or volume being created
+	Create Status = "create"
+	// Init is the status of a container being created in the OCI runtime
+	Init Status = "init"
+	// Start is the status of a container being started
+	Start Status = "start"
+	// Stop is the status of a container being stopped
+	Stop Status = "stop"
+	// Died is the status of a container's process exiting
+	Died Status = "die"
+	// OOM is the status of a container being killed as it ran out of
+	// memory
+	OOM Status = "oom"
+	// Pause is the status of a container being paused
+	Pause Status = "pause"
+	// Unpause is the status of a container being unpaused
+	Unpause Status = "unpause"
+	// Kill is the status of a container being sent a signal
+	Kill Status = "kill"
+	// Remove is the status of a container, pod, image or volume being
+	// removed
+	Remove Status = "remove"
+	// Exec is the status of a command being executed in a container
+	Exec Status = "exec"
+	// Pull is the status of an image being pulled
+	Pull Status = "pull"
+	// Tag is the status of an image being tagged
+	Tag Status = "tag"
+	// Untag is the status of a tag being removed from an image
+	Untag Status = "untag"
+)
+
+// humanTimeFormat is the format of event times in human readable events
+const humanTimeFormat = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// Event is something that happened to a container, pod, image or volume
+type Event struct {
+	// ID is the ID of the container, pod or image
+	ID string `json:"id,omitempty"`
+	// Name is the name of the container, pod or volume, or the name the
+	// image was pulled or tagged with
+	Name string `json:"name,omitempty"`
+	// Image is the image a container was created from
+	Image string `json:"image,omitempty"`
+	// Type is the type of object the event happened to
+	Type Type `json:"type"`
+	// Status is the action that took place
+	Status Status `json:"status"`
+	// Time is when the event happened
+	Time time.Time `json:"time"`
+	// ContainerExitCode is the exit code of a container that died
+	ContainerExitCode int `json:"containerExitCode,omitempty"`
+	// Attributes are further details of the event, such as the pod a
+	// container is part of, the signal it was sent or the exec session
+	// that was started
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Filter is a function to determine whether an event is included in the
+// events read from the event log
+type Filter func(*Event) bool
+
+// NewEvent creates an event of the given type and status at the current time
+func NewEvent(eventType Type, status Status) Event {
+	return Event{
+		Type:   eventType,
+		Status: status,
+		Time:   time.Now(),
+	}
+}
+
+// ToJSONString returns the event as a single line of JSON
+func (e *Event) ToJSONString() (string, error) {
+	b, err := json.Marshal(e)
+	return string(b), err
+}
+
+// ToHumanReadable returns the event in the format printed by podman events
+func (e *Event) ToHumanReadable() string {
+	var details []string
+	if e.Image != "" {
+		details = append(details, "image="+e.Image)
+	}
+	if e.Name != "" {
+		details = append(details, "name="+e.Name)
+	}
+	if e.Status == Died {
+		details = append(details, fmt.Sprintf("exitCode=%d", e.ContainerExitCode))
+	}
+	keys := make([]string, 0, len(e.Attributes))
+	for key := range e.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		details = append(details, key+"="+e.Attributes[key])
+	}
+
+	output := fmt.Sprintf("%s %s %s %s", e.Time.Format(humanTimeFormat), e.Type, e.Status, e.ID)
+	if len(details) > 0 {
+		output += " (" + strings.Join(details, ", ") + ")"
+	}
+	return output
+}
+
+// newEventFromJSONString parses an event from a line of the event log
+func newEventFromJSONString(event string) (*Event, error) {
+	e := new(Event)
+	if err := json.Unmarshal([]byte(event), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// StringToType converts a string to an event type
+func StringToType(name string) (Type, error) {
+	switch Type(name) {
+	case Container, Pod, Image, Volume:
+		return Type(name), nil
+	}
+	return "", errors.Errorf("unknown event type %q", name)
+}
+
+// StringToStatus converts a string to an event status
+func StringToStatus(name string) (Status, error) {
+	switch Status(name) {
+	case Create, Init, Start, Stop, Died, OOM, Pause, Unpause, Kill, Remove, Exec, Pull, Tag, Untag:
+		return Status(name), nil
+	}
+	return "", errors.Errorf("unknown event status %q", name)
+}
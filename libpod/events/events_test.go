@@ -0,0 +1,246 @@
+package events
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Make an Eventer writing to a log in a temporary directory
+func getTestEventer(t *testing.T) (*Eventer, func()) {
+	tmpDir, err := ioutil.TempDir("", "libpod_events_test")
+	assert.NoError(t, err)
+
+	eventer := NewEventer(filepath.Join(tmpDir, "events", "events.log"), DefaultMaxLogSize)
+	return eventer, func() { os.RemoveAll(tmpDir) }
+}
+
+// Read all events in the log with the given options
+func readAllEvents(t *testing.T, eventer *Eventer, options ReadOptions) []*Event {
+	options.EventChannel = make(chan *Event)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- eventer.Read(context.Background(), options)
+	}()
+
+	var read []*Event
+	for event := range options.EventChannel {
+		read = append(read, event)
+	}
+	assert.NoError(t, <-errChan)
+	return read
+}
+
+func newTestEvent(eventType Type, status Status, id string, when time.Time) Event {
+	e := NewEvent(eventType, status)
+	e.ID = id
+	e.Time = when
+	return e
+}
+
+func TestReadMissingLog(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	assert.Empty(t, readAllEvents(t, eventer, ReadOptions{}))
+}
+
+func TestWriteAndRead(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	died := NewEvent(Container, Died)
+	died.ID = "ctr"
+	died.Name = "test"
+	died.ContainerExitCode = 3
+	died.Attributes = map[string]string{"pod": "pod1"}
+	assert.NoError(t, eventer.Write(died))
+	assert.NoError(t, eventer.Write(NewEvent(Image, Pull)))
+
+	read := readAllEvents(t, eventer, ReadOptions{})
+	assert.Len(t, read, 2)
+	assert.Equal(t, "ctr", read[0].ID)
+	assert.Equal(t, "test", read[0].Name)
+	assert.Equal(t, Container, read[0].Type)
+	assert.Equal(t, Died, read[0].Status)
+	assert.Equal(t, 3, read[0].ContainerExitCode)
+	assert.Equal(t, "pod1", read[0].Attributes["pod"])
+	assert.True(t, read[0].Time.Equal(died.Time))
+	assert.Equal(t, Pull, read[1].Status)
+}
+
+func TestReadSkipsMalformedEvents(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	assert.NoError(t, eventer.Write(NewEvent(Container, Start)))
+	f, err := os.OpenFile(eventer.LogPath(), os.O_WRONLY|os.O_APPEND, 0600)
+	assert.NoError(t, err)
+	_, err = f.WriteString("not an event\n\n")
+	assert.NoError(t, err)
+	f.Close()
+	assert.NoError(t, eventer.Write(NewEvent(Container, Stop)))
+
+	read := readAllEvents(t, eventer, ReadOptions{})
+	assert.Len(t, read, 2)
+}
+
+func TestReadSinceUntilAndFilters(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	start := time.Now().Add(-time.Hour)
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Create, "a", start)))
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "a", start.Add(time.Minute))))
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "b", start.Add(2*time.Minute))))
+	assert.NoError(t, eventer.Write(newTestEvent(Pod, Create, "c", start.Add(3*time.Minute))))
+
+	read := readAllEvents(t, eventer, ReadOptions{
+		Since: start.Add(30 * time.Second),
+		Until: start.Add(150 * time.Second),
+	})
+	assert.Len(t, read, 2)
+	assert.Equal(t, "a", read[0].ID)
+	assert.Equal(t, "b", read[1].ID)
+
+	read = readAllEvents(t, eventer, ReadOptions{
+		Filters: []Filter{
+			func(e *Event) bool { return e.Type == Container },
+			func(e *Event) bool { return e.Status == Start },
+		},
+	})
+	assert.Len(t, read, 2)
+	assert.Equal(t, Start, read[0].Status)
+	assert.Equal(t, Start, read[1].Status)
+}
+
+func TestReadFollow(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	options := ReadOptions{
+		Follow:       true,
+		EventChannel: make(chan *Event),
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- eventer.Read(ctx, options)
+	}()
+
+	// The log is created while it is being followed
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "a", time.Now())))
+	select {
+	case event := <-options.EventChannel:
+		assert.Equal(t, "a", event.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	cancel()
+	assert.NoError(t, <-errChan)
+	_, open := <-options.EventChannel
+	assert.False(t, open)
+}
+
+func TestWriteRotatesLog(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	// Every event fills the log, so only the last one is kept
+	eventer.maxSize = 1
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "a", time.Now())))
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "b", time.Now())))
+	_, err := os.Stat(eventer.LogPath())
+	assert.True(t, os.IsNotExist(err))
+
+	// Rotated events are read before those in the log
+	eventer.maxSize = DefaultMaxLogSize
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "c", time.Now())))
+	read := readAllEvents(t, eventer, ReadOptions{})
+	assert.Len(t, read, 2)
+	assert.Equal(t, "b", read[0].ID)
+	assert.Equal(t, "c", read[1].ID)
+}
+
+func TestReadFollowRotatedLog(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	options := ReadOptions{
+		Follow:       true,
+		EventChannel: make(chan *Event),
+	}
+	go eventer.Read(ctx, options)
+
+	receive := func(id string) {
+		select {
+		case event := <-options.EventChannel:
+			assert.Equal(t, id, event.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %s", id)
+		}
+	}
+
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "a", time.Now())))
+	receive("a")
+
+	// The log being followed is rotated after this event
+	eventer.maxSize = 1
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "b", time.Now())))
+	receive("b")
+
+	// Following carries on in the new log
+	eventer.maxSize = DefaultMaxLogSize
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Start, "c", time.Now())))
+	receive("c")
+}
+
+func TestSubscribe(t *testing.T) {
+	eventer, cleanup := getTestEventer(t)
+	defer cleanup()
+
+	ch, cancel := eventer.Subscribe()
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Kill, "a", time.Now())))
+
+	event := <-ch
+	assert.Equal(t, "a", event.ID)
+	assert.Equal(t, Kill, event.Status)
+
+	cancel()
+	cancel()
+	_, open := <-ch
+	assert.False(t, open)
+	assert.NoError(t, eventer.Write(newTestEvent(Container, Kill, "b", time.Now())))
+}
+
+func TestToHumanReadable(t *testing.T) {
+	when := time.Date(2018, 9, 20, 10, 41, 9, 0, time.UTC)
+	e := newTestEvent(Container, Died, "ctr", when)
+	e.Name = "test"
+	e.Image = "alpine"
+	e.Attributes = map[string]string{"pod": "p", "a": "b"}
+
+	assert.Equal(t, "2018-09-20 10:41:09 +0000 UTC container die ctr (image=alpine, name=test, exitCode=0, a=b, pod=p)", e.ToHumanReadable())
+}
+
+func TestStringToStatusAndType(t *testing.T) {
+	status, err := StringToStatus("die")
+	assert.NoError(t, err)
+	assert.Equal(t, Died, status)
+	_, err = StringToStatus("bogus")
+	assert.Error(t, err)
+
+	eventType, err := StringToType("pod")
+	assert.NoError(t, err)
+	assert.Equal(t, Pod, eventType)
+	_, err = StringToType("bogus")
+	assert.Error(t, err)
+}
@@ -0,0 +1,427 @@
+package libpod
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/pkg/inspect"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HealthCheckHealthy indicates that the container's most recent
+	// healthcheck succeeded
+	HealthCheckHealthy = "healthy"
+	// HealthCheckUnhealthy indicates that the container's healthcheck has
+	// failed at least as many times in a row as its retries allow
+	HealthCheckUnhealthy = "unhealthy"
+	// HealthCheckStarting indicates that the container has not yet passed
+	// a healthcheck since it was started
+	HealthCheckStarting = "starting"
+)
+
+const (
+	// HealthCheckTestCmd is the first element of a healthcheck test that
+	// runs its remaining elements as a command
+	HealthCheckTestCmd = "CMD"
+	// HealthCheckTestCmdShell is the first element of a healthcheck test
+	// that runs its second element with the container's shell
+	HealthCheckTestCmdShell = "CMD-SHELL"
+	// HealthCheckTestNone is the only element of a healthcheck test that
+	// disables the healthcheck
+	HealthCheckTestNone = "NONE"
+)
+
+const (
+	// DefaultHealthCheckInterval is the time between healthchecks run by
+	// Supervise if no interval is given
+	DefaultHealthCheckInterval = 30 * time.Second
+	// DefaultHealthCheckTimeout is how long a healthcheck may run before it
+	// is considered to have failed if no timeout is given
+	DefaultHealthCheckTimeout = 30 * time.Second
+	// DefaultHealthCheckRetries is the number of consecutive failures
+	// needed to consider a container unhealthy if no retries are given
+	DefaultHealthCheckRetries = 3
+
+	// maxHealthCheckLogLength is the number of healthcheck results kept in
+	// the container's health log
+	maxHealthCheckLogLength = 5
+	// maxHealthCheckOutputLength is the number of bytes of output kept for
+	// each healthcheck
+	maxHealthCheckOutputLength = 4096
+)
+
+// HealthCheckConfig describes how to check that a container is healthy
+type HealthCheckConfig struct {
+	// Test is the healthcheck to run, either HealthCheckTestCmd followed by
+	// the command and its arguments, or HealthCheckTestCmdShell followed by
+	// a single command to run with /bin/sh
+	Test []string `json:"test"`
+	// Interval is the time between healthchecks run by Supervise
+	Interval time.Duration `json:"interval,omitempty"`
+	// Timeout is how long a healthcheck may run before it is considered
+	// to have failed
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// StartPeriod is the time after the container starts during which
+	// failed healthchecks do not count towards its retries
+	StartPeriod time.Duration `json:"startPeriod,omitempty"`
+	// Retries is the number of consecutive failed healthchecks after which
+	// the container is considered unhealthy
+	Retries int `json:"retries,omitempty"`
+}
+
+// Validate checks that the healthcheck can be run
+func (h *HealthCheckConfig) Validate() error {
+	if len(h.Test) == 0 {
+		return errors.Wrapf(ErrInvalidArg, "healthcheck must have a test")
+	}
+	switch h.Test[0] {
+	case HealthCheckTestCmd:
+		if len(h.Test) < 2 {
+			return errors.Wrapf(ErrInvalidArg, "healthcheck test %s must have a command", HealthCheckTestCmd)
+		}
+	case HealthCheckTestCmdShell:
+		if len(h.Test) != 2 {
+			return errors.Wrapf(ErrInvalidArg, "healthcheck test %s must have exactly one command", HealthCheckTestCmdShell)
+		}
+	default:
+		return errors.Wrapf(ErrInvalidArg, "healthcheck test must begin with %s or %s, not %q", HealthCheckTestCmd, HealthCheckTestCmdShell, h.Test[0])
+	}
+	if h.Interval < 0 || h.Timeout < 0 || h.StartPeriod < 0 {
+		return errors.Wrapf(ErrInvalidArg, "healthcheck interval, timeout, and start period must not be negative")
+	}
+	if h.Retries < 0 {
+		return errors.Wrapf(ErrInvalidArg, "healthcheck retries must not be negative")
+	}
+	return nil
+}
+
+// Get the command the healthcheck runs in the container
+func (h *HealthCheckConfig) command() []string {
+	if h.Test[0] == HealthCheckTestCmdShell {
+		return []string{"/bin/sh", "-c", h.Test[1]}
+	}
+	return h.Test[1:]
+}
+
+// Get the healthcheck interval, applying the default if none was given
+func (h *HealthCheckConfig) interval() time.Duration {
+	if h.Interval == 0 {
+		return DefaultHealthCheckInterval
+	}
+	return h.Interval
+}
+
+// Get the healthcheck timeout, applying the default if none was given
+func (h *HealthCheckConfig) timeout() time.Duration {
+	if h.Timeout == 0 {
+		return DefaultHealthCheckTimeout
+	}
+	return h.Timeout
+}
+
+// Get the healthcheck retries, applying the default if none were given
+func (h *HealthCheckConfig) retries() int {
+	if h.Retries == 0 {
+		return DefaultHealthCheckRetries
+	}
+	return h.Retries
+}
+
+// healthCheckSet holds the IDs of the containers whose healthchecks are
+// running
+type healthCheckSet struct {
+	lock sync.Mutex
+	ids  map[string]bool
+}
+
+// Add a container to the set, returning false if it is already in it
+func (s *healthCheckSet) add(id string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ids == nil {
+		s.ids = make(map[string]bool)
+	}
+	if s.ids[id] {
+		return false
+	}
+	s.ids[id] = true
+	return true
+}
+
+// Remove a container from the set
+func (s *healthCheckSet) remove(id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.ids, id)
+}
+
+// healthCheckOutput collects the output of a healthcheck, up to
+// maxHealthCheckOutputLength bytes
+// It is safe to read while the healthcheck is still writing to it
+type healthCheckOutput struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (o *healthCheckOutput) Write(p []byte) (int, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if remaining := maxHealthCheckOutputLength - o.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			o.buf.Write(p[:remaining])
+		} else {
+			o.buf.Write(p)
+		}
+	}
+	// Report everything as written so the healthcheck is not interrupted
+	return len(p), nil
+}
+
+func (o *healthCheckOutput) Close() error {
+	return nil
+}
+
+func (o *healthCheckOutput) String() string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.buf.String()
+}
+
+// RunHealthCheck runs the container's healthcheck once, records its result
+// in the container's health log, and returns the resulting health of the
+// container
+// The container must be running
+func (c *Container) RunHealthCheck() (string, error) {
+	if c.config.HealthCheckConfig == nil {
+		return "", errors.Wrapf(ErrCtrNoHealthCheck, "container %s", c.ID())
+	}
+
+	state, err := c.State()
+	if err != nil {
+		return "", err
+	}
+	if state != ContainerStateRunning {
+		return "", errors.Wrapf(ErrCtrStateInvalid, "container %s is not running", c.ID())
+	}
+
+	output := new(healthCheckOutput)
+	streams := &AttachStreams{
+		OutputStream: output,
+		ErrorStream:  output,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+
+	command := c.config.HealthCheckConfig.command()
+	timeout := c.config.HealthCheckConfig.timeout()
+	logrus.Debugf("Running healthcheck %v in container %s", command, c.ID())
+
+	result := inspect.HealthCheckLog{
+		Start: time.Now(),
+	}
+
+	sessionID, err := c.ExecCreate(&ExecConfig{Command: command})
+	if err != nil {
+		return "", errors.Wrapf(err, "error running healthcheck in container %s", c.ID())
+	}
+	run := func() (int, error) {
+		return c.ExecStartAndAttach(sessionID, streams)
+	}
+	// Removing the session by force kills its process
+	kill := func() error {
+		return c.ExecRemove(sessionID, true)
+	}
+
+	exitCode, timedOut, err := runHealthCheckWithTimeout(run, kill, timeout)
+	if !timedOut {
+		if rmErr := c.ExecRemove(sessionID, false); rmErr != nil && errors.Cause(rmErr) != ErrNoSuchExecSession {
+			logrus.Errorf("Error removing healthcheck exec session %s from container %s: %v", sessionID, c.ID(), rmErr)
+		}
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "error running healthcheck in container %s", c.ID())
+	}
+
+	if timedOut {
+		result.ExitCode = -1
+		result.Output = fmt.Sprintf("healthcheck exceeded timeout of %v", timeout)
+	} else {
+		result.ExitCode = exitCode
+		result.Output = output.String()
+	}
+	result.End = time.Now()
+
+	return c.updateHealthCheck(result)
+}
+
+// Run the healthchecks of running containers that are due, each in its own
+// goroutine added to wg
+// Containers in the running set already have a healthcheck running, and are
+// skipped until it finishes
+func (r *Runtime) runDueHealthChecks(running *healthCheckSet, wg *sync.WaitGroup) {
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		logrus.Errorf("Error retrieving containers to run their healthchecks: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, ctr := range ctrs {
+		if ctr.config.HealthCheckConfig == nil || !running.add(ctr.ID()) {
+			continue
+		}
+		due, err := ctr.healthCheckDueLocked(now)
+		if err != nil || !due {
+			if err != nil && errors.Cause(err) != ErrCtrRemoved {
+				logrus.Errorf("Error checking healthcheck of container %s: %v", ctr.ID(), err)
+			}
+			running.remove(ctr.ID())
+			continue
+		}
+
+		wg.Add(1)
+		go func(ctr *Container) {
+			defer wg.Done()
+			defer running.remove(ctr.ID())
+			if _, err := ctr.RunHealthCheck(); err != nil {
+				// The container may have stopped or been removed
+				// since it was found due
+				switch errors.Cause(err) {
+				case ErrCtrRemoved, ErrCtrStateInvalid:
+					logrus.Debugf("Skipping healthcheck of container %s: %v", ctr.ID(), err)
+				default:
+					logrus.Errorf("Error running healthcheck of container %s: %v", ctr.ID(), err)
+				}
+			}
+		}(ctr)
+	}
+}
+
+// Determine whether the container's healthcheck is due to run
+// Must be called without the container locked
+func (c *Container) healthCheckDueLocked(now time.Time) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return false, err
+	}
+	return c.healthCheckDue(now), nil
+}
+
+// Determine whether the container's healthcheck is due to run, as it is
+// running and its interval has passed since it started or since its last
+// healthcheck
+// Must be called with the container locked and its state synced
+func (c *Container) healthCheckDue(now time.Time) bool {
+	if c.config.HealthCheckConfig == nil || c.state.State != ContainerStateRunning {
+		return false
+	}
+	last := c.state.StartedTime
+	if c.state.HealthCheck != nil && len(c.state.HealthCheck.Log) > 0 {
+		// Healthchecks logged before the container was last started
+		// do not count
+		if start := c.state.HealthCheck.Log[len(c.state.HealthCheck.Log)-1].Start; start.After(last) {
+			last = start
+		}
+	}
+	return !now.Before(last.Add(c.config.HealthCheckConfig.interval()))
+}
+
+// Run a healthcheck, killing it if it does not exit within the timeout
+// run starts the healthcheck and waits for it to exit, and must return once
+// kill has stopped the healthcheck
+// Returns the exit code of the healthcheck and whether it timed out
+func runHealthCheckWithTimeout(run func() (int, error), kill func() error, timeout time.Duration) (int, bool, error) {
+	type execResult struct {
+		exitCode int
+		err      error
+	}
+	execDone := make(chan execResult, 1)
+	go func() {
+		exitCode, err := run()
+		execDone <- execResult{exitCode, err}
+	}()
+
+	select {
+	case res := <-execDone:
+		return res.exitCode, false, res.err
+	case <-time.After(timeout):
+	}
+
+	if err := kill(); err != nil {
+		return -1, true, errors.Wrapf(err, "error stopping healthcheck after timeout of %v", timeout)
+	}
+	// Wait until the healthcheck is gone so nothing is left running
+	<-execDone
+
+	return -1, true, nil
+}
+
+// Record the result of a healthcheck and save the container's resulting
+// health
+func (c *Container) updateHealthCheck(result inspect.HealthCheckLog) (string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return "", err
+		}
+	}
+
+	c.recordHealthCheck(result)
+
+	if err := c.save(); err != nil {
+		return "", err
+	}
+
+	return c.state.HealthCheck.Status, nil
+}
+
+// Add the result of a healthcheck to the container's health log and update
+// the container's health accordingly
+// Must be called with the container locked
+func (c *Container) recordHealthCheck(result inspect.HealthCheckLog) {
+	if c.state.HealthCheck == nil {
+		c.state.HealthCheck = &inspect.HealthCheckResults{
+			Status: HealthCheckStarting,
+		}
+	}
+	health := c.state.HealthCheck
+
+	if result.ExitCode == 0 {
+		health.Status = HealthCheckHealthy
+		health.FailingStreak = 0
+	} else if !c.inHealthCheckStartPeriod(result.Start) {
+		health.FailingStreak++
+		if health.FailingStreak >= c.config.HealthCheckConfig.retries() {
+			health.Status = HealthCheckUnhealthy
+		}
+	}
+
+	health.Log = append(health.Log, result)
+	if len(health.Log) > maxHealthCheckLogLength {
+		health.Log = health.Log[len(health.Log)-maxHealthCheckLogLength:]
+	}
+}
+
+// Determine whether a healthcheck that began at the given time did so during
+// the container's start period, when failures are not counted
+func (c *Container) inHealthCheckStartPeriod(t time.Time) bool {
+	return t.Before(c.state.StartedTime.Add(c.config.HealthCheckConfig.StartPeriod))
+}
+
+// Reset the container's health when it is started
+// The health log is kept, but the container must pass a healthcheck again
+// to be considered healthy
+func (c *Container) resetHealthCheck() {
+	if c.config.HealthCheckConfig == nil || c.state.HealthCheck == nil {
+		return
+	}
+	c.state.HealthCheck.Status = HealthCheckStarting
+	c.state.HealthCheck.FailingStreak = 0
+}
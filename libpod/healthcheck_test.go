@@ -0,0 +1,179 @@
+package libpod
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/projectatomic/libpod/pkg/inspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeHealthCheckContainer(retries int, startPeriod time.Duration) *Container {
+	return &Container{
+		config: &ContainerConfig{
+			HealthCheckConfig: &HealthCheckConfig{
+				Test:        []string{HealthCheckTestCmdShell, "true"},
+				Retries:     retries,
+				StartPeriod: startPeriod,
+			},
+		},
+		state: &containerState{
+			State:       ContainerStateRunning,
+			StartedTime: time.Now().Add(-time.Minute),
+		},
+	}
+}
+
+func TestHealthCheckValidate(t *testing.T) {
+	valid := [][]string{
+		{HealthCheckTestCmd, "/bin/check", "--quiet"},
+		{HealthCheckTestCmdShell, "curl -f http://localhost/ || exit 1"},
+	}
+	for _, test := range valid {
+		healthCheck := &HealthCheckConfig{Test: test}
+		assert.NoError(t, healthCheck.Validate())
+	}
+
+	invalid := [][]string{
+		{},
+		{HealthCheckTestNone},
+		{HealthCheckTestCmd},
+		{HealthCheckTestCmdShell, "a", "b"},
+		{"/bin/check"},
+	}
+	for _, test := range invalid {
+		healthCheck := &HealthCheckConfig{Test: test}
+		assert.Error(t, healthCheck.Validate())
+	}
+
+	healthCheck := &HealthCheckConfig{Test: valid[0], Retries: -1}
+	assert.Error(t, healthCheck.Validate())
+}
+
+func TestHealthCheckCommand(t *testing.T) {
+	healthCheck := &HealthCheckConfig{Test: []string{HealthCheckTestCmd, "/bin/check", "--quiet"}}
+	assert.Equal(t, []string{"/bin/check", "--quiet"}, healthCheck.command())
+
+	healthCheck = &HealthCheckConfig{Test: []string{HealthCheckTestCmdShell, "exit 1"}}
+	assert.Equal(t, []string{"/bin/sh", "-c", "exit 1"}, healthCheck.command())
+}
+
+func TestRecordHealthCheckHealthy(t *testing.T) {
+	ctr := makeHealthCheckContainer(0, 0)
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 0})
+	assert.Equal(t, HealthCheckHealthy, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 0, ctr.state.HealthCheck.FailingStreak)
+}
+
+func TestRecordHealthCheckRetries(t *testing.T) {
+	ctr := makeHealthCheckContainer(2, 0)
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 0})
+
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 1})
+	assert.Equal(t, HealthCheckHealthy, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 1, ctr.state.HealthCheck.FailingStreak)
+
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 1})
+	assert.Equal(t, HealthCheckUnhealthy, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 2, ctr.state.HealthCheck.FailingStreak)
+
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 0})
+	assert.Equal(t, HealthCheckHealthy, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 0, ctr.state.HealthCheck.FailingStreak)
+}
+
+func TestRecordHealthCheckStartPeriod(t *testing.T) {
+	ctr := makeHealthCheckContainer(1, time.Hour)
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 1})
+	assert.Equal(t, HealthCheckStarting, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 0, ctr.state.HealthCheck.FailingStreak)
+
+	ctr.config.HealthCheckConfig.StartPeriod = 0
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 1})
+	assert.Equal(t, HealthCheckUnhealthy, ctr.state.HealthCheck.Status)
+}
+
+func TestRecordHealthCheckLogLength(t *testing.T) {
+	ctr := makeHealthCheckContainer(0, 0)
+	for i := 0; i < maxHealthCheckLogLength+2; i++ {
+		ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: i})
+	}
+	assert.Len(t, ctr.state.HealthCheck.Log, maxHealthCheckLogLength)
+	assert.Equal(t, maxHealthCheckLogLength+1, ctr.state.HealthCheck.Log[maxHealthCheckLogLength-1].ExitCode)
+}
+
+func TestResetHealthCheck(t *testing.T) {
+	ctr := makeHealthCheckContainer(1, 0)
+	ctr.recordHealthCheck(inspect.HealthCheckLog{Start: time.Now(), ExitCode: 1})
+	assert.Equal(t, HealthCheckUnhealthy, ctr.state.HealthCheck.Status)
+
+	ctr.resetHealthCheck()
+	assert.Equal(t, HealthCheckStarting, ctr.state.HealthCheck.Status)
+	assert.Equal(t, 0, ctr.state.HealthCheck.FailingStreak)
+	assert.Len(t, ctr.state.HealthCheck.Log, 1)
+}
+
+func TestHealthCheckDue(t *testing.T) {
+	ctr := makeHealthCheckContainer(0, 0)
+	now := time.Now()
+	// The container started a minute ago and has never been checked
+	assert.True(t, ctr.healthCheckDue(now))
+
+	ctr.state.HealthCheck = &inspect.HealthCheckResults{
+		Log: []inspect.HealthCheckLog{{Start: now.Add(-10 * time.Second)}},
+	}
+	assert.False(t, ctr.healthCheckDue(now))
+	assert.True(t, ctr.healthCheckDue(now.Add(DefaultHealthCheckInterval)))
+
+	ctr.config.HealthCheckConfig.Interval = 5 * time.Second
+	assert.True(t, ctr.healthCheckDue(now))
+
+	ctr.state.State = ContainerStateStopped
+	assert.False(t, ctr.healthCheckDue(now))
+}
+
+func TestHealthCheckSet(t *testing.T) {
+	var set healthCheckSet
+	assert.True(t, set.add("ctr"))
+	assert.False(t, set.add("ctr"))
+	set.remove("ctr")
+	assert.True(t, set.add("ctr"))
+}
+
+func TestRunHealthCheckWithTimeout(t *testing.T) {
+	run := func() (int, error) {
+		return 3, nil
+	}
+	kill := func() error {
+		t.Error("healthcheck killed before its timeout")
+		return nil
+	}
+	exitCode, timedOut, err := runHealthCheckWithTimeout(run, kill, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, timedOut)
+	assert.Equal(t, 3, exitCode)
+}
+
+func TestRunHealthCheckTimeoutKillsCheck(t *testing.T) {
+	check := exec.Command("sleep", "60")
+	assert.NoError(t, check.Start())
+
+	exited := false
+	run := func() (int, error) {
+		err := check.Wait()
+		exited = true
+		return 0, err
+	}
+	kill := func() error {
+		return check.Process.Kill()
+	}
+
+	start := time.Now()
+	exitCode, timedOut, err := runHealthCheckWithTimeout(run, kill, 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, timedOut)
+	assert.Equal(t, -1, exitCode)
+	assert.True(t, exited)
+	assert.True(t, time.Since(start) < 30*time.Second)
+}
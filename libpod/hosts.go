@@ -0,0 +1,212 @@
+package libpod
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containers/storage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Contains the hosts entries letting containers that share a network or pod
+// resolve each other by name
+
+// Rewrite the hosts files of a container and of the containers sharing a
+// network, network namespace or pod with it, so they reflect the container's
+// current state, name and networks
+// Networks the container has just left can be given, so the containers still
+// in them drop their entries for the container
+// Only the hosts files of created, running and paused containers are
+// rewritten; other containers have theirs generated when they are started.
+func (r *Runtime) updateHostsFiles(ctr *Container, oldNetworks ...string) error {
+	hostsLock, err := r.getHostsLock()
+	if err != nil {
+		return err
+	}
+	hostsLock.Lock()
+	defer hostsLock.Unlock()
+
+	ctrs, err := r.hostsContainers(ctr)
+	if err != nil {
+		return err
+	}
+	byID := containersByID(ctrs)
+
+	networks := make(map[string]bool)
+	for _, network := range oldNetworks {
+		networks[network] = true
+	}
+	defaultNetwork := r.defaultNetworkName()
+	if netCtr := netNSContainer(ctr, byID); netCtr != nil {
+		for _, network := range networkNames(netCtr, defaultNetwork) {
+			networks[network] = true
+		}
+	}
+
+	var lastError error
+	for _, other := range ctrs {
+		if other.ID() != ctr.ID() && len(sharedNetworks(ctr, other, byID, networks, defaultNetwork)) == 0 && !sharesNetNSOrPod(ctr, other, byID) {
+			continue
+		}
+		switch other.state.State {
+		case ContainerStateCreated, ContainerStateRunning, ContainerStatePaused:
+		default:
+			continue
+		}
+		if err := other.rewriteHosts(ctrs); err != nil {
+			if lastError != nil {
+				logrus.Errorf("%v", lastError)
+			}
+			lastError = err
+		}
+	}
+	return lastError
+}
+
+// Get the lock serializing changes to the hosts files of containers
+func (r *Runtime) getHostsLock() (storage.Locker, error) {
+	lock, err := storage.GetLockfile(filepath.Join(r.lockDir, "hosts"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving hosts lock")
+	}
+	return lock, nil
+}
+
+// Get all containers, for generating the hosts entries of the given container
+// The given container is used as is, rather than as last saved, so changes
+// to its state that are not saved yet are included
+func (r *Runtime) hostsContainers(ctr *Container) ([]*Container, error) {
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+	for i, other := range ctrs {
+		if other.ID() == ctr.ID() {
+			ctrs[i] = ctr
+			return ctrs, nil
+		}
+	}
+	return append(ctrs, ctr), nil
+}
+
+// Get the hosts entries of a container, given the containers that may be its
+// peers
+// The container's name and hostname resolve to the addresses of its network
+// namespace. The names and network aliases of running peers resolve to their
+// addresses in the networks shared with the container, or to all addresses of
+// their network namespace for peers sharing the container's network
+// namespace or pod.
+func hostsEntries(ctr *Container, ctrs []*Container, defaultNetwork string) []string {
+	byID := containersByID(ctrs)
+	byID[ctr.ID()] = ctr
+
+	netCtr := netNSContainer(ctr, byID)
+	if netCtr == nil {
+		return nil
+	}
+
+	var entries []string
+	names := ctr.Name()
+	if hostname := ctr.Hostname(); hostname != ctr.Name() {
+		names = fmt.Sprintf("%s %s", hostname, names)
+	}
+	for _, ip := range netCtr.state.IPs {
+		entries = append(entries, fmt.Sprintf("%s %s", ip.Address.IP.String(), names))
+	}
+
+	networks := make(map[string]bool)
+	for _, network := range networkNames(netCtr, defaultNetwork) {
+		networks[network] = true
+	}
+
+	peers := make([]*Container, 0, len(ctrs))
+	for _, other := range ctrs {
+		if other.ID() != ctr.ID() && (other.state.State == ContainerStateRunning || other.state.State == ContainerStatePaused) {
+			peers = append(peers, other)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Name() < peers[j].Name()
+	})
+
+	for _, peer := range peers {
+		peerNetCtr := netNSContainer(peer, byID)
+		if peerNetCtr == nil {
+			continue
+		}
+
+		var ips []*cnitypes.IPConfig
+		if sharesNetNSOrPod(ctr, peer, byID) {
+			ips = peerNetCtr.state.IPs
+		} else {
+			for _, network := range sharedNetworks(ctr, peer, byID, networks, defaultNetwork) {
+				if result, ok := peerNetCtr.state.NetworkStatus[network]; ok {
+					ips = append(ips, result.IPs...)
+				}
+			}
+		}
+
+		names := strings.Join(append([]string{peer.Name()}, peer.config.NetworkAliases...), " ")
+		for _, ip := range ips {
+			entries = append(entries, fmt.Sprintf("%s %s", ip.Address.IP.String(), names))
+		}
+	}
+
+	return entries
+}
+
+// Get the networks of the given ones that a peer of a container joins
+func sharedNetworks(ctr, peer *Container, byID map[string]*Container, networks map[string]bool, defaultNetwork string) []string {
+	peerNetCtr := netNSContainer(peer, byID)
+	if peerNetCtr == nil || netNSContainer(ctr, byID) == nil {
+		return nil
+	}
+	var shared []string
+	for _, network := range networkNames(peerNetCtr, defaultNetwork) {
+		if networks[network] {
+			shared = append(shared, network)
+		}
+	}
+	return shared
+}
+
+// Whether two containers share a network namespace created by libpod, or a pod
+func sharesNetNSOrPod(ctr, peer *Container, byID map[string]*Container) bool {
+	if ctr.config.Pod != "" && ctr.config.Pod == peer.config.Pod {
+		return true
+	}
+	netCtr := netNSContainer(ctr, byID)
+	peerNetCtr := netNSContainer(peer, byID)
+	return netCtr != nil && peerNetCtr != nil && netCtr.ID() == peerNetCtr.ID()
+}
+
+// Get the container whose network namespace a container uses
+// Returns nil if the container does not use a network namespace created by
+// libpod, like containers in the host's network namespace
+func netNSContainer(ctr *Container, byID map[string]*Container) *Container {
+	// Bound the number of containers followed, in case of a cycle
+	for i := 0; i <= len(byID); i++ {
+		if ctr.config.CreateNetNS {
+			return ctr
+		}
+		next, ok := byID[ctr.config.NetNsCtr]
+		if !ok {
+			return nil
+		}
+		ctr = next
+	}
+	return nil
+}
+
+// Map containers by their IDs
+func containersByID(ctrs []*Container) map[string]*Container {
+	byID := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		byID[ctr.ID()] = ctr
+	}
+	return byID
+}
@@ -0,0 +1,100 @@
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types/current"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func getHostsTestCtr(id string, networks []string, ips ...string) *Container {
+	ctr := &Container{
+		config: &ContainerConfig{
+			ID:          id,
+			Name:        id,
+			Spec:        &spec.Spec{},
+			CreateNetNS: true,
+			Networks:    networks,
+		},
+		state: &containerState{
+			State:         ContainerStateRunning,
+			NetworkStatus: make(map[string]*cnitypes.Result),
+		},
+	}
+	for i, network := range networkNames(ctr, "podman") {
+		if i >= len(ips) {
+			break
+		}
+		ip := cnitypes.IPConfig{Version: "4", Address: net.IPNet{IP: net.ParseIP(ips[i]), Mask: net.CIDRMask(24, 32)}}
+		ctr.state.NetworkStatus[network] = &cnitypes.Result{IPs: []*cnitypes.IPConfig{&ip}}
+		ctr.state.IPs = append(ctr.state.IPs, &ip)
+	}
+	return ctr
+}
+
+func TestHostsEntries(t *testing.T) {
+	web := getHostsTestCtr("web", []string{"front", "back"}, "10.89.0.2", "10.89.1.2")
+	web.config.Spec.Hostname = "webhost"
+	proxy := getHostsTestCtr("proxy", []string{"front"}, "10.89.0.3")
+	proxy.config.NetworkAliases = []string{"lb"}
+	db := getHostsTestCtr("db", []string{"back"}, "10.89.1.3")
+	other := getHostsTestCtr("other", nil, "10.88.0.2")
+	stopped := getHostsTestCtr("stopped", []string{"front"}, "10.89.0.4")
+	stopped.state.State = ContainerStateStopped
+	ctrs := []*Container{web, proxy, db, other, stopped}
+
+	assert.Equal(t, []string{
+		"10.89.0.2 webhost web",
+		"10.89.1.2 webhost web",
+		"10.89.1.3 db",
+		"10.89.0.3 proxy lb",
+	}, hostsEntries(web, ctrs, "podman"))
+
+	// Peers only resolve to their addresses in the shared networks
+	assert.Equal(t, []string{
+		"10.89.0.3 proxy",
+		"10.89.0.2 web",
+	}, hostsEntries(proxy, ctrs, "podman"))
+
+	// Containers without networks join the default network
+	assert.Equal(t, []string{"10.88.0.2 other"}, hostsEntries(other, ctrs, "podman"))
+}
+
+func TestHostsEntriesPod(t *testing.T) {
+	infra := getHostsTestCtr("infra", []string{"front"}, "10.89.0.2")
+	infra.config.Pod = "pod"
+	member := &Container{
+		config: &ContainerConfig{
+			ID:       "member",
+			Name:     "member",
+			Spec:     &spec.Spec{},
+			NetNsCtr: "infra",
+			Pod:      "pod",
+		},
+		state: &containerState{State: ContainerStateRunning},
+	}
+	peer := getHostsTestCtr("peer", []string{"front"}, "10.89.0.3")
+	host := &Container{
+		config: &ContainerConfig{ID: "host", Name: "host", Spec: &spec.Spec{}},
+		state:  &containerState{State: ContainerStateRunning},
+	}
+	ctrs := []*Container{infra, member, peer, host}
+
+	// Containers joining the pod's network namespace resolve to its
+	// addresses, and share its networks
+	assert.Equal(t, []string{
+		"10.89.0.2 member",
+		"10.89.0.2 infra",
+		"10.89.0.3 peer",
+	}, hostsEntries(member, ctrs, "podman"))
+	assert.Equal(t, []string{
+		"10.89.0.3 peer",
+		"10.89.0.2 infra",
+		"10.89.0.2 member",
+	}, hostsEntries(peer, ctrs, "podman"))
+
+	// Containers in the host's network namespace have no entries
+	assert.Empty(t, hostsEntries(host, ctrs, "podman"))
+}
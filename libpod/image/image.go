@@ -25,6 +25,7 @@ import (
 	"github.com/projectatomic/buildah"
 	"github.com/projectatomic/libpod/libpod/common"
 	"github.com/projectatomic/libpod/libpod/driver"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/projectatomic/libpod/pkg/inspect"
 	"github.com/projectatomic/libpod/pkg/registries"
 	"github.com/projectatomic/libpod/pkg/util"
@@ -58,6 +59,8 @@ type Image struct {
 type Runtime struct {
 	store               storage.Store
 	SignaturePolicyPath string
+	// Eventer records image events, if set
+	Eventer *events.Eventer
 }
 
 // NewImageRuntimeFromStore creates an ImageRuntime based on a provided store
@@ -153,6 +156,7 @@ func (ir *Runtime) New(ctx context.Context, name, signaturePolicyPath, authfile
 		return nil, errors.Wrapf(err, "error retrieving local image after pulling %s", name)
 	}
 	newImage.image = img
+	newImage.newImageEvent(events.Pull, name)
 	return &newImage, nil
 }
 
@@ -185,12 +189,27 @@ func (ir *Runtime) LoadFromArchive(ctx context.Context, name, signaturePolicyPat
 			return nil, errors.Wrapf(err, "error retrieving local image after pulling %s", name)
 		}
 		newImage.image = img
+		newImage.newImageEvent(events.Pull, name)
 		newImages = append(newImages, &newImage)
 	}
 
 	return newImages, nil
 }
 
+// Record an event for the image under the given name
+// Failing to record an event does not fail the operation it is for
+func (i *Image) newImageEvent(status events.Status, name string) {
+	if i.imageruntime.Eventer == nil {
+		return
+	}
+	e := events.NewEvent(events.Image, status)
+	e.ID = i.ID()
+	e.Name = name
+	if err := i.imageruntime.Eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to record image %s event: %v", status, err)
+	}
+}
+
 // Shutdown closes down the storage and require a bool arg as to
 // whether it should do so forcibly.
 func (ir *Runtime) Shutdown(force bool) error {
@@ -335,8 +354,11 @@ func (i *Image) TopLayer() string {
 // Remove an image; container removal for the image must be done
 // outside the context of images
 func (i *Image) Remove(force bool) error {
-	_, err := i.imageruntime.store.DeleteImage(i.ID(), true)
-	return err
+	if _, err := i.imageruntime.store.DeleteImage(i.ID(), true); err != nil {
+		return err
+	}
+	i.newImageEvent(events.Remove, i.InputName)
+	return nil
 }
 
 // Decompose an Image
@@ -439,6 +461,7 @@ func (i *Image) TagImage(tag string) error {
 		return err
 	}
 	i.reloadImage()
+	i.newImageEvent(events.Tag, tag)
 	return nil
 }
 
@@ -459,6 +482,7 @@ func (i *Image) UntagImage(tag string) error {
 		return err
 	}
 	i.reloadImage()
+	i.newImageEvent(events.Untag, tag)
 	return nil
 }
 
@@ -722,6 +746,10 @@ func (i *Image) Inspect(ctx context.Context) (*inspect.ImageData, error) {
 	if err != nil {
 		return nil, err
 	}
+	healthCheck, err := i.HealthCheck(ctx, manifestType)
+	if err != nil {
+		return nil, err
+	}
 
 	data := &inspect.ImageData{
 		ID:              i.ID(),
@@ -745,6 +773,7 @@ func (i *Image) Inspect(ctx context.Context) (*inspect.ImageData, error) {
 		},
 		GraphDriver:  driver,
 		ManifestType: manifestType,
+		HealthCheck:  healthCheck,
 	}
 	return data, nil
 }
@@ -890,3 +919,27 @@ func (i *Image) Comment(ctx context.Context, manifestType string) (string, error
 	}
 	return ociv1Img.History[0].Comment, nil
 }
+
+// HealthCheck returns the healthcheck of an image, or nil if it has none
+// Only images with a Docker manifest can have a healthcheck
+func (i *Image) HealthCheck(ctx context.Context, manifestType string) (*manifest.Schema2HealthConfig, error) {
+	if manifestType != buildah.Dockerv2ImageManifest {
+		return nil, nil
+	}
+	imgRef, err := i.toImageRef(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create image reference from image")
+	}
+	blob, err := imgRef.ConfigBlob(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get config blob from image")
+	}
+	b := manifest.Schema2Image{}
+	if err := json.Unmarshal(blob, &b); err != nil {
+		return nil, err
+	}
+	if b.Config == nil {
+		return nil, nil
+	}
+	return b.Config.Healthcheck, nil
+}
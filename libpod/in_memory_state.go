@@ -16,7 +16,9 @@ import (
 type InMemoryState struct {
 	pods          map[string]*Pod
 	containers    map[string]*Container
+	volumes       map[string]*Volume
 	ctrDepends    map[string][]string
+	volumeDepends map[string][]string
 	podContainers map[string]map[string]*Container
 	nameIndex     *registrar.Registrar
 	idIndex       *truncindex.TruncIndex
@@ -28,8 +30,10 @@ func NewInMemoryState() (State, error) {
 
 	state.pods = make(map[string]*Pod)
 	state.containers = make(map[string]*Container)
+	state.volumes = make(map[string]*Volume)
 
 	state.ctrDepends = make(map[string][]string)
+	state.volumeDepends = make(map[string][]string)
 
 	state.podContainers = make(map[string]map[string]*Container)
 
@@ -135,6 +139,12 @@ func (s *InMemoryState) AddContainer(ctr *Container) error {
 		}
 	}
 
+	for _, namedVol := range ctr.config.NamedVolumes {
+		if _, ok := s.volumes[namedVol.Name]; !ok {
+			return errors.Wrapf(ErrNoSuchVolume, "cannot use nonexistent volume %s", namedVol.Name)
+		}
+	}
+
 	if err := s.nameIndex.Reserve(ctr.Name(), ctr.ID()); err != nil {
 		return errors.Wrapf(err, "error registering container name %s", ctr.Name())
 	}
@@ -151,6 +161,11 @@ func (s *InMemoryState) AddContainer(ctr *Container) error {
 		s.addCtrToDependsMap(ctr.ID(), depCtr)
 	}
 
+	// Add the container as a user of its named volumes
+	for _, namedVol := range ctr.config.NamedVolumes {
+		s.addCtrToVolDependsMap(ctr.ID(), namedVol.Name)
+	}
+
 	return nil
 }
 
@@ -186,6 +201,11 @@ func (s *InMemoryState) RemoveContainer(ctr *Container) error {
 		s.removeCtrFromDependsMap(ctr.ID(), depCtr)
 	}
 
+	// Remove us from the users of our named volumes
+	for _, namedVol := range ctr.config.NamedVolumes {
+		s.removeCtrFromVolDependsMap(ctr.ID(), namedVol.Name)
+	}
+
 	return nil
 }
 
@@ -226,6 +246,51 @@ func (s *InMemoryState) SaveContainer(ctr *Container) error {
 	return nil
 }
 
+// RenameContainer changes the name of a container
+func (s *InMemoryState) RenameContainer(ctr *Container, newName string) error {
+	if !ctr.valid {
+		return errors.Wrapf(ErrCtrRemoved, "container with ID %s is not valid", ctr.ID())
+	}
+
+	stateCtr, ok := s.containers[ctr.ID()]
+	if !ok {
+		ctr.valid = false
+		return errors.Wrapf(ErrNoSuchCtr, "container with ID %s not found in state", ctr.ID())
+	}
+
+	oldName := ctr.Name()
+	if newName == oldName {
+		return errors.Wrapf(ErrCtrExists, "name %s is in use", newName)
+	}
+	if err := s.nameIndex.Reserve(newName, ctr.ID()); err != nil {
+		return errors.Wrapf(ErrCtrExists, "name %s is in use", newName)
+	}
+	s.nameIndex.Release(oldName)
+
+	ctr.config.Name = newName
+	stateCtr.config.Name = newName
+
+	return nil
+}
+
+// RewriteContainerConfig replaces the configuration of a container
+func (s *InMemoryState) RewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) error {
+	if !ctr.valid {
+		return errors.Wrapf(ErrCtrRemoved, "container with ID %s is not valid", ctr.ID())
+	}
+
+	stateCtr, ok := s.containers[ctr.ID()]
+	if !ok {
+		ctr.valid = false
+		return errors.Wrapf(ErrNoSuchCtr, "container with ID %s not found in state", ctr.ID())
+	}
+
+	ctr.config = newCfg
+	stateCtr.config = newCfg
+
+	return nil
+}
+
 // ContainerInUse checks if the given container is being used by other containers
 func (s *InMemoryState) ContainerInUse(ctr *Container) ([]string, error) {
 	if !ctr.valid {
@@ -475,6 +540,10 @@ func (s *InMemoryState) RemovePodContainers(pod *Pod) error {
 
 		delete(s.containers, ctr.ID())
 		delete(s.ctrDepends, ctr.ID())
+
+		for _, namedVol := range ctr.config.NamedVolumes {
+			s.removeCtrFromVolDependsMap(ctr.ID(), namedVol.Name)
+		}
 	}
 
 	return nil
@@ -519,6 +588,12 @@ func (s *InMemoryState) AddContainerToPod(pod *Pod, ctr *Container) error {
 		}
 	}
 
+	for _, namedVol := range ctr.config.NamedVolumes {
+		if _, ok = s.volumes[namedVol.Name]; !ok {
+			return errors.Wrapf(ErrNoSuchVolume, "cannot use nonexistent volume %s", namedVol.Name)
+		}
+	}
+
 	// Add container to state
 	if _, ok = s.containers[ctr.ID()]; ok {
 		return errors.Wrapf(ErrCtrExists, "container with ID %s already exists in state", ctr.ID())
@@ -543,6 +618,11 @@ func (s *InMemoryState) AddContainerToPod(pod *Pod, ctr *Container) error {
 		s.addCtrToDependsMap(ctr.ID(), depCtr)
 	}
 
+	// Add the container as a user of its named volumes
+	for _, namedVol := range ctr.config.NamedVolumes {
+		s.addCtrToVolDependsMap(ctr.ID(), namedVol.Name)
+	}
+
 	return nil
 }
 
@@ -601,6 +681,11 @@ func (s *InMemoryState) RemoveContainerFromPod(pod *Pod, ctr *Container) error {
 		s.removeCtrFromDependsMap(ctr.ID(), depCtr)
 	}
 
+	// Remove us from the users of our named volumes
+	for _, namedVol := range ctr.config.NamedVolumes {
+		s.removeCtrFromVolDependsMap(ctr.ID(), namedVol.Name)
+	}
+
 	return nil
 }
 
@@ -644,6 +729,125 @@ func (s *InMemoryState) AllPods() ([]*Pod, error) {
 	return pods, nil
 }
 
+// Volume retrieves a volume from its full name
+func (s *InMemoryState) Volume(name string) (*Volume, error) {
+	if name == "" {
+		return nil, ErrEmptyID
+	}
+
+	volume, ok := s.volumes[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrNoSuchVolume, "no volume with name %s found", name)
+	}
+
+	return volume, nil
+}
+
+// HasVolume checks if a volume with the given name is present in the state
+func (s *InMemoryState) HasVolume(name string) (bool, error) {
+	if name == "" {
+		return false, ErrEmptyID
+	}
+
+	_, ok := s.volumes[name]
+
+	return ok, nil
+}
+
+// AddVolume adds a volume to the state
+func (s *InMemoryState) AddVolume(volume *Volume) error {
+	if !volume.valid {
+		return errors.Wrapf(ErrVolumeRemoved, "volume %s is not valid and cannot be added", volume.Name())
+	}
+
+	if _, ok := s.volumes[volume.Name()]; ok {
+		return errors.Wrapf(ErrVolumeExists, "volume with name %s already exists in state", volume.Name())
+	}
+
+	s.volumes[volume.Name()] = volume
+
+	return nil
+}
+
+// RemoveVolume removes a volume from the state
+// Only volumes not used by containers can be removed
+func (s *InMemoryState) RemoveVolume(volume *Volume) error {
+	deps, ok := s.volumeDepends[volume.Name()]
+	if ok && len(deps) != 0 {
+		depsStr := strings.Join(deps, ", ")
+		return errors.Wrapf(ErrVolumeBeingUsed, "the following containers use volume %s: %s", volume.Name(), depsStr)
+	}
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	delete(s.volumes, volume.Name())
+	delete(s.volumeDepends, volume.Name())
+
+	return nil
+}
+
+// UpdateVolume updates a volume in the state
+// This is a no-op as there is no backing store
+func (s *InMemoryState) UpdateVolume(volume *Volume) error {
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	return nil
+}
+
+// SaveVolume saves a volume in the state
+// This is a no-op as there is no backing store
+func (s *InMemoryState) SaveVolume(volume *Volume) error {
+	if !volume.valid {
+		return ErrVolumeRemoved
+	}
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	return nil
+}
+
+// VolumeInUse returns the IDs of the containers using the given volume
+func (s *InMemoryState) VolumeInUse(volume *Volume) ([]string, error) {
+	if !volume.valid {
+		return nil, ErrVolumeRemoved
+	}
+
+	if _, ok := s.volumes[volume.Name()]; !ok {
+		volume.valid = false
+		return nil, errors.Wrapf(ErrNoSuchVolume, "no volume exists in state with name %s", volume.Name())
+	}
+
+	arr, ok := s.volumeDepends[volume.Name()]
+	if !ok {
+		return []string{}, nil
+	}
+
+	return arr, nil
+}
+
+// AllVolumes retrieves all volumes currently in the state
+func (s *InMemoryState) AllVolumes() ([]*Volume, error) {
+	volumes := make([]*Volume, 0, len(s.volumes))
+	for _, volume := range s.volumes {
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
 // Internal Functions
 
 // Add a container to the dependency mappings
@@ -683,3 +887,29 @@ func (s *InMemoryState) removeCtrFromDependsMap(ctrID, dependsID string) {
 		s.ctrDepends[dependsID] = newArr
 	}
 }
+
+// Add a container to the users of a volume
+func (s *InMemoryState) addCtrToVolDependsMap(ctrID, volName string) {
+	s.volumeDepends[volName] = append(s.volumeDepends[volName], ctrID)
+}
+
+// Remove a container from the users of a volume
+func (s *InMemoryState) removeCtrFromVolDependsMap(ctrID, volName string) {
+	arr, ok := s.volumeDepends[volName]
+	if !ok {
+		// Internal state seems inconsistent
+		// But the container is definitely not a user
+		// So just return
+		return
+	}
+
+	newArr := make([]string, 0, len(arr))
+
+	for _, id := range arr {
+		if id != ctrID {
+			newArr = append(newArr, id)
+		}
+	}
+
+	s.volumeDepends[volName] = newArr
+}
@@ -0,0 +1,424 @@
+package libpod
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/pkg/util"
+	"github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// kubeAppLabel is the label used to select the pods a Kubernetes
+	// service generated by libpod directs traffic to
+	kubeAppLabel = "app"
+	// maxKubeVolumeNameBase is the longest a volume name generated from a
+	// host path may be before its suffixes, leaving room for them within
+	// the 63 characters of a DNS-1123 label
+	maxKubeVolumeNameBase = 48
+)
+
+// kubeVolumeNameInvalidChars matches the runs of characters not allowed in
+// Kubernetes volume names
+var kubeVolumeNameInvalidChars = regexp.MustCompile("[^a-z0-9]+")
+
+// GenerateForKube takes a pod and generates a v1.Pod describing it, along with
+// the service ports of the ports published by its containers
+func (p *Pod) GenerateForKube() (*v1.Pod, []v1.ServicePort, error) {
+	allCtrs, err := p.AllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sort containers by creation time so the output is stable
+	sort.Slice(allCtrs, func(i, j int) bool {
+		return allCtrs[i].CreatedTime().Before(allCtrs[j].CreatedTime())
+	})
+
+	var (
+		podContainers []v1.Container
+		podVolumes    []v1.Volume
+		servicePorts  []v1.ServicePort
+	)
+	for _, ctr := range allCtrs {
+		// Kubernetes provides the pod's infra container itself
+		if ctr.IsInfra() {
+			continue
+		}
+
+		var kubeCtr v1.Container
+		kubeCtr, podVolumes = ctr.containerToV1Container(podVolumes)
+		servicePorts = append(servicePorts, ContainerPortsToServicePorts(kubeCtr.Ports)...)
+		podContainers = append(podContainers, kubeCtr)
+	}
+
+	if len(podContainers) == 0 {
+		return nil, nil, errors.Wrapf(ErrNoSuchCtr, "pod %s has no containers to generate a Kubernetes pod from", p.ID())
+	}
+
+	// Ports published by the infra container belong to the pod as a whole
+	// Kubernetes publishes ports per container, so they are given to the
+	// first container
+	infraPorts, err := p.infraContainerPorts()
+	if err != nil {
+		return nil, nil, err
+	}
+	podContainers[0].Ports = append(infraPorts, podContainers[0].Ports...)
+	servicePorts = uniqueServicePortNames(append(ContainerPortsToServicePorts(infraPorts), servicePorts...))
+
+	return newV1Pod(p.Name(), p.Labels(), p.CreatedTime(), podContainers, podVolumes), servicePorts, nil
+}
+
+// GenerateForKube takes a container and generates a v1.Pod holding only that
+// container
+func (c *Container) GenerateForKube() (*v1.Pod, error) {
+	kubeCtr, volumes := c.containerToV1Container(nil)
+
+	hostNetwork := false
+	if c.config.Spec.Linux != nil {
+		hostNetwork = true
+		for _, ns := range c.config.Spec.Linux.Namespaces {
+			if ns.Type == "network" {
+				hostNetwork = false
+				break
+			}
+		}
+	}
+
+	pod := newV1Pod(c.Name(), c.Labels(), c.CreatedTime(), []v1.Container{kubeCtr}, volumes)
+	pod.Spec.HostNetwork = hostNetwork
+
+	return pod, nil
+}
+
+// GenerateKubeServiceFromV1Pod generates a v1.Service exposing the given ports
+// of a v1.Pod
+// The service selects the pod through its app label and uses the NodePort type,
+// so the ports are reachable on every node as they are on the podman host
+func GenerateKubeServiceFromV1Pod(pod *v1.Pod, servicePorts []v1.ServicePort) *v1.Service {
+	service := new(v1.Service)
+	service.TypeMeta = metav1.TypeMeta{
+		Kind:       "Service",
+		APIVersion: "v1",
+	}
+	service.ObjectMeta = metav1.ObjectMeta{
+		Name:              pod.Name,
+		Labels:            map[string]string{kubeAppLabel: pod.Name},
+		CreationTimestamp: pod.CreationTimestamp,
+	}
+	service.Spec = v1.ServiceSpec{
+		Ports:    servicePorts,
+		Selector: map[string]string{kubeAppLabel: pod.Name},
+		Type:     v1.ServiceTypeNodePort,
+	}
+	return service
+}
+
+// infraContainerPorts returns the ports published by the pod's infra
+// container, if it has one
+func (p *Pod) infraContainerPorts() ([]v1.ContainerPort, error) {
+	if !p.HasInfraContainer() {
+		return nil, nil
+	}
+	infraID, err := p.InfraContainerID()
+	if err != nil {
+		return nil, err
+	}
+	infra, err := p.runtime.state.Container(infraID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving infra container of pod %s", p.ID())
+	}
+	return portMappingsToContainerPorts(infra.config.PortMappings), nil
+}
+
+// newV1Pod assembles a v1.Pod from the given containers and volumes
+func newV1Pod(name string, labels map[string]string, created time.Time, containers []v1.Container, volumes []v1.Volume) *v1.Pod {
+	podLabels := make(map[string]string)
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+	podLabels[kubeAppLabel] = name
+
+	pod := new(v1.Pod)
+	pod.TypeMeta = metav1.TypeMeta{
+		Kind:       "Pod",
+		APIVersion: "v1",
+	}
+	pod.ObjectMeta = metav1.ObjectMeta{
+		Name:              name,
+		Labels:            podLabels,
+		CreationTimestamp: metav1.NewTime(created),
+	}
+	pod.Spec = v1.PodSpec{
+		Containers: containers,
+		Volumes:    volumes,
+	}
+	return pod
+}
+
+// containerToV1Container converts the configuration of a container into a
+// v1.Container
+// The volumes backing its bind mounts are added to the given volumes of the
+// pod, which are returned
+func (c *Container) containerToV1Container(volumes []v1.Volume) (v1.Container, []v1.Volume) {
+	kubeCtr := v1.Container{
+		Name:    c.Name(),
+		Image:   c.config.RootfsImageName,
+		Command: c.config.Entrypoint,
+		Args:    c.config.Command,
+		Stdin:   c.config.Stdin,
+		Ports:   portMappingsToContainerPorts(c.config.PortMappings),
+	}
+
+	ctrSpec := c.config.Spec
+	if ctrSpec == nil {
+		return kubeCtr, volumes
+	}
+
+	if ctrSpec.Process != nil {
+		kubeCtr.WorkingDir = ctrSpec.Process.Cwd
+		kubeCtr.TTY = ctrSpec.Process.Terminal
+		kubeCtr.Env = envVarsToKubeEnvVars(ctrSpec.Process.Env)
+
+		kubeCtr.SecurityContext = c.generateKubeSecurityContext()
+	}
+
+	if ctrSpec.Linux != nil && ctrSpec.Linux.Resources != nil {
+		kubeCtr.Resources = resourcesToKubeResources(ctrSpec.Linux.Resources.Memory, ctrSpec.Linux.Resources.CPU)
+	}
+
+	kubeCtr.VolumeMounts, volumes = c.bindMountsToKubeVolumes(volumes)
+
+	return kubeCtr, volumes
+}
+
+// envVarsToKubeEnvVars converts environment variables in KEY=VALUE form into
+// v1.EnvVars
+// Variables set by libpod itself are left out, as they are not meaningful in
+// Kubernetes
+func envVarsToKubeEnvVars(envs []string) []v1.EnvVar {
+	var kubeEnvs []v1.EnvVar
+	for _, env := range envs {
+		split := strings.SplitN(env, "=", 2)
+		if len(split) != 2 {
+			continue
+		}
+		if split[0] == "container" || split[0] == "HOSTNAME" {
+			continue
+		}
+		kubeEnvs = append(kubeEnvs, v1.EnvVar{
+			Name:  split[0],
+			Value: split[1],
+		})
+	}
+	return kubeEnvs
+}
+
+// portMappingsToContainerPorts converts CNI port mappings into
+// v1.ContainerPorts
+func portMappingsToContainerPorts(portMappings []ocicni.PortMapping) []v1.ContainerPort {
+	var ports []v1.ContainerPort
+	for _, mapping := range portMappings {
+		ports = append(ports, v1.ContainerPort{
+			ContainerPort: mapping.ContainerPort,
+			HostPort:      mapping.HostPort,
+			HostIP:        mapping.HostIP,
+			Protocol:      v1.Protocol(strings.ToUpper(mapping.Protocol)),
+		})
+	}
+	return ports
+}
+
+// ContainerPortsToServicePorts converts the container ports published on the
+// host into v1.ServicePorts
+func ContainerPortsToServicePorts(ports []v1.ContainerPort) []v1.ServicePort {
+	var servicePorts []v1.ServicePort
+	for _, port := range ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		servicePorts = append(servicePorts, v1.ServicePort{
+			Name:       fmt.Sprintf("%d-%s", port.ContainerPort, strings.ToLower(string(port.Protocol))),
+			Protocol:   port.Protocol,
+			Port:       port.HostPort,
+			TargetPort: intstr.FromInt(int(port.ContainerPort)),
+		})
+	}
+	return uniqueServicePortNames(servicePorts)
+}
+
+// uniqueServicePortNames suffixes the names of service ports that are already
+// used by an earlier port, as Kubernetes requires the ports of a service to
+// have distinct names
+// Ports of different containers, or published on several host ports, can
+// share a container port and protocol, and so a name.
+func uniqueServicePortNames(ports []v1.ServicePort) []v1.ServicePort {
+	original := make(map[string]bool)
+	for _, port := range ports {
+		original[port.Name] = true
+	}
+	used := make(map[string]bool)
+	for i := range ports {
+		name := ports[i].Name
+		for n := 1; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d", ports[i].Name, n)
+			if original[name] {
+				// Leave the name to the port it was given to
+				name = ports[i].Name
+			}
+		}
+		used[name] = true
+		ports[i].Name = name
+	}
+	return ports
+}
+
+// resourcesToKubeResources converts the memory and CPU limits of a container
+// into v1.ResourceRequirements
+func resourcesToKubeResources(memory *spec.LinuxMemory, cpu *spec.LinuxCPU) v1.ResourceRequirements {
+	limits := make(v1.ResourceList)
+	if memory != nil && memory.Limit != nil && *memory.Limit > 0 {
+		limits[v1.ResourceMemory] = *resource.NewQuantity(*memory.Limit, resource.BinarySI)
+	}
+	if cpu != nil && cpu.Quota != nil && *cpu.Quota > 0 && cpu.Period != nil && *cpu.Period > 0 {
+		milliCPU := *cpu.Quota * 1000 / int64(*cpu.Period)
+		limits[v1.ResourceCPU] = *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+	}
+	if len(limits) == 0 {
+		return v1.ResourceRequirements{}
+	}
+	return v1.ResourceRequirements{Limits: limits}
+}
+
+// generateKubeSecurityContext generates a v1.SecurityContext from the
+// container's privileges, capabilities, user and root filesystem
+func (c *Container) generateKubeSecurityContext() *v1.SecurityContext {
+	privileged := c.config.Privileged
+	readOnly := c.config.Spec.Root != nil && c.config.Spec.Root.Readonly
+
+	securityContext := &v1.SecurityContext{
+		Privileged:             &privileged,
+		ReadOnlyRootFilesystem: &readOnly,
+	}
+
+	// Privileged containers get all capabilities, so there is no point
+	// listing them
+	if !privileged && c.config.Spec.Process.Capabilities != nil {
+		securityContext.Capabilities = capabilitiesToKubeCapabilities(c.config.Spec.Process.Capabilities.Bounding)
+	}
+
+	if c.config.User != "" {
+		// Kubernetes only supports numeric users
+		user := strings.SplitN(c.config.User, ":", 2)[0]
+		uid, err := strconv.ParseInt(user, 10, 64)
+		if err == nil {
+			securityContext.RunAsUser = &uid
+		} else {
+			logrus.Warnf("Unable to convert non-numeric user %q of container %s for Kubernetes", user, c.ID())
+		}
+	}
+
+	return securityContext
+}
+
+// capabilitiesToKubeCapabilities compares the given capabilities to the
+// default ones and returns the capabilities added and dropped, if any
+func capabilitiesToKubeCapabilities(caps []string) *v1.Capabilities {
+	g := generate.New()
+	defaultCaps := g.Spec().Process.Capabilities.Bounding
+
+	kubeCaps := new(v1.Capabilities)
+	for _, capability := range caps {
+		if !util.StringInSlice(capability, defaultCaps) {
+			kubeCaps.Add = append(kubeCaps.Add, v1.Capability(strings.TrimPrefix(capability, "CAP_")))
+		}
+	}
+	for _, capability := range defaultCaps {
+		if !util.StringInSlice(capability, caps) {
+			kubeCaps.Drop = append(kubeCaps.Drop, v1.Capability(strings.TrimPrefix(capability, "CAP_")))
+		}
+	}
+	if len(kubeCaps.Add) == 0 && len(kubeCaps.Drop) == 0 {
+		return nil
+	}
+	return kubeCaps
+}
+
+// bindMountsToKubeVolumes converts the bind mounts added by the user to the
+// container into v1.VolumeMounts backed by hostPath v1.Volumes
+// Mounts of a host path that already backs one of the given volumes use that
+// volume, and the volumes of other host paths are added to the given volumes,
+// which are returned
+func (c *Container) bindMountsToKubeVolumes(volumes []v1.Volume) ([]v1.VolumeMount, []v1.Volume) {
+	var volumeMounts []v1.VolumeMount
+	for _, mount := range c.config.Spec.Mounts {
+		if mount.Type != "bind" || !util.StringInSlice(mount.Source, c.config.UserVolumes) {
+			continue
+		}
+		name := ""
+		for _, volume := range volumes {
+			if volume.HostPath != nil && volume.HostPath.Path == mount.Source {
+				name = volume.Name
+				break
+			}
+		}
+		if name == "" {
+			name = uniqueVolumeName(hostPathVolumeName(mount.Source), volumes)
+			volumes = append(volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					HostPath: &v1.HostPathVolumeSource{
+						Path: mount.Source,
+					},
+				},
+			})
+		}
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: mount.Destination,
+			ReadOnly:  util.StringInSlice("ro", mount.Options),
+		})
+	}
+	return volumeMounts, volumes
+}
+
+// hostPathVolumeName generates a Kubernetes volume name from a host path
+// Characters not allowed in DNS-1123 labels are replaced by dashes, so
+// several host paths can give the same name. For example, /home/user/data
+// becomes home-user-data-host
+func hostPathVolumeName(hostPath string) string {
+	name := strings.Trim(kubeVolumeNameInvalidChars.ReplaceAllString(strings.ToLower(hostPath), "-"), "-")
+	if len(name) > maxKubeVolumeNameBase {
+		name = strings.TrimRight(name[:maxKubeVolumeNameBase], "-")
+	}
+	if name == "" {
+		return "root-host"
+	}
+	return name + "-host"
+}
+
+// uniqueVolumeName suffixes a volume name with a number if one of the given
+// volumes already has it, as the volumes of a pod must have distinct names
+func uniqueVolumeName(name string, volumes []v1.Volume) string {
+	used := make(map[string]bool)
+	for _, volume := range volumes {
+		used[volume.Name] = true
+	}
+	unique := name
+	for n := 1; used[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", name, n)
+	}
+	return unique
+}
@@ -0,0 +1,113 @@
+package libpod
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+)
+
+func TestEnvVarsToKubeEnvVars(t *testing.T) {
+	envs := []string{"PATH=/usr/bin", "FOO=bar=baz", "container=libpod", "HOSTNAME=abc", "EMPTY="}
+	expected := []v1.EnvVar{
+		{Name: "PATH", Value: "/usr/bin"},
+		{Name: "FOO", Value: "bar=baz"},
+		{Name: "EMPTY", Value: ""},
+	}
+	assert.Equal(t, expected, envVarsToKubeEnvVars(envs))
+}
+
+func TestPortMappingsToServicePorts(t *testing.T) {
+	mappings := []ocicni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{ContainerPort: 53, Protocol: "udp"},
+	}
+	ports := portMappingsToContainerPorts(mappings)
+	assert.Len(t, ports, 2)
+	assert.Equal(t, v1.ProtocolTCP, ports[0].Protocol)
+	assert.Equal(t, v1.ProtocolUDP, ports[1].Protocol)
+
+	servicePorts := ContainerPortsToServicePorts(ports)
+	assert.Len(t, servicePorts, 1)
+	assert.Equal(t, "80-tcp", servicePorts[0].Name)
+	assert.Equal(t, int32(8080), servicePorts[0].Port)
+	assert.Equal(t, int32(80), servicePorts[0].TargetPort.IntVal)
+
+	// Ports sharing a container port and protocol get distinct names,
+	// within a container and across the containers of a pod
+	servicePorts = ContainerPortsToServicePorts(portMappingsToContainerPorts([]ocicni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostPort: 8081, ContainerPort: 80, Protocol: "tcp"},
+	}))
+	servicePorts = uniqueServicePortNames(append(servicePorts, ContainerPortsToServicePorts(ports)...))
+	assert.Len(t, servicePorts, 3)
+	assert.Equal(t, "80-tcp", servicePorts[0].Name)
+	assert.Equal(t, "80-tcp-1", servicePorts[1].Name)
+	assert.Equal(t, "80-tcp-2", servicePorts[2].Name)
+	assert.Equal(t, int32(8080), servicePorts[2].Port)
+}
+
+func TestResourcesToKubeResources(t *testing.T) {
+	limit := int64(512 * 1024 * 1024)
+	quota := int64(150000)
+	period := uint64(100000)
+	resources := resourcesToKubeResources(&spec.LinuxMemory{Limit: &limit}, &spec.LinuxCPU{Quota: &quota, Period: &period})
+	memory := resources.Limits[v1.ResourceMemory]
+	cpu := resources.Limits[v1.ResourceCPU]
+	assert.Equal(t, "512Mi", memory.String())
+	assert.Equal(t, "1500m", cpu.String())
+
+	assert.Nil(t, resourcesToKubeResources(nil, nil).Limits)
+}
+
+func TestCapabilitiesToKubeCapabilities(t *testing.T) {
+	defaultCaps := []string{"CAP_CHOWN", "CAP_DAC_OVERRIDE", "CAP_FSETID", "CAP_FOWNER", "CAP_MKNOD", "CAP_NET_RAW", "CAP_SETGID", "CAP_SETUID", "CAP_SETFCAP", "CAP_SETPCAP", "CAP_NET_BIND_SERVICE", "CAP_SYS_CHROOT", "CAP_KILL", "CAP_AUDIT_WRITE"}
+	assert.Nil(t, capabilitiesToKubeCapabilities(defaultCaps))
+
+	caps := append([]string{"CAP_SYS_ADMIN"}, defaultCaps[1:]...)
+	kubeCaps := capabilitiesToKubeCapabilities(caps)
+	assert.Equal(t, []v1.Capability{"SYS_ADMIN"}, kubeCaps.Add)
+	assert.Equal(t, []v1.Capability{"CHOWN"}, kubeCaps.Drop)
+}
+
+func TestHostPathVolumeName(t *testing.T) {
+	assert.Equal(t, "home-user-my-data-host", hostPathVolumeName("/home/user/my_data/"))
+	assert.Equal(t, "root-host", hostPathVolumeName("/"))
+	assert.Equal(t, "srv-my-app-v1-2-host", hostPathVolumeName("/srv/My App/v1.2"))
+	assert.Len(t, hostPathVolumeName("/"+strings.Repeat("a", 100)), maxKubeVolumeNameBase+len("-host"))
+}
+
+func TestBindMountsToKubeVolumesCollidingPaths(t *testing.T) {
+	newCtr := func(sources ...string) *Container {
+		ctr := &Container{config: &ContainerConfig{Spec: &spec.Spec{}, UserVolumes: sources}}
+		for i, source := range sources {
+			ctr.config.Spec.Mounts = append(ctr.config.Spec.Mounts, spec.Mount{
+				Type:        "bind",
+				Source:      source,
+				Destination: fmt.Sprintf("/mnt/%d", i),
+			})
+		}
+		return ctr
+	}
+
+	mounts, volumes := newCtr("/srv/a_b", "/srv/a-b").bindMountsToKubeVolumes(nil)
+	mounts2, volumes := newCtr("/srv/a-b", "/data/Foo").bindMountsToKubeVolumes(volumes)
+	_, volumes = newCtr("/data/foo").bindMountsToKubeVolumes(volumes)
+
+	assert.Len(t, volumes, 4)
+	paths := make(map[string]string)
+	for _, volume := range volumes {
+		paths[volume.Name] = volume.HostPath.Path
+	}
+	assert.Len(t, paths, 4)
+	assert.Equal(t, "/srv/a_b", paths[mounts[0].Name])
+	assert.Equal(t, "/srv/a-b", paths[mounts[1].Name])
+	assert.Equal(t, "srv-a-b-host-1", mounts[1].Name)
+	// Mounts of the same host path share its volume
+	assert.Equal(t, mounts[1].Name, mounts2[0].Name)
+	assert.Equal(t, "/data/foo", paths["data-foo-host-1"])
+}
@@ -0,0 +1,178 @@
+package logs
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// LogTimeFormat is the format of the timestamps in container logs
+	LogTimeFormat = "2006-01-02T15:04:05.999999999-07:00"
+	// PartialLogType marks a log line that is continued by the next one,
+	// as the container wrote part of a line
+	PartialLogType = "P"
+	// FullLogType marks a log line that ends a line of output
+	FullLogType = "F"
+
+	// tailChunkSize is the number of bytes read at a time when reading a
+	// log file backwards
+	tailChunkSize = 32 * 1024
+	// tailHeaderSize is the number of bytes read at the start of a log line
+	// to find its type, which is enough for the timestamp and stream
+	tailHeaderSize = 64
+)
+
+// LogOptions are the options for reading container logs
+type LogOptions struct {
+	// Follow waits for new lines once all lines in the logs have been
+	// read, until the containers exit, Until has passed or the context is
+	// cancelled
+	Follow bool
+	// Since, if set, skips lines written before it
+	Since time.Time
+	// Until, if set, skips lines written after it
+	Until time.Time
+	// Tail, if not 0, reads only that number of lines at the end of the
+	// logs of each container
+	Tail uint64
+}
+
+// LogLine is a line of output of a container
+type LogLine struct {
+	// Device is the stream the line was written to, stdout or stderr
+	Device string
+	// ParseLogType is PartialLogType or FullLogType
+	ParseLogType string
+	// Time is when the line was written
+	Time time.Time
+	// Msg is the line itself
+	Msg string
+	// CID is the ID of the container that wrote the line
+	CID string
+}
+
+// NewLogLine parses a line of a container log, which holds a timestamp, the
+// stream, the log type and the output, separated by spaces
+func NewLogLine(line string) (*LogLine, error) {
+	splitLine := strings.SplitN(strings.TrimSuffix(line, "\n"), " ", 4)
+	if len(splitLine) < 3 {
+		return nil, errors.Errorf("%q is not a valid container log line", line)
+	}
+	logTime, err := time.Parse(LogTimeFormat, splitLine[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timestamp in container log line %q", line)
+	}
+	if splitLine[1] != "stdout" && splitLine[1] != "stderr" {
+		return nil, errors.Errorf("invalid stream %q in container log line", splitLine[1])
+	}
+	if splitLine[2] != PartialLogType && splitLine[2] != FullLogType {
+		return nil, errors.Errorf("invalid log type %q in container log line", splitLine[2])
+	}
+
+	l := &LogLine{
+		Device:       splitLine[1],
+		ParseLogType: splitLine[2],
+		Time:         logTime,
+	}
+	if len(splitLine) == 4 {
+		l.Msg = splitLine[3]
+	}
+	return l, nil
+}
+
+// Partial returns whether the line is continued by the next one
+func (l *LogLine) Partial() bool {
+	return l.ParseLogType == PartialLogType
+}
+
+// String returns the line in the format of container logs
+func (l *LogLine) String() string {
+	return strings.Join([]string{l.Time.Format(LogTimeFormat), l.Device, l.ParseLogType, l.Msg}, " ")
+}
+
+// Matches returns whether the line was written in the time range of the
+// options
+func (o *LogOptions) Matches(l *LogLine) bool {
+	if !o.Since.IsZero() && l.Time.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && l.Time.After(o.Until) {
+		return false
+	}
+	return true
+}
+
+// TailOffset returns the offset in a container log of the given size at which
+// its last tail lines start
+// The log is read backwards from its end, so only the lines at its end are
+// read. Partial lines count as part of the line that ends them.
+func TailOffset(r io.ReaderAt, size int64, tail uint64) (int64, error) {
+	if tail == 0 {
+		return 0, nil
+	}
+
+	var fullLines uint64
+	buf := make([]byte, tailChunkSize)
+	header := make([]byte, tailHeaderSize)
+	// end is the offset of the newline ending the line being scanned,
+	// or the size of the log for the last line
+	end := size
+	for pos := size; pos > 0; {
+		n := int64(tailChunkSize)
+		if n > pos {
+			n = pos
+		}
+		pos -= n
+		if _, err := r.ReadAt(buf[:n], pos); err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := n - 1; i >= 0; i-- {
+			if buf[i] != '\n' {
+				continue
+			}
+			lineStart := pos + i + 1
+			if lineStart < end {
+				full, err := isFullLine(r, header, lineStart, end)
+				if err != nil {
+					return 0, err
+				}
+				if full {
+					fullLines++
+					if fullLines > tail {
+						return end + 1, nil
+					}
+				}
+			}
+			end = pos + i
+		}
+	}
+
+	// The first line is not preceded by a newline
+	if end > 0 {
+		full, err := isFullLine(r, header, 0, end)
+		if err != nil {
+			return 0, err
+		}
+		if full && fullLines+1 > tail {
+			return end + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// Whether the log line between the given offsets ends a line of output
+func isFullLine(r io.ReaderAt, header []byte, start, end int64) (bool, error) {
+	n := end - start
+	if n > int64(len(header)) {
+		n = int64(len(header))
+	}
+	if _, err := r.ReadAt(header[:n], start); err != nil && err != io.EOF {
+		return false, err
+	}
+	fields := strings.SplitN(string(header[:n]), " ", 4)
+	return len(fields) >= 3 && fields[2] == FullLogType, nil
+}
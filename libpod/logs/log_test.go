@@ -0,0 +1,84 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogLine(t *testing.T) {
+	line, err := NewLogLine("2018-09-20T10:41:09.123456789-05:00 stderr P hello  world\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "stderr", line.Device)
+	assert.True(t, line.Partial())
+	assert.Equal(t, "hello  world", line.Msg)
+	assert.Equal(t, 123456789, line.Time.Nanosecond())
+	assert.Equal(t, "2018-09-20T10:41:09.123456789-05:00 stderr P hello  world", line.String())
+
+	line, err = NewLogLine("2018-09-20T10:41:09.123456789-05:00 stdout F")
+	assert.NoError(t, err)
+	assert.False(t, line.Partial())
+	assert.Equal(t, "", line.Msg)
+
+	_, err = NewLogLine("not a log line")
+	assert.Error(t, err)
+	_, err = NewLogLine("2018-09-20T10:41:09.123456789-05:00 stdin F hello")
+	assert.Error(t, err)
+}
+
+func TestLogOptionsMatches(t *testing.T) {
+	when := time.Date(2018, 9, 20, 10, 41, 9, 0, time.UTC)
+	line := &LogLine{Time: when}
+
+	assert.True(t, (&LogOptions{}).Matches(line))
+	assert.True(t, (&LogOptions{Since: when, Until: when}).Matches(line))
+	assert.False(t, (&LogOptions{Since: when.Add(time.Second)}).Matches(line))
+	assert.False(t, (&LogOptions{Until: when.Add(-time.Second)}).Matches(line))
+}
+
+func TestTailOffset(t *testing.T) {
+	lines := []string{
+		"2018-09-20T10:41:09.1-05:00 stdout F one",
+		"2018-09-20T10:41:09.2-05:00 stdout P tw",
+		"2018-09-20T10:41:09.3-05:00 stdout F o",
+		"2018-09-20T10:41:09.4-05:00 stderr F three",
+	}
+	log := strings.Join(lines, "\n") + "\n"
+	r := strings.NewReader(log)
+	size := int64(len(log))
+
+	offset, err := TailOffset(r, size, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, lines[3]+"\n", log[offset:])
+
+	// The partial line belongs to the line that ends it
+	offset, err = TailOffset(r, size, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join(lines[1:], "\n")+"\n", log[offset:])
+
+	offset, err = TailOffset(r, size, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	offset, err = TailOffset(r, size, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+
+	// A line that is still being written is read too
+	offset, err = TailOffset(r, size-1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, lines[3], log[offset:size-1])
+}
+
+func TestTailOffsetLargeLog(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 5000; i++ {
+		b.WriteString("2018-09-20T10:41:09.1-05:00 stdout F some output of the container\n")
+	}
+	log := b.String()
+
+	offset, err := TailOffset(strings.NewReader(log), int64(len(log)), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, strings.Count(log[offset:], "\n"))
+}
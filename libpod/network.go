@@ -0,0 +1,355 @@
+package libpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BridgeNetworkDriver is the network driver connecting containers to a
+	// bridge on the host
+	BridgeNetworkDriver = "bridge"
+	// MacvlanNetworkDriver is the network driver connecting containers
+	// directly to a network interface of the host
+	MacvlanNetworkDriver = "macvlan"
+
+	// cniVersion is the version of the CNI specification networks are
+	// created with
+	cniVersion = "0.3.0"
+	// bridgeNamePrefix is the prefix of the names of the bridges created for
+	// bridge networks
+	bridgeNamePrefix = "cni-podman"
+)
+
+// defaultSubnetPool is the range subnets are picked from for networks created
+// without one
+var defaultSubnetPool = net.IPNet{IP: net.IPv4(10, 89, 0, 0).To4(), Mask: net.CIDRMask(16, 32)}
+
+// Network is a CNI network, configured by a file in the runtime's CNI
+// configuration directory
+type Network struct {
+	config *libcni.NetworkConfigList
+	path   string
+}
+
+// NetworkInspect represents the data we want to display for
+// podman network inspect
+type NetworkInspect struct {
+	Name     string          `json:"Name"`
+	Driver   string          `json:"Driver"`
+	Path     string          `json:"Path"`
+	Subnets  []string        `json:"Subnets"`
+	Internal bool            `json:"Internal"`
+	Config   json.RawMessage `json:"Config"`
+}
+
+// networkCreateConfig holds the settings a network is created with
+type networkCreateConfig struct {
+	name          string
+	driver        string
+	subnet        *net.IPNet
+	gateway       net.IP
+	ipRange       *net.IPNet
+	internal      bool
+	macvlanParent string
+}
+
+// bridgePluginConfig is the configuration of the bridge CNI plugin
+type bridgePluginConfig struct {
+	Type      string        `json:"type"`
+	Bridge    string        `json:"bridge"`
+	IsGateway bool          `json:"isGateway"`
+	IPMasq    bool          `json:"ipMasq"`
+	IPAM      hostLocalIPAM `json:"ipam"`
+}
+
+// macvlanPluginConfig is the configuration of the macvlan CNI plugin
+type macvlanPluginConfig struct {
+	Type   string        `json:"type"`
+	Master string        `json:"master"`
+	IPAM   hostLocalIPAM `json:"ipam"`
+}
+
+// portMapPluginConfig is the configuration of the portmap CNI plugin
+type portMapPluginConfig struct {
+	Type         string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// hostLocalIPAM is the configuration of the host-local IPAM plugin
+type hostLocalIPAM struct {
+	Type   string             `json:"type"`
+	Ranges [][]hostLocalRange `json:"ranges"`
+	Routes []hostLocalRoute   `json:"routes,omitempty"`
+}
+
+// hostLocalRange is a range of IPs assigned by the host-local IPAM plugin
+type hostLocalRange struct {
+	Subnet     string `json:"subnet"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+// hostLocalRoute is a route added by the host-local IPAM plugin
+type hostLocalRoute struct {
+	Dst string `json:"dst"`
+}
+
+// Name returns the network's name
+func (n *Network) Name() string {
+	return n.config.Name
+}
+
+// Path returns the path of the network's configuration file
+func (n *Network) Path() string {
+	return n.path
+}
+
+// Driver returns the type of the network's main CNI plugin
+func (n *Network) Driver() string {
+	return n.config.Plugins[0].Network.Type
+}
+
+// Subnets returns the subnets IPs are assigned from in the network
+func (n *Network) Subnets() []*net.IPNet {
+	return networkSubnets(n.config)
+}
+
+// Internal returns whether the network is a bridge network without access to
+// other networks
+func (n *Network) Internal() bool {
+	if n.Driver() != BridgeNetworkDriver {
+		return false
+	}
+	bridge := bridgePluginConfig{}
+	if err := json.Unmarshal(n.config.Plugins[0].Bytes, &bridge); err != nil {
+		return false
+	}
+	return !bridge.IsGateway && !bridge.IPMasq
+}
+
+// Inspect returns the network's configuration for inspection
+func (n *Network) Inspect() *NetworkInspect {
+	subnets := []string{}
+	for _, subnet := range n.Subnets() {
+		subnets = append(subnets, subnet.String())
+	}
+	return &NetworkInspect{
+		Name:     n.Name(),
+		Driver:   n.Driver(),
+		Path:     n.Path(),
+		Subnets:  subnets,
+		Internal: n.Internal(),
+		Config:   json.RawMessage(n.config.Bytes),
+	}
+}
+
+// Get the name of the bridge of a bridge network
+func (n *Network) bridgeName() string {
+	if n.Driver() != BridgeNetworkDriver {
+		return ""
+	}
+	bridge := bridgePluginConfig{}
+	if err := json.Unmarshal(n.config.Plugins[0].Bytes, &bridge); err != nil {
+		return ""
+	}
+	return bridge.Bridge
+}
+
+// Load the CNI networks configured in the given directory, in the order OCICNI
+// considers them when choosing the default network
+// Files that cannot be loaded, and networks with the same name as one loaded
+// before, are skipped
+func loadNetworks(dir string) ([]*Network, error) {
+	files, err := libcni.ConfFiles(dir, []string{".conf", ".conflist", ".json"})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading CNI configuration directory %s", dir)
+	}
+	sort.Strings(files)
+
+	names := make(map[string]bool)
+	var networks []*Network
+	for _, file := range files {
+		var confList *libcni.NetworkConfigList
+		if strings.HasSuffix(file, ".conflist") {
+			confList, err = libcni.ConfListFromFile(file)
+		} else {
+			var conf *libcni.NetworkConfig
+			conf, err = libcni.ConfFromFile(file)
+			if err == nil {
+				confList, err = libcni.ConfListFromConf(conf)
+			}
+		}
+		if err != nil {
+			logrus.Debugf("Skipping CNI configuration file %s: %v", file, err)
+			continue
+		}
+		if len(confList.Plugins) == 0 || names[confList.Name] {
+			continue
+		}
+		names[confList.Name] = true
+		networks = append(networks, &Network{config: confList, path: file})
+	}
+	return networks, nil
+}
+
+// Get the subnets IPs are assigned from by the IPAM plugins of a network
+func networkSubnets(network *libcni.NetworkConfigList) []*net.IPNet {
+	var subnets []*net.IPNet
+	for _, plugin := range network.Plugins {
+		ipamConf := struct {
+			IPAM struct {
+				Subnet string `json:"subnet"`
+				Ranges [][]struct {
+					Subnet string `json:"subnet"`
+				} `json:"ranges"`
+			} `json:"ipam"`
+		}{}
+		if err := json.Unmarshal(plugin.Bytes, &ipamConf); err != nil {
+			continue
+		}
+
+		cidrs := []string{ipamConf.IPAM.Subnet}
+		for _, rangeSet := range ipamConf.IPAM.Ranges {
+			for _, ipRange := range rangeSet {
+				cidrs = append(cidrs, ipRange.Subnet)
+			}
+		}
+		for _, cidr := range cidrs {
+			if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+	return subnets
+}
+
+// Generate the CNI configuration list of a new network
+// The subnet and, for bridge networks, the bridge name must already be chosen
+func generateNetworkConfig(config *networkCreateConfig, bridgeName string) ([]byte, error) {
+	ipRange := hostLocalRange{
+		Subnet: config.subnet.String(),
+	}
+	if config.gateway != nil {
+		ipRange.Gateway = config.gateway.String()
+	}
+	if config.ipRange != nil {
+		ipRange.RangeStart = firstIP(config.ipRange).String()
+		ipRange.RangeEnd = lastIP(config.ipRange).String()
+	}
+	ipam := hostLocalIPAM{
+		Type:   "host-local",
+		Ranges: [][]hostLocalRange{{ipRange}},
+	}
+	if !config.internal {
+		ipam.Routes = []hostLocalRoute{{Dst: "0.0.0.0/0"}}
+	}
+
+	var plugins []interface{}
+	switch config.driver {
+	case BridgeNetworkDriver:
+		plugins = append(plugins, bridgePluginConfig{
+			Type:      BridgeNetworkDriver,
+			Bridge:    bridgeName,
+			IsGateway: !config.internal,
+			IPMasq:    !config.internal,
+			IPAM:      ipam,
+		})
+		if !config.internal {
+			plugins = append(plugins, portMapPluginConfig{
+				Type:         "portmap",
+				Capabilities: map[string]bool{"portMappings": true},
+			})
+		}
+	case MacvlanNetworkDriver:
+		plugins = append(plugins, macvlanPluginConfig{
+			Type:   MacvlanNetworkDriver,
+			Master: config.macvlanParent,
+			IPAM:   ipam,
+		})
+	default:
+		return nil, errors.Wrapf(ErrInvalidArg, "unsupported network driver %q", config.driver)
+	}
+
+	confList := struct {
+		CNIVersion string        `json:"cniVersion"`
+		Name       string        `json:"name"`
+		Plugins    []interface{} `json:"plugins"`
+	}{
+		CNIVersion: cniVersion,
+		Name:       config.name,
+		Plugins:    plugins,
+	}
+	return json.MarshalIndent(confList, "", "    ")
+}
+
+// Pick a subnet for a new network from the default subnet pool, which does not
+// overlap any of the given subnets
+func freeSubnet(used []*net.IPNet) (*net.IPNet, error) {
+	for i := 0; i < 256; i++ {
+		subnet := &net.IPNet{
+			IP:   net.IPv4(defaultSubnetPool.IP[0], defaultSubnetPool.IP[1], byte(i), 0).To4(),
+			Mask: net.CIDRMask(24, 32),
+		}
+		free := true
+		for _, other := range used {
+			if subnetsOverlap(subnet, other) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return subnet, nil
+		}
+	}
+	return nil, errors.Errorf("no free subnet left in %s, a subnet must be given", defaultSubnetPool.String())
+}
+
+// Pick the name of the bridge of a new bridge network, which is not used by
+// any of the given networks or host interfaces
+func freeBridgeName(networks []*Network, interfaces []string) string {
+	used := make(map[string]bool)
+	for _, network := range networks {
+		used[network.bridgeName()] = true
+	}
+	for _, iface := range interfaces {
+		used[iface] = true
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s%d", bridgeNamePrefix, i)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// Whether two subnets share any IPs
+func subnetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// Get the first IP of a subnet that can be assigned, after the network address
+func firstIP(subnet *net.IPNet) net.IP {
+	ip := make(net.IP, len(subnet.IP))
+	copy(ip, subnet.IP)
+	ip[len(ip)-1]++
+	return ip
+}
+
+// Get the last IP of a subnet that can be assigned, before the broadcast address
+func lastIP(subnet *net.IPNet) net.IP {
+	ip := make(net.IP, len(subnet.IP))
+	for i := range subnet.IP {
+		ip[i] = subnet.IP[i] | ^subnet.Mask[i]
+	}
+	ip[len(ip)-1]--
+	return ip
+}
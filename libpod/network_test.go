@@ -0,0 +1,148 @@
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseCIDR(t *testing.T, cidr string) *net.IPNet {
+	_, subnet, err := net.ParseCIDR(cidr)
+	assert.NoError(t, err)
+	return subnet
+}
+
+func TestGenerateNetworkConfig(t *testing.T) {
+	config := &networkCreateConfig{
+		name:    "test",
+		driver:  BridgeNetworkDriver,
+		subnet:  parseCIDR(t, "10.90.0.0/24"),
+		gateway: net.ParseIP("10.90.0.254").To4(),
+		ipRange: parseCIDR(t, "10.90.0.128/25"),
+	}
+	contents, err := generateNetworkConfig(config, "cni-podman3")
+	assert.NoError(t, err)
+
+	confList, err := libcni.ConfListFromBytes(contents)
+	assert.NoError(t, err)
+	network := &Network{config: confList}
+	assert.Equal(t, "test", network.Name())
+	assert.Equal(t, BridgeNetworkDriver, network.Driver())
+	assert.Equal(t, "cni-podman3", network.bridgeName())
+	assert.False(t, network.Internal())
+	assert.Len(t, confList.Plugins, 2)
+	assert.Equal(t, "portmap", confList.Plugins[1].Network.Type)
+	subnets := network.Subnets()
+	assert.Len(t, subnets, 1)
+	assert.Equal(t, "10.90.0.0/24", subnets[0].String())
+	assert.Contains(t, string(contents), `"rangeStart": "10.90.0.129"`)
+	assert.Contains(t, string(contents), `"rangeEnd": "10.90.0.254"`)
+	assert.Contains(t, string(contents), `"gateway": "10.90.0.254"`)
+
+	// Internal networks have no gateway, routes or published ports
+	config = &networkCreateConfig{
+		name:     "internal",
+		driver:   BridgeNetworkDriver,
+		subnet:   parseCIDR(t, "10.90.1.0/24"),
+		internal: true,
+	}
+	contents, err = generateNetworkConfig(config, "cni-podman4")
+	assert.NoError(t, err)
+	confList, err = libcni.ConfListFromBytes(contents)
+	assert.NoError(t, err)
+	network = &Network{config: confList}
+	assert.True(t, network.Internal())
+	assert.Len(t, confList.Plugins, 1)
+	assert.NotContains(t, string(contents), "routes")
+
+	config = &networkCreateConfig{
+		name:          "lan",
+		driver:        MacvlanNetworkDriver,
+		subnet:        parseCIDR(t, "192.168.1.0/24"),
+		macvlanParent: "eth0",
+	}
+	contents, err = generateNetworkConfig(config, "")
+	assert.NoError(t, err)
+	confList, err = libcni.ConfListFromBytes(contents)
+	assert.NoError(t, err)
+	network = &Network{config: confList}
+	assert.Equal(t, MacvlanNetworkDriver, network.Driver())
+	assert.Contains(t, string(contents), `"master": "eth0"`)
+}
+
+func TestFreeSubnetAndBridgeName(t *testing.T) {
+	subnet, err := freeSubnet([]*net.IPNet{
+		parseCIDR(t, "10.88.0.0/16"),
+		parseCIDR(t, "10.89.0.0/24"),
+		parseCIDR(t, "10.89.1.128/25"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "10.89.2.0/24", subnet.String())
+
+	_, err = freeSubnet([]*net.IPNet{parseCIDR(t, "10.0.0.0/8")})
+	assert.Error(t, err)
+
+	confList, err := libcni.ConfListFromBytes([]byte(testNetworkConfList))
+	assert.NoError(t, err)
+	networks := []*Network{{config: confList}}
+	assert.Equal(t, "cni-podman1", freeBridgeName(networks, []string{"lo", "eth0"}))
+	assert.Equal(t, "cni-podman2", freeBridgeName(networks, []string{"cni-podman1"}))
+}
+
+func TestValidateNetworkCreateConfig(t *testing.T) {
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{driver: BridgeNetworkDriver}))
+	assert.NoError(t, validateNetworkCreateConfig(&networkCreateConfig{name: "test", driver: BridgeNetworkDriver}))
+
+	// Gateways and IP ranges must be in the given subnet
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:    "test",
+		driver:  BridgeNetworkDriver,
+		gateway: net.ParseIP("10.90.0.1"),
+	}))
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:    "test",
+		driver:  BridgeNetworkDriver,
+		subnet:  parseCIDR(t, "10.90.0.0/24"),
+		gateway: net.ParseIP("10.90.1.1"),
+	}))
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:    "test",
+		driver:  BridgeNetworkDriver,
+		subnet:  parseCIDR(t, "10.90.0.0/24"),
+		ipRange: parseCIDR(t, "10.90.0.0/16"),
+	}))
+	assert.NoError(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:    "test",
+		driver:  BridgeNetworkDriver,
+		subnet:  parseCIDR(t, "10.90.0.0/24"),
+		gateway: net.ParseIP("10.90.0.1"),
+		ipRange: parseCIDR(t, "10.90.0.64/26"),
+	}))
+
+	// Macvlan networks need a parent and a subnet, and cannot be internal
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:   "lan",
+		driver: MacvlanNetworkDriver,
+		subnet: parseCIDR(t, "192.168.1.0/24"),
+	}))
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:          "lan",
+		driver:        MacvlanNetworkDriver,
+		macvlanParent: "eth0",
+	}))
+	assert.Error(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:          "lan",
+		driver:        MacvlanNetworkDriver,
+		subnet:        parseCIDR(t, "192.168.1.0/24"),
+		macvlanParent: "eth0",
+		internal:      true,
+	}))
+	assert.NoError(t, validateNetworkCreateConfig(&networkCreateConfig{
+		name:          "lan",
+		driver:        MacvlanNetworkDriver,
+		subnet:        parseCIDR(t, "192.168.1.0/24"),
+		macvlanParent: "eth0",
+	}))
+}
@@ -1,22 +1,37 @@
 package libpod
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/types"
 	cnitypes "github.com/containernetworking/cni/pkg/types/current"
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/projectatomic/libpod/utils"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
+// loopbackNetworkConfig is the configuration of the CNI network bringing up
+// the loopback interface of network namespaces
+var loopbackNetworkConfig = []byte(`{
+	"cniVersion": "0.2.0",
+	"name": "cni-loopback",
+	"plugins": [{
+		"type": "loopback"
+	}]
+}`)
+
 // Get an OCICNI network config
 func getPodNetwork(id, name, nsPath string, ports []ocicni.PortMapping) ocicni.PodNetwork {
 	return ocicni.PodNetwork{
@@ -28,39 +43,391 @@ func getPodNetwork(id, name, nsPath string, ports []ocicni.PortMapping) ocicni.P
 	}
 }
 
+// Get the CNI configuration to run the plugins of a network with
+// Vendor specific plugins are looked up as OCICNI does
+func (r *Runtime) getCNIConfig(network *libcni.NetworkConfigList) *libcni.CNIConfig {
+	paths := append([]string{}, r.config.CNIPluginDir...)
+	paths = append(paths, fmt.Sprintf(ocicni.VendorCNIDirTemplate, "", network.Plugins[0].Network.Type))
+	return &libcni.CNIConfig{Path: paths}
+}
+
+// Get the CNI runtime config for attaching a container to one of its networks,
+// or detaching it, with the given interface name
+// Port mappings, and the static IP and MAC address, only apply to the
+// container's first network
+func (r *Runtime) getCNIRuntimeConf(ctr *Container, nsPath, network, ifName string) *libcni.RuntimeConf {
+	rt := &libcni.RuntimeConf{
+		ContainerID: ctr.ID(),
+		NetNS:       nsPath,
+		IfName:      ifName,
+		Args: [][2]string{
+			{"IgnoreUnknown", "1"},
+			{"K8S_POD_NAMESPACE", ctr.Name()}, // TODO is there something else we should put here? We don't know about Kube namespaces
+			{"K8S_POD_NAME", ctr.Name()},
+			{"K8S_POD_INFRA_CONTAINER_ID", ctr.ID()},
+		},
+	}
+
+	if network != r.containerNetworks(ctr)[0] {
+		return rt
+	}
+
+	if ctr.config.StaticIP != nil {
+		rt.Args = append(rt.Args, [2]string{"IP", ctr.config.StaticIP.String()})
+	}
+	if ctr.config.StaticMAC != nil {
+		rt.Args = append(rt.Args, [2]string{"MAC", ctr.config.StaticMAC.String()})
+	}
+	if len(ctr.config.PortMappings) > 0 {
+		rt.CapabilityArgs = map[string]interface{}{
+			"portMappings": ctr.config.PortMappings,
+		}
+	}
+
+	return rt
+}
+
+// Get the configuration of one of the CNI networks a container joins
+func (r *Runtime) getCNINetwork(ctr *Container, network string) (*libcni.NetworkConfigList, error) {
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if n.Name() == network {
+			return n.config, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNoSuchNetwork, "no configuration of CNI network %q of container %s found in %s", network, ctr.ID(), r.config.CNIConfigDir)
+}
+
+// Bring up the loopback interface of a container's network namespace
+func (r *Runtime) setupLoopback(ctr *Container, nsPath string) error {
+	loNetwork, err := libcni.ConfListFromBytes(loopbackNetworkConfig)
+	if err != nil {
+		return errors.Wrapf(ErrInternal, "error parsing loopback network configuration: %v", err)
+	}
+	if _, err := r.getCNIConfig(loNetwork).AddNetworkList(loNetwork, r.getCNIRuntimeConf(ctr, nsPath, loNetwork.Name, "lo")); err != nil {
+		return errors.Wrapf(err, "error bringing up loopback interface of container %s", ctr.ID())
+	}
+	return nil
+}
+
 // Create and configure a new network namespace for a container
+// The container is attached to each of its networks in turn, with interfaces
+// eth0, eth1 and so on
 func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) (err error) {
-	podNetwork := getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.config.PortMappings)
+	if err := r.setupLoopback(ctr, ctrNS.Path()); err != nil {
+		return err
+	}
+
+	networks := r.containerNetworks(ctr)
+	status := make(map[string]*cnitypes.Result)
+	defer func() {
+		if err != nil {
+			for network, result := range status {
+				for _, ip := range result.IPs {
+					iptablesDNS("-D", ip.Address.IP.String())
+				}
+				if err2 := r.detachNetwork(ctr, ctrNS.Path(), network, interfaceName(result)); err2 != nil {
+					logrus.Errorf("Error tearing down partially created network namespace for container %s: %v", ctr.ID(), err2)
+				}
+			}
+		}
+	}()
 
-	result, err := r.netPlugin.SetUpPod(podNetwork)
+	for i, network := range networks {
+		result, err := r.attachNetwork(ctr, ctrNS.Path(), network, fmt.Sprintf("eth%d", i))
+		if err != nil {
+			return err
+		}
+		status[network] = result
+	}
+
+	ctr.state.NetNS = ctrNS
+	ctr.state.NetworkStatus = status
+	ctr.state.IPs, ctr.state.Routes, ctr.state.Interfaces = mergeNetworkStatus(networks, status)
+
+	return nil
+}
+
+// Attach a container's network namespace to one of its networks, creating the
+// interface with the given name
+func (r *Runtime) attachNetwork(ctr *Container, nsPath, network, ifName string) (_ *cnitypes.Result, err error) {
+	cniNetwork, err := r.getCNINetwork(ctr, network)
 	if err != nil {
-		return errors.Wrapf(err, "error configuring network namespace for container %s", ctr.ID())
+		return nil, err
+	}
+	cniConfig := r.getCNIConfig(cniNetwork)
+	rt := r.getCNIRuntimeConf(ctr, nsPath, network, ifName)
+
+	result, err := cniConfig.AddNetworkList(cniNetwork, rt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error attaching container %s to network %s", ctr.ID(), network)
 	}
 	defer func() {
 		if err != nil {
-			if err2 := r.netPlugin.TearDownPod(podNetwork); err2 != nil {
-				logrus.Errorf("Error tearing down partially created network namespace for container %s: %v", ctr.ID(), err2)
+			if err2 := cniConfig.DelNetworkList(cniNetwork, rt); err2 != nil {
+				logrus.Errorf("Error detaching container %s from network %s: %v", ctr.ID(), network, err2)
 			}
 		}
 	}()
 
-	logrus.Debugf("Response from CNI plugins: %v", result.String())
+	logrus.Debugf("Response from CNI plugins of network %s: %v", network, result.String())
 
 	resultStruct, err := cnitypes.GetResult(result)
 	if err != nil {
-		return errors.Wrapf(err, "error parsing result from CNI plugins")
+		return nil, errors.Wrapf(err, "error parsing result from CNI plugins of network %s", network)
 	}
 
-	ctr.state.NetNS = ctrNS
-	ctr.state.IPs = resultStruct.IPs
-	ctr.state.Routes = resultStruct.Routes
+	// Plugins ignore arguments they do not know, so make sure the static
+	// IP and MAC address requested were honored
+	if network == r.containerNetworks(ctr)[0] {
+		if err := checkStaticNetwork(ctr, resultStruct); err != nil {
+			return nil, err
+		}
+	}
 
 	// We need to temporarily use iptables to allow the container
 	// to resolve DNS until this issue is fixed upstream.
 	// https://github.com/containernetworking/plugins/pull/75
-	if resultStruct.IPs != nil {
-		for _, ip := range resultStruct.IPs {
-			iptablesDNS("-I", ip.Address.IP.String())
+	for _, ip := range resultStruct.IPs {
+		iptablesDNS("-I", ip.Address.IP.String())
+	}
+
+	return resultStruct, nil
+}
+
+// Detach a container's network namespace from one of its networks, removing
+// the interface with the given name
+func (r *Runtime) detachNetwork(ctr *Container, nsPath, network, ifName string) error {
+	cniNetwork, err := r.getCNINetwork(ctr, network)
+	if err != nil {
+		return err
+	}
+	rt := r.getCNIRuntimeConf(ctr, nsPath, network, ifName)
+	if err := r.getCNIConfig(cniNetwork).DelNetworkList(cniNetwork, rt); err != nil {
+		return errors.Wrapf(err, "error detaching container %s from network %s", ctr.ID(), network)
+	}
+	return nil
+}
+
+// Merge the results of attaching a container to its networks into its IPs,
+// routes and interfaces, in the order of the networks
+// The interface indexes of the IPs are adjusted to point into the merged
+// interfaces
+func mergeNetworkStatus(networks []string, status map[string]*cnitypes.Result) ([]*cnitypes.IPConfig, []*types.Route, []*cnitypes.Interface) {
+	var (
+		ips        []*cnitypes.IPConfig
+		routes     []*types.Route
+		interfaces []*cnitypes.Interface
+	)
+	for _, network := range networks {
+		result, ok := status[network]
+		if !ok {
+			continue
+		}
+		offset := len(interfaces)
+		for _, ip := range result.IPs {
+			ipCopy := *ip
+			if ip.Interface != nil {
+				index := *ip.Interface + offset
+				ipCopy.Interface = &index
+			}
+			ips = append(ips, &ipCopy)
+		}
+		routes = append(routes, result.Routes...)
+		interfaces = append(interfaces, result.Interfaces...)
+	}
+	return ips, routes, interfaces
+}
+
+// Get the name of the interface a network created in the container's network
+// namespace
+func interfaceName(result *cnitypes.Result) string {
+	for _, iface := range result.Interfaces {
+		if iface.Sandbox != "" {
+			return iface.Name
+		}
+	}
+	return ""
+}
+
+// Pick the name of the interface for a network the container is attached to,
+// which is not used by the networks it is already attached to
+func freeInterfaceName(status map[string]*cnitypes.Result) string {
+	used := make(map[string]bool)
+	for _, result := range status {
+		used[interfaceName(result)] = true
+	}
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("eth%d", i)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// Check that the CNI plugins assigned the container the static IP and MAC
+// address it requested
+func checkStaticNetwork(ctr *Container, result *cnitypes.Result) error {
+	if ctr.config.StaticIP != nil {
+		found := false
+		for _, ip := range result.IPs {
+			if ip.Address.IP.Equal(ctr.config.StaticIP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Wrapf(ErrNetworkUnsupported, "container %s was not assigned the requested IP %s, the IPAM plugin of its network must support static IPs", ctr.ID(), ctr.config.StaticIP)
+		}
+	}
+
+	if ctr.config.StaticMAC != nil {
+		found := false
+		for _, iface := range result.Interfaces {
+			if iface.Sandbox == "" {
+				continue
+			}
+			if mac, err := net.ParseMAC(iface.Mac); err == nil && bytes.Equal(mac, ctr.config.StaticMAC) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Wrapf(ErrNetworkUnsupported, "container %s was not assigned the requested MAC address %s, a plugin of its network must support static MAC addresses", ctr.ID(), ctr.config.StaticMAC)
+		}
+	}
+
+	return nil
+}
+
+// Get the name of the CNI network containers join when they are not given
+// any network
+// This is the configured default network if it exists, and the first network
+// in the CNI configuration directory otherwise
+func (r *Runtime) defaultNetworkName() string {
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		logrus.Debugf("Error loading CNI networks: %v", err)
+		return r.config.CNIDefaultNetwork
+	}
+	for _, network := range networks {
+		if network.Name() == r.config.CNIDefaultNetwork {
+			return network.Name()
+		}
+	}
+	if len(networks) == 0 {
+		return r.config.CNIDefaultNetwork
+	}
+	logrus.Debugf("Default CNI network %q not found in %s, using network %s", r.config.CNIDefaultNetwork, r.config.CNIConfigDir, networks[0].Name())
+	return networks[0].Name()
+}
+
+// Get the names of the CNI networks a container joins
+func (r *Runtime) containerNetworks(ctr *Container) []string {
+	return networkNames(ctr, r.defaultNetworkName())
+}
+
+// Get the names of the CNI networks a container's network namespace is
+// attached to
+// These are the networks whose status was recorded when they were attached,
+// which stay right even if the default network changes. Containers set up
+// before the status of each network was recorded are attached to the networks
+// they join.
+func (r *Runtime) attachedNetworks(ctr *Container) []string {
+	if len(ctr.state.NetworkStatus) == 0 {
+		return r.containerNetworks(ctr)
+	}
+	var networks []string
+	for network := range ctr.state.NetworkStatus {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// Get the names of the CNI networks a container joins, given the name of the
+// default network
+func networkNames(ctr *Container, defaultNetwork string) []string {
+	if len(ctr.config.Networks) > 0 {
+		return ctr.config.Networks
+	}
+	return []string{defaultNetwork}
+}
+
+// Check that the CNI networks a container joins exist, and get them
+func (r *Runtime) lookupContainerNetworks(ctr *Container) ([]*Network, error) {
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctrNetworks []*Network
+	for _, name := range r.containerNetworks(ctr) {
+		found := false
+		for _, network := range networks {
+			if network.Name() == name {
+				ctrNetworks = append(ctrNetworks, network)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Wrapf(ErrNoSuchNetwork, "no configuration of CNI network %q found in %s", name, r.config.CNIConfigDir)
+		}
+	}
+	return ctrNetworks, nil
+}
+
+// Validate the static IP requested for a container
+// It must be in a subnet of the container's network, and not be requested by
+// another container on the same network
+func (r *Runtime) validateStaticIP(ctr *Container) error {
+	networks, err := r.lookupContainerNetworks(ctr)
+	if err != nil {
+		return err
+	}
+	network := networks[0]
+	subnets := network.Subnets()
+	inSubnet := false
+	for _, subnet := range subnets {
+		if subnet.Contains(ctr.config.StaticIP) {
+			inSubnet = true
+			break
+		}
+	}
+	if !inSubnet {
+		var cidrs []string
+		for _, subnet := range subnets {
+			cidrs = append(cidrs, subnet.String())
+		}
+		return errors.Wrapf(ErrInvalidArg, "IP %s is not in the subnets of network %s (%s)", ctr.config.StaticIP, network.Name(), strings.Join(cidrs, ", "))
+	}
+
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return err
+	}
+	if other := staticIPConflict(ctr, ctrs, r.defaultNetworkName()); other != nil {
+		return errors.Wrapf(ErrInvalidArg, "IP %s is already requested by container %s on network %s", ctr.config.StaticIP, other.ID(), network.Name())
+	}
+	return nil
+}
+
+// Get the container requesting the same static IP as a container in its
+// network, if any
+// Static IPs only apply to the first network of a container, so containers
+// whose first networks differ never conflict
+func staticIPConflict(ctr *Container, ctrs []*Container, defaultNetwork string) *Container {
+	network := networkNames(ctr, defaultNetwork)[0]
+	for _, other := range ctrs {
+		if other.ID() == ctr.ID() || !other.config.StaticIP.Equal(ctr.config.StaticIP) {
+			continue
+		}
+		if networkNames(other, defaultNetwork)[0] == network {
+			return other
 		}
 	}
 	return nil
@@ -177,18 +544,27 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 
 	logrus.Debugf("Tearing down network namespace at %s for container %s", ctr.state.NetNS.Path(), ctr.ID())
 
-	podNetwork := getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.config.PortMappings)
+	// Detach the container from every network even if some fail, and
+	// report all the failures once the network namespace is closed
+	var detachErrors *multierror.Error
+	for i, network := range r.attachedNetworks(ctr) {
+		// Containers set up before the status of each network was
+		// recorded have their interfaces in the order of their networks
+		ifName := fmt.Sprintf("eth%d", i)
+		if result, ok := ctr.state.NetworkStatus[network]; ok {
+			ifName = interfaceName(result)
+		}
 
-	// The network may have already been torn down, so don't fail here, just log
-	if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
-		logrus.Errorf("Failed to tear down network namespace for container %s: %v", ctr.ID(), err)
+		if err := r.detachNetwork(ctr, ctr.state.NetNS.Path(), network, ifName); err != nil {
+			detachErrors = multierror.Append(detachErrors, err)
+		}
 	}
 
 	if err := ctr.state.NetNS.Close(); err != nil {
-		return errors.Wrapf(err, "error closing network namespace for container %s", ctr.ID())
+		return multierror.Append(detachErrors, errors.Wrapf(err, "error closing network namespace for container %s", ctr.ID()))
 	}
 
 	ctr.state.NetNS = nil
 
-	return nil
+	return detachErrors.ErrorOrNil()
 }
@@ -0,0 +1,181 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNetworkConfList = `{
+    "cniVersion": "0.3.0",
+    "name": "podman",
+    "plugins": [
+      {
+        "type": "bridge",
+        "ipam": {
+            "type": "host-local",
+            "subnet": "10.88.0.0/16"
+        }
+      },
+      {
+        "type": "portmap"
+      }
+    ]
+}`
+
+const testNetworkConf = `{
+    "cniVersion": "0.3.0",
+    "name": "ranges",
+    "type": "bridge",
+    "ipam": {
+        "type": "host-local",
+        "ranges": [
+            [{"subnet": "10.89.0.0/24"}],
+            [{"subnet": "10.89.1.0/24"}]
+        ]
+    }
+}`
+
+func TestLoadNetworksAndSubnets(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_network_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "87-podman.conflist"), []byte(testNetworkConfList), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "90-ranges.conf"), []byte(testNetworkConf), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "10-broken.conflist"), []byte("{"), 0644))
+
+	// Networks with the name of one loaded before are skipped
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "99-podman.conflist"), []byte(testNetworkConfList), 0644))
+
+	networks, err := loadNetworks(tmpDir)
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "podman", networks[0].Name())
+	assert.Equal(t, filepath.Join(tmpDir, "87-podman.conflist"), networks[0].Path())
+	assert.Equal(t, "bridge", networks[0].Driver())
+	assert.Equal(t, "ranges", networks[1].Name())
+
+	subnets := networks[0].Subnets()
+	assert.Len(t, subnets, 1)
+	assert.Equal(t, "10.88.0.0/16", subnets[0].String())
+
+	subnets = networks[1].Subnets()
+	assert.Len(t, subnets, 2)
+	assert.Equal(t, "10.89.0.0/24", subnets[0].String())
+	assert.Equal(t, "10.89.1.0/24", subnets[1].String())
+}
+
+func TestCheckStaticNetwork(t *testing.T) {
+	mac, err := net.ParseMAC("92:d0:c6:0a:29:33")
+	assert.NoError(t, err)
+	ctr := &Container{
+		config: &ContainerConfig{
+			ID:        "ctr",
+			StaticIP:  net.ParseIP("10.88.0.5"),
+			StaticMAC: mac,
+		},
+	}
+
+	_, address, err := net.ParseCIDR("10.88.0.5/16")
+	assert.NoError(t, err)
+	address.IP = net.ParseIP("10.88.0.5")
+	result := &cnitypes.Result{
+		Interfaces: []*cnitypes.Interface{
+			{Name: "cni0", Mac: "0a:58:0a:58:00:01"},
+			{Name: "eth0", Mac: "92:d0:c6:0a:29:33", Sandbox: "/var/run/netns/cni"},
+		},
+		IPs: []*cnitypes.IPConfig{{Version: "4", Address: *address}},
+	}
+	assert.NoError(t, checkStaticNetwork(ctr, result))
+
+	// The MAC address of the host side of the network does not count
+	result.Interfaces[1].Mac = "0a:58:0a:58:00:05"
+	result.Interfaces[0].Mac = "92:d0:c6:0a:29:33"
+	assert.Error(t, checkStaticNetwork(ctr, result))
+
+	ctr.config.StaticMAC = nil
+	address.IP = net.ParseIP("10.88.0.6")
+	result.IPs[0].Address = *address
+	assert.Error(t, checkStaticNetwork(ctr, result))
+}
+
+func TestStaticIPConflict(t *testing.T) {
+	ctr := getHostsTestCtr("ctr", nil)
+	ctr.config.StaticIP = net.ParseIP("10.88.0.5")
+	other := getHostsTestCtr("other", []string{"podman", "internal"})
+	other.config.StaticIP = net.ParseIP("10.88.0.5")
+	assert.Equal(t, other, staticIPConflict(ctr, []*Container{ctr, other}, "podman"))
+
+	// The same IP on another network does not conflict
+	other.config.Networks = []string{"internal", "podman"}
+	assert.Nil(t, staticIPConflict(ctr, []*Container{ctr, other}, "podman"))
+
+	other.config.Networks = nil
+	other.config.StaticIP = net.ParseIP("10.88.0.6")
+	assert.Nil(t, staticIPConflict(ctr, []*Container{ctr, other}, "podman"))
+}
+
+func TestAttachedNetworks(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "libpod_network_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "90-ranges.conf"), []byte(testNetworkConf), 0644))
+
+	r := &Runtime{config: &RuntimeConfig{CNIConfigDir: tmpDir, CNIDefaultNetwork: "podman"}}
+	ctr := getHostsTestCtr("ctr", nil, "10.88.0.5")
+	assert.Equal(t, []string{"ranges"}, r.containerNetworks(ctr))
+
+	// The network the container was attached to is detached, even though
+	// the default network is now another one
+	assert.Equal(t, []string{"podman"}, r.attachedNetworks(ctr))
+
+	ctr.state.NetworkStatus = nil
+	assert.Equal(t, []string{"ranges"}, r.attachedNetworks(ctr))
+}
+
+func TestMergeNetworkStatus(t *testing.T) {
+	_, address1, err := net.ParseCIDR("10.88.0.5/16")
+	assert.NoError(t, err)
+	_, address2, err := net.ParseCIDR("10.89.0.5/24")
+	assert.NoError(t, err)
+	zero, two := 0, 2
+	status := map[string]*cnitypes.Result{
+		"first": {
+			Interfaces: []*cnitypes.Interface{
+				{Name: "cni0"},
+				{Name: "eth0", Sandbox: "/var/run/netns/cni"},
+			},
+			IPs: []*cnitypes.IPConfig{{Version: "4", Address: *address1}},
+		},
+		"second": {
+			Interfaces: []*cnitypes.Interface{
+				{Name: "cni-podman1"},
+				{Name: "veth1"},
+				{Name: "eth1", Sandbox: "/var/run/netns/cni"},
+			},
+			IPs: []*cnitypes.IPConfig{{Version: "4", Address: *address2, Interface: &two}},
+		},
+	}
+	status["first"].IPs[0].Interface = &zero
+
+	ips, _, interfaces := mergeNetworkStatus([]string{"second", "first", "missing"}, status)
+	assert.Len(t, interfaces, 5)
+	assert.Len(t, ips, 2)
+	assert.Equal(t, "10.89.0.0/24", ips[0].Address.String())
+	assert.Equal(t, "eth1", interfaces[*ips[0].Interface].Name)
+	assert.Equal(t, "10.88.0.0/16", ips[1].Address.String())
+	assert.Equal(t, "cni0", interfaces[*ips[1].Interface].Name)
+	// The results of the networks are left alone
+	assert.Equal(t, 0, *status["first"].IPs[0].Interface)
+
+	assert.Equal(t, "eth1", interfaceName(status["second"]))
+	assert.Equal(t, "eth2", freeInterfaceName(status))
+	delete(status, "first")
+	assert.Equal(t, "eth0", freeInterfaceName(status))
+}
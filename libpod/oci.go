@@ -20,6 +20,7 @@ import (
 	"github.com/coreos/go-systemd/activation"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 	kwait "k8s.io/apimachinery/pkg/util/wait"
@@ -187,12 +188,14 @@ func waitPidsStop(pids []int, timeout time.Duration) error {
 }
 
 // CreateContainer creates a container in the OCI runtime
+// If restoreOptions is not nil, the container is restored from its checkpoint
+// instead, and will be running once created
 // TODO terminal support for container
 // Presently just ignoring conmon opts related to it
-func (r *OCIRuntime) createContainer(ctr *Container, cgroupParent string) (err error) {
+func (r *OCIRuntime) createContainer(ctr *Container, cgroupParent string, restoreOptions *ContainerRestoreOptions) (err error) {
 	if ctr.state.UserNSRoot == "" {
 		// no need of an intermediate mount ns
-		return r.createOCIContainer(ctr, cgroupParent)
+		return r.createOCIContainer(ctr, cgroupParent, restoreOptions)
 	}
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -229,14 +232,26 @@ func (r *OCIRuntime) createContainer(ctr *Container, cgroupParent string) (err e
 		if err != nil {
 			return
 		}
-		err = r.createOCIContainer(ctr, cgroupParent)
+		err = r.createOCIContainer(ctr, cgroupParent, restoreOptions)
 	}()
 	wg.Wait()
 
 	return err
 }
 
-func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string) (err error) {
+// logDriverArg returns conmon's log path argument for the container, which
+// selects its log driver
+func logDriverArg(ctr *Container) string {
+	switch ctr.LogDriver() {
+	case JournaldLogging:
+		return JournaldLogging + ":"
+	case NoLogging:
+		return NoLogging + ":"
+	}
+	return KubernetesLogging + ":" + ctr.LogPath()
+}
+
+func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string, restoreOptions *ContainerRestoreOptions) (err error) {
 	var stderrBuf bytes.Buffer
 
 	parentPipe, childPipe, err := newPipe()
@@ -261,7 +276,7 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string) (er
 	args = append(args, "-r", r.path)
 	args = append(args, "-b", ctr.bundlePath())
 	args = append(args, "-p", filepath.Join(ctr.state.RunDir, "pidfile"))
-	args = append(args, "-l", ctr.LogPath())
+	args = append(args, "-l", logDriverArg(ctr))
 	args = append(args, "--exit-dir", r.exitsDir)
 	if ctr.config.ConmonPidFile != "" {
 		args = append(args, "--conmon-pidfile", ctr.config.ConmonPidFile)
@@ -272,12 +287,23 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, cgroupParent string) (er
 	} else if ctr.config.Stdin {
 		args = append(args, "-i")
 	}
-	if r.logSizeMax >= 0 {
+	// conmon truncates the log file at the container's own size limit
+	// instead of the runtime's, whether or not it is rotated, so it stays
+	// bounded if it grows faster than it is rotated
+	if ctr.config.LogMaxSize > 0 {
+		args = append(args, "--log-size-max", fmt.Sprintf("%v", ctr.config.LogMaxSize))
+	} else if r.logSizeMax >= 0 {
 		args = append(args, "--log-size-max", fmt.Sprintf("%v", r.logSizeMax))
 	}
 	if r.noPivot {
 		args = append(args, "--no-pivot")
 	}
+	if restoreOptions != nil {
+		args = append(args, "--restore", ctr.checkpointPath())
+		if restoreOptions.TCPEstablished {
+			args = append(args, "--restore-arg", "--tcp-established")
+		}
+	}
 	logrus.WithFields(logrus.Fields{
 		"args": args,
 	}).Debugf("running conmon: %s", r.conmonPath)
@@ -455,6 +481,7 @@ func (r *OCIRuntime) updateContainerStatus(ctr *Container) error {
 			ctr.state.ExitCode = -1
 			ctr.state.FinishedTime = time.Now()
 			logrus.Errorf("No exit file for container %s found: %v", ctr.ID(), err)
+			ctr.newContainerExitedEvent(ctr.state.ExitCode)
 			return nil
 		}
 
@@ -473,7 +500,9 @@ func (r *OCIRuntime) updateContainerStatus(ctr *Container) error {
 		oomFilePath := filepath.Join(ctr.bundlePath(), "oom")
 		if _, err = os.Stat(oomFilePath); err == nil {
 			ctr.state.OOMKilled = true
+			ctr.newContainerEvent(events.OOM)
 		}
+		ctr.newContainerExitedEvent(ctr.state.ExitCode)
 
 	}
 
@@ -493,6 +522,41 @@ func (r *OCIRuntime) startContainer(ctr *Container) error {
 	return nil
 }
 
+// checkpointContainer checkpoints the given container with CRIU
+// The checkpoint is written to the container's checkpoint directory, and the
+// logs of CRIU to its bundle
+func (r *OCIRuntime) checkpointContainer(ctr *Container, options ContainerCheckpointOptions) error {
+	args := []string{"checkpoint", "--image-path", ctr.checkpointPath(), "--work-path", ctr.bundlePath()}
+	if options.KeepRunning {
+		args = append(args, "--leave-running")
+	}
+	if options.TCPEstablished {
+		args = append(args, "--tcp-established")
+	}
+	args = append(args, ctr.ID())
+
+	logrus.Debugf("Checkpointing container %s to %s", ctr.ID(), ctr.checkpointPath())
+	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, r.path, args...)
+}
+
+// updateContainer changes the resource limits of the given container
+// Only the limits set in the given resources are changed
+func (r *OCIRuntime) updateContainer(ctr *Container, resources *spec.LinuxResources) error {
+	resourcesJSON, err := json.Marshal(resources)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding resources for container %s", ctr.ID())
+	}
+
+	logrus.Debugf("Updating resources of container %s", ctr.ID())
+	cmd := exec.Command(r.path, "update", "--resources", "-", ctr.ID())
+	cmd.Stdin = bytes.NewReader(resourcesJSON)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "error updating resources of container %s: %s", ctr.ID(), strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 // killContainer sends the given signal to the given container
 func (r *OCIRuntime) killContainer(ctr *Container, signal uint) error {
 	logrus.Debugf("Sending signal %d to container %s", signal, ctr.ID())
@@ -578,12 +642,12 @@ func (r *OCIRuntime) unpauseContainer(ctr *Container) error {
 	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, r.path, "resume", ctr.ID())
 }
 
-// execContainer executes a command in a running container
-// TODO: Add --detach support
+// execContainer prepares the command that runs an exec session in a container
+// The runtime is run by an exec monitor, a script run by /bin/sh, which records
+// the exit code of the session in its exit file
 // TODO: Convert to use conmon
-// TODO: add --pid-file and use that to generate exec session tracking
-func (r *OCIRuntime) execContainer(c *Container, cmd, capAdd, env []string, tty bool, user, sessionID string) (*exec.Cmd, error) {
-	if len(cmd) == 0 {
+func (r *OCIRuntime) execContainer(c *Container, sessionID string, config *ExecConfig, capAdd []string, streams *AttachStreams, detach bool) (*exec.Cmd, error) {
+	if len(config.Command) == 0 {
 		return nil, errors.Wrapf(ErrInvalidArg, "must provide a command to execute")
 	}
 
@@ -591,23 +655,31 @@ func (r *OCIRuntime) execContainer(c *Container, cmd, capAdd, env []string, tty
 		return nil, errors.Wrapf(ErrEmptyID, "must provide a session ID for exec")
 	}
 
-	args := []string{}
+	args := []string{"-c", execMonitorScript, execMonitorCommand, c.execExitPath(sessionID), r.path}
 
 	// TODO - should we maintain separate logpaths for exec sessions?
 	args = append(args, "--log", c.LogPath())
 
 	args = append(args, "exec")
 
-	args = append(args, "--cwd", c.config.Spec.Process.Cwd)
+	cwd := c.config.Spec.Process.Cwd
+	if config.WorkDir != "" {
+		cwd = config.WorkDir
+	}
+	args = append(args, "--cwd", cwd)
 
 	args = append(args, "--pid-file", c.execPidPath(sessionID))
 
-	if tty {
+	if config.PreserveFDs > 0 {
+		args = append(args, "--preserve-fds", fmt.Sprintf("%d", config.PreserveFDs))
+	}
+
+	if config.Tty {
 		args = append(args, "--tty")
 	}
 
-	if user != "" {
-		args = append(args, "--user", user)
+	if config.User != "" {
+		args = append(args, "--user", config.User)
 	}
 
 	if c.config.Spec.Process.NoNewPrivileges {
@@ -618,20 +690,43 @@ func (r *OCIRuntime) execContainer(c *Container, cmd, capAdd, env []string, tty
 		args = append(args, "--cap", cap)
 	}
 
-	for _, envVar := range env {
+	for _, envVar := range config.Env {
 		args = append(args, "--env", envVar)
 	}
 
 	// Append container ID and command
 	args = append(args, c.ID())
-	args = append(args, cmd...)
+	args = append(args, config.Command...)
+
+	logrus.Debugf("Starting runtime %s with following arguments: %v", r.path, args[5:])
+
+	execCmd := exec.Command(execMonitorShell, args...)
+	for fd := uint(3); fd < 3+config.PreserveFDs; fd++ {
+		execCmd.ExtraFiles = append(execCmd.ExtraFiles, os.NewFile(uintptr(fd), fmt.Sprintf("fd-%d", fd)))
+	}
 
-	logrus.Debugf("Starting runtime %s with following arguments: %v", r.path, args)
+	if detach {
+		// The session must outlive us, so it gets its own session, and
+		// its standard streams are left closed
+		execCmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+		return execCmd, nil
+	}
 
-	execCmd := exec.Command(r.path, args...)
-	execCmd.Stdout = os.Stdout
-	execCmd.Stderr = os.Stderr
-	execCmd.Stdin = os.Stdin
+	if streams == nil {
+		execCmd.Stdout = os.Stdout
+		execCmd.Stderr = os.Stderr
+		execCmd.Stdin = os.Stdin
+	} else {
+		if streams.AttachOutput {
+			execCmd.Stdout = streams.OutputStream
+		}
+		if streams.AttachError {
+			execCmd.Stderr = streams.ErrorStream
+		}
+		if streams.AttachInput {
+			execCmd.Stdin = streams.InputStream
+		}
+	}
 
 	return execCmd, nil
 }
@@ -642,13 +737,16 @@ func (r *OCIRuntime) execContainer(c *Container, cmd, capAdd, env []string, tty
 // SIGTERM is used by default to stop processes. If SIGTERM fails, SIGKILL will be used.
 func (r *OCIRuntime) execStopContainer(ctr *Container, timeout uint) error {
 	// Do we have active exec sessions?
-	if len(ctr.state.ExecSessions) == 0 {
+	if !ctr.hasRunningExecSessions() {
 		return nil
 	}
 
 	// Get a list of active exec sessions
 	execSessions := []int{}
 	for _, session := range ctr.state.ExecSessions {
+		if session.State != ExecStateRunning || session.PID <= 0 {
+			continue
+		}
 		pid := session.PID
 		// Ping the PID with signal 0 to see if it still exists
 		if err := unix.Kill(pid, 0); err == unix.ESRCH {
@@ -9,11 +9,20 @@ import (
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
 var (
 	nameRegex = regexp.MustCompile("[a-zA-Z0-9_-]+")
+	// Container names are written to hosts files, so the whole name must
+	// match
+	ctrNameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+	// Volume names are used as directory names, so the whole name must
+	// match
+	volumeNameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+	// Network aliases are resolved like host names
+	networkAliasRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
 )
 
 // Runtime Creation Options
@@ -243,6 +252,32 @@ func WithMaxLogSize(limit int64) RuntimeOption {
 	}
 }
 
+// WithEventsLogFilePath sets the path to the file events are recorded in.
+func WithEventsLogFilePath(path string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.config.EventsLogFilePath = path
+
+		return nil
+	}
+}
+
+// WithVolumePath sets the path named volumes are created in.
+func WithVolumePath(volPath string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return ErrRuntimeFinalized
+		}
+
+		rt.config.VolumePath = volPath
+
+		return nil
+	}
+}
+
 // WithNoPivotRoot sets the runtime to use MS_MOVE instead of PIVOT_ROOT when
 // starting containers.
 func WithNoPivotRoot(noPivot bool) RuntimeOption {
@@ -402,6 +437,21 @@ func (r *Runtime) WithPod(pod *Pod) CtrCreateOption {
 	}
 }
 
+// withIsInfra marks the container as the infra container of its pod.
+// Infra containers are created by libpod when the pod is created, and are only
+// removed along with their pod.
+func withIsInfra() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.IsInfra = true
+
+		return nil
+	}
+}
+
 // WithLabels adds labels to the container.
 func WithLabels(labels map[string]string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -426,8 +476,8 @@ func WithName(name string) CtrCreateOption {
 		}
 
 		// Check the name against a regex
-		if !nameRegex.MatchString(name) {
-			return errors.Wrapf(ErrInvalidArg, "name must match regex [a-zA-Z0-9_-]+")
+		if !ctrNameRegex.MatchString(name) {
+			return errors.Wrapf(ErrInvalidArg, "name must match regex %s", ctrNameRegex.String())
 		}
 
 		ctr.config.Name = name
@@ -469,6 +519,64 @@ func WithStopTimeout(timeout uint) CtrCreateOption {
 	}
 }
 
+// WithRestartPolicy sets what to do when the container exits.
+// Valid policies are "no" (the default), "on-failure", "always", and
+// "unless-stopped".
+func WithRestartPolicy(policy string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch policy {
+		case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
+			ctr.config.RestartPolicy = policy
+		default:
+			return errors.Wrapf(ErrInvalidArg, "%q is not a valid restart policy", policy)
+		}
+
+		return nil
+	}
+}
+
+// WithRestartRetries sets the maximum number of times the container will be
+// restarted by the on-failure restart policy.
+// It is only valid with the on-failure restart policy.
+func WithRestartRetries(tries uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		ctr.config.RestartRetries = tries
+
+		return nil
+	}
+}
+
+// WithHealthCheck sets the healthcheck of the container.
+// The healthcheck is run by RunHealthCheck.
+func WithHealthCheck(healthCheck *HealthCheckConfig) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if healthCheck == nil {
+			return errors.Wrapf(ErrInvalidArg, "must provide a healthcheck")
+		}
+		if err := healthCheck.Validate(); err != nil {
+			return err
+		}
+
+		config := *healthCheck
+		config.Test = append([]string{}, healthCheck.Test...)
+		ctr.config.HealthCheckConfig = &config
+
+		return nil
+	}
+}
+
 // WithIDMappings sets the idmappsings for the container
 func WithIDMappings(idmappings storage.IDMappingOptions) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -716,8 +824,10 @@ func WithDependencyCtrs(ctrs []*Container) CtrCreateOption {
 // WithNetNS indicates that the container should be given a new network
 // namespace with a minimal configuration.
 // An optional array of port mappings can be provided.
+// Optional CNI networks can be given for the container to join, instead of
+// the default network. Ports are forwarded through the first network.
 // Conflicts with WithNetNSFrom().
-func WithNetNS(portMappings []ocicni.PortMapping, postConfigureNetNS bool) CtrCreateOption {
+func WithNetNS(portMappings []ocicni.PortMapping, postConfigureNetNS bool, networks []string) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return ErrCtrFinalized
@@ -727,9 +837,97 @@ func WithNetNS(portMappings []ocicni.PortMapping, postConfigureNetNS bool) CtrCr
 			return errors.Wrapf(ErrInvalidArg, "container is already set to join another container's net ns, cannot create a new net ns")
 		}
 
+		seen := make(map[string]bool)
+		for _, network := range networks {
+			if network == "" {
+				return errors.Wrapf(ErrInvalidArg, "network name must not be empty")
+			}
+			if seen[network] {
+				return errors.Wrapf(ErrInvalidArg, "container cannot join network %s more than once", network)
+			}
+			seen[network] = true
+		}
+
 		ctr.config.PostConfigureNetNS = postConfigureNetNS
 		ctr.config.CreateNetNS = true
 		ctr.config.PortMappings = portMappings
+		ctr.config.Networks = networks
+
+		return nil
+	}
+}
+
+// WithStaticIP requests the given IP for the container in its network, instead
+// of one assigned by the network.
+// The IP must be in a subnet of the network, whose IPAM plugin must support
+// static IPs. It cannot be set unless WithNetNS has already been passed.
+func WithStaticIP(ip net.IP) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(ErrInvalidArg, "cannot set a static IP if the container is not creating a network namespace")
+		}
+
+		if ip == nil || ip.IsUnspecified() {
+			return errors.Wrapf(ErrInvalidArg, "static IP must be specified")
+		}
+
+		ctr.config.StaticIP = ip
+
+		return nil
+	}
+}
+
+// WithStaticMAC requests the given MAC address for the container's interface in
+// its network.
+// The network's plugins must support setting MAC addresses. It cannot be set
+// unless WithNetNS has already been passed.
+func WithStaticMAC(mac net.HardwareAddr) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(ErrInvalidArg, "cannot set a static MAC address if the container is not creating a network namespace")
+		}
+
+		if len(mac) != 6 {
+			return errors.Wrapf(ErrInvalidArg, "MAC address %s is not an Ethernet MAC address", mac)
+		}
+		if mac[0]&1 == 1 {
+			return errors.Wrapf(ErrInvalidArg, "MAC address %s is a multicast address", mac)
+		}
+
+		ctr.config.StaticMAC = mac
+
+		return nil
+	}
+}
+
+// WithNetworkAliases sets names the container can be reached by in its network,
+// besides its name.
+// It cannot be set unless WithNetNS has already been passed.
+func WithNetworkAliases(aliases []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(ErrInvalidArg, "cannot set network aliases if the container is not creating a network namespace")
+		}
+
+		for _, alias := range aliases {
+			if !networkAliasRegex.MatchString(alias) {
+				return errors.Wrapf(ErrInvalidArg, "invalid network alias %q", alias)
+			}
+		}
+
+		ctr.config.NetworkAliases = aliases
 
 		return nil
 	}
@@ -751,6 +949,50 @@ func WithLogPath(path string) CtrCreateOption {
 	}
 }
 
+// WithLogDriver sets the driver handling the container's output.
+func WithLogDriver(driver string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		switch driver {
+		case KubernetesLogging, JournaldLogging, NoLogging:
+		case "":
+			return errors.Wrapf(ErrInvalidArg, "log driver must be set")
+		default:
+			return errors.Wrapf(ErrInvalidArg, "unsupported log driver %q", driver)
+		}
+
+		ctr.config.LogDriver = driver
+
+		return nil
+	}
+}
+
+// WithLogRotation limits the size of the container's log file.
+// Once the log file reaches maxSize bytes, it is rotated, keeping maxFiles log
+// files including the current one; with maxFiles at most 1, the log file is
+// truncated instead. Only the k8s-file log driver supports rotation.
+func WithLogRotation(maxSize int64, maxFiles uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+		if maxSize <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "maximum log size must be greater than 0")
+		}
+		if ctr.LogDriver() != KubernetesLogging {
+			return errors.Wrapf(ErrInvalidArg, "log rotation is only supported by the %s log driver", KubernetesLogging)
+		}
+
+		ctr.config.LogMaxSize = maxSize
+		ctr.config.LogMaxFiles = maxFiles
+
+		return nil
+	}
+}
+
 // WithCgroupParent sets the Cgroup Parent of the new container.
 func WithCgroupParent(parent string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -869,6 +1111,40 @@ func WithUserVolumes(volumes []string) CtrCreateOption {
 	}
 }
 
+// WithNamedVolumes adds the given named volumes to the container.
+// Volumes that do not exist are created along with the container. The content
+// of the container's image at the destination of a volume is copied into it
+// when the volume is first used, unless the nocopy option is given.
+func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return ErrCtrFinalized
+		}
+
+		dests := make(map[string]bool)
+		for _, vol := range volumes {
+			if !volumeNameRegex.MatchString(vol.Name) {
+				return errors.Wrapf(ErrInvalidArg, "volume name %q must match regex [a-zA-Z0-9][a-zA-Z0-9_.-]*", vol.Name)
+			}
+			if !filepath.IsAbs(vol.Dest) {
+				return errors.Wrapf(ErrInvalidArg, "destination %q of volume %s must be an absolute path", vol.Dest, vol.Name)
+			}
+			if dests[filepath.Clean(vol.Dest)] {
+				return errors.Wrapf(ErrInvalidArg, "more than one volume is mounted at %s", vol.Dest)
+			}
+			dests[filepath.Clean(vol.Dest)] = true
+
+			ctr.config.NamedVolumes = append(ctr.config.NamedVolumes, &ContainerNamedVolume{
+				Name:    vol.Name,
+				Dest:    filepath.Clean(vol.Dest),
+				Options: append([]string{}, vol.Options...),
+			})
+		}
+
+		return nil
+	}
+}
+
 // WithEntrypoint sets the entrypoint of the container.
 // This is not used to change the container's spec, but will instead be used
 // during commit to populate the entrypoint of the new image.
@@ -973,3 +1249,423 @@ func WithPodCgroups() PodCreateOption {
 		return nil
 	}
 }
+
+// podCgroupResources returns the resource limits of the pod's cgroup,
+// initializing them if they have not been set yet
+func podCgroupResources(pod *Pod) *spec.LinuxResources {
+	if pod.config.CgroupResources == nil {
+		pod.config.CgroupResources = new(spec.LinuxResources)
+	}
+	return pod.config.CgroupResources
+}
+
+// podCgroupCPU returns the CPU limits of the pod's cgroup, initializing them if
+// they have not been set yet
+func podCgroupCPU(pod *Pod) *spec.LinuxCPU {
+	resources := podCgroupResources(pod)
+	if resources.CPU == nil {
+		resources.CPU = new(spec.LinuxCPU)
+	}
+	return resources.CPU
+}
+
+// WithPodMemoryLimit limits the memory, in bytes, that may be used by all
+// containers in the pod combined.
+// Requires the pod to use a pod cgroup (WithPodCgroups).
+func WithPodMemoryLimit(limit int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		if limit <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "pod memory limit must be greater than 0")
+		}
+
+		resources := podCgroupResources(pod)
+		resources.Memory = &spec.LinuxMemory{
+			Limit: &limit,
+		}
+
+		return nil
+	}
+}
+
+// WithPodCPUShares sets the CPU shares (relative weight) of the pod's cgroup.
+// Requires the pod to use a pod cgroup (WithPodCgroups).
+func WithPodCPUShares(shares uint64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		if shares == 0 {
+			return errors.Wrapf(ErrInvalidArg, "pod CPU shares must be greater than 0")
+		}
+
+		podCgroupCPU(pod).Shares = &shares
+
+		return nil
+	}
+}
+
+// WithPodCPUQuota limits the CPU time available to all containers in the pod
+// combined to quota microseconds every period microseconds.
+// If period is 0, the kernel default of 100000 microseconds is used.
+// Requires the pod to use a pod cgroup (WithPodCgroups).
+func WithPodCPUQuota(quota int64, period uint64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		if quota <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "pod CPU quota must be greater than 0")
+		}
+		if period == 0 {
+			period = DefaultCPUPeriod
+		}
+
+		cpu := podCgroupCPU(pod)
+		cpu.Quota = &quota
+		cpu.Period = &period
+
+		return nil
+	}
+}
+
+// WithPodCPUSet restricts the containers in the pod to the given CPUs and
+// memory nodes, given in the cpuset list format (e.g. 0-3,5).
+// Either may be empty to leave it unrestricted.
+// Requires the pod to use a pod cgroup (WithPodCgroups).
+func WithPodCPUSet(cpus, mems string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		if cpus == "" && mems == "" {
+			return errors.Wrapf(ErrInvalidArg, "must provide CPUs or memory nodes to restrict the pod to")
+		}
+
+		cpu := podCgroupCPU(pod)
+		cpu.Cpus = cpus
+		cpu.Mems = mems
+
+		return nil
+	}
+}
+
+// WithPodPidsLimit limits the number of processes that may be run by all
+// containers in the pod combined.
+// Requires the pod to use a pod cgroup (WithPodCgroups).
+func WithPodPidsLimit(limit int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		if limit <= 0 {
+			return errors.Wrapf(ErrInvalidArg, "pod pids limit must be greater than 0")
+		}
+
+		podCgroupResources(pod).Pids = &spec.LinuxPids{
+			Limit: limit,
+		}
+
+		return nil
+	}
+}
+
+// WithInfraContainer tells the pod to create an infra container that holds
+// the namespaces shared by the containers in the pod.
+func WithInfraContainer() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.InfraContainer.HasInfraContainer = true
+
+		return nil
+	}
+}
+
+// WithInfraImage sets the image the pod's infra container is created from,
+// overriding the runtime's default infra image.
+func WithInfraImage(image string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.InfraContainer.Image = image
+
+		return nil
+	}
+}
+
+// WithInfraCommand sets the command run by the pod's infra container,
+// overriding the runtime's default infra command.
+func WithInfraCommand(command []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.InfraContainer.Command = make([]string, 0, len(command))
+		pod.config.InfraContainer.Command = append(pod.config.InfraContainer.Command, command...)
+
+		return nil
+	}
+}
+
+// WithInfraPortBindings sets the ports published by the pod's infra
+// container. As containers in the pod join the infra container's network
+// namespace, this publishes ports of every container in the pod.
+func WithInfraPortBindings(bindings []ocicni.PortMapping) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.InfraContainer.PortBindings = make([]ocicni.PortMapping, 0, len(bindings))
+		pod.config.InfraContainer.PortBindings = append(pod.config.InfraContainer.PortBindings, bindings...)
+
+		return nil
+	}
+}
+
+// WithPodNet tells containers in this pod to join the network namespace of
+// the pod's infra container.
+func WithPodNet() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.UsePodNet = true
+
+		return nil
+	}
+}
+
+// WithPodIPC tells containers in this pod to join the IPC namespace of the
+// pod's infra container.
+func WithPodIPC() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.UsePodIPC = true
+
+		return nil
+	}
+}
+
+// WithPodUTS tells containers in this pod to join the UTS namespace of the
+// pod's infra container.
+func WithPodUTS() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return ErrPodFinalized
+		}
+
+		pod.config.UsePodUTS = true
+
+		return nil
+	}
+}
+
+// Volume Creation Options
+
+// WithVolumeName sets the name of the volume.
+func WithVolumeName(name string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		// Check the name against a regex
+		if !volumeNameRegex.MatchString(name) {
+			return errors.Wrapf(ErrInvalidArg, "volume name must match regex [a-zA-Z0-9][a-zA-Z0-9_.-]*")
+		}
+
+		volume.config.Name = name
+
+		return nil
+	}
+}
+
+// WithVolumeLabels sets the labels of the volume.
+func WithVolumeLabels(labels map[string]string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		volume.config.Labels = make(map[string]string)
+		for key, value := range labels {
+			volume.config.Labels[key] = value
+		}
+
+		return nil
+	}
+}
+
+// WithVolumeDriver sets the driver of the volume.
+// Only the local driver is supported.
+func WithVolumeDriver(driver string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		if driver != LocalVolumeDriver {
+			return errors.Wrapf(ErrNotImplemented, "volume driver %q is not supported, only %q is", driver, LocalVolumeDriver)
+		}
+
+		volume.config.Driver = driver
+
+		return nil
+	}
+}
+
+// WithVolumeOptions sets the driver options of the volume.
+// The local driver accepts type and device, the type and device of a
+// filesystem to mount as the volume while containers use it, and o, the
+// comma-separated options it is mounted with.
+func WithVolumeOptions(options map[string]string) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return ErrVolumeFinalized
+		}
+
+		volume.config.Options = make(map[string]string)
+		for key, value := range options {
+			switch key {
+			case "type", "device", "o":
+				volume.config.Options[key] = value
+			default:
+				return errors.Wrapf(ErrInvalidArg, "unrecognized volume option %q, must be type, device or o", key)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Network Creation Options
+
+// WithNetworkName sets the name of the network.
+func WithNetworkName(name string) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		// Network names are used as file names, so the whole name must
+		// match
+		if !volumeNameRegex.MatchString(name) {
+			return errors.Wrapf(ErrInvalidArg, "network name must match regex [a-zA-Z0-9][a-zA-Z0-9_.-]*")
+		}
+
+		config.name = name
+
+		return nil
+	}
+}
+
+// WithNetworkDriver sets the driver of the network, which is the type of its
+// main CNI plugin.
+// The bridge and macvlan drivers are supported.
+func WithNetworkDriver(driver string) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		if driver != BridgeNetworkDriver && driver != MacvlanNetworkDriver {
+			return errors.Wrapf(ErrNotImplemented, "network driver %q is not supported, only %q and %q are", driver, BridgeNetworkDriver, MacvlanNetworkDriver)
+		}
+
+		config.driver = driver
+
+		return nil
+	}
+}
+
+// WithNetworkSubnet sets the IPv4 subnet IPs are assigned from in the network.
+// If not set, a free subnet is chosen.
+func WithNetworkSubnet(subnet *net.IPNet) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		if subnet == nil || subnet.IP.To4() == nil || len(subnet.Mask) != net.IPv4len {
+			return errors.Wrapf(ErrInvalidArg, "network subnet must be an IPv4 subnet")
+		}
+		if ones, _ := subnet.Mask.Size(); ones > 30 {
+			return errors.Wrapf(ErrInvalidArg, "network subnet %s is too small", subnet.String())
+		}
+
+		config.subnet = &net.IPNet{
+			IP:   subnet.IP.Mask(subnet.Mask).To4(),
+			Mask: subnet.Mask,
+		}
+
+		return nil
+	}
+}
+
+// WithNetworkGateway sets the IP of the network's gateway.
+// It must be in the network's subnet, which must also be given with
+// WithNetworkSubnet.
+func WithNetworkGateway(gateway net.IP) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		if gateway == nil || gateway.To4() == nil {
+			return errors.Wrapf(ErrInvalidArg, "network gateway must be an IPv4 address")
+		}
+
+		config.gateway = gateway.To4()
+
+		return nil
+	}
+}
+
+// WithNetworkIPRange sets the range IPs are assigned to containers from in the
+// network.
+// It must be in the network's subnet, which must also be given with
+// WithNetworkSubnet.
+func WithNetworkIPRange(ipRange *net.IPNet) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		if ipRange == nil || ipRange.IP.To4() == nil || len(ipRange.Mask) != net.IPv4len {
+			return errors.Wrapf(ErrInvalidArg, "network IP range must be an IPv4 subnet")
+		}
+		if ones, _ := ipRange.Mask.Size(); ones > 30 {
+			return errors.Wrapf(ErrInvalidArg, "network IP range %s is too small", ipRange.String())
+		}
+
+		config.ipRange = &net.IPNet{
+			IP:   ipRange.IP.Mask(ipRange.Mask).To4(),
+			Mask: ipRange.Mask,
+		}
+
+		return nil
+	}
+}
+
+// WithNetworkInternal makes the network internal: containers in it can reach
+// each other, but not the host's other networks.
+// Only bridge networks can be internal.
+func WithNetworkInternal() NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		config.internal = true
+
+		return nil
+	}
+}
+
+// WithMacvlanParent sets the host interface the containers of a macvlan network
+// are connected to.
+func WithMacvlanParent(parent string) NetworkCreateOption {
+	return func(config *networkCreateConfig) error {
+		if parent == "" {
+			return errors.Wrapf(ErrInvalidArg, "macvlan parent interface must be specified")
+		}
+
+		config.macvlanParent = parent
+
+		return nil
+	}
+}
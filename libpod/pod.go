@@ -4,11 +4,16 @@ import (
 	"context"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/containers/storage"
+	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/docker/pkg/stringid"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/sirupsen/logrus"
+	"github.com/ulule/deepcopier"
 )
 
 // Pod represents a group of containers that may share namespaces
@@ -36,12 +41,70 @@ type PodConfig struct {
 	// If true, all containers joined to the pod will use the pod cgroup as
 	// their cgroup parent, and cannot set a different cgroup parent
 	UsePodCgroup bool
+	// CgroupResources contains the resource limits applied to the pod's
+	// CGroup, if any. They are shared by all containers in the pod.
+	// Requires UsePodCgroup.
+	CgroupResources *spec.LinuxResources `json:"cgroupResources,omitempty"`
+
+	// The following UsePod{kernelNamespace} indicate whether the
+	// containers in the pod will join the namespace of the pod's infra
+	// container
+	UsePodNet bool `json:"sharesNet,omitempty"`
+	UsePodIPC bool `json:"sharesIpc,omitempty"`
+	UsePodUTS bool `json:"sharesUts,omitempty"`
+
+	// InfraContainer contains the configuration of the pod's infra
+	// container
+	InfraContainer *InfraContainerConfig `json:"infraConfig"`
+
+	// Time pod was created
+	CreatedTime time.Time `json:"created"`
+}
+
+// InfraContainerConfig contains the configuration of a pod's infra container,
+// a minimal container that holds the namespaces shared by the pod
+type InfraContainerConfig struct {
+	// HasInfraContainer indicates whether the pod has an infra container
+	HasInfraContainer bool `json:"makeInfraContainer"`
+	// Image is the image the infra container is created from
+	// If empty, the runtime's default infra image is used
+	Image string `json:"image,omitempty"`
+	// Command is the command run by the infra container
+	// If empty, the runtime's default infra command is used
+	Command []string `json:"command,omitempty"`
+	// PortBindings are the ports published by the infra container on
+	// behalf of the pod
+	// Only valid if the pod shares its network namespace
+	PortBindings []ocicni.PortMapping `json:"portBindings,omitempty"`
 }
 
 // podState represents a pod's state
 type podState struct {
 	// CgroupPath is the path to the pod's CGroup
 	CgroupPath string
+	// InfraContainerID is the ID of the pod's infra container, if one is
+	// present
+	InfraContainerID string
+}
+
+// PodInspect represents the data we want to display for
+// podman pod inspect
+type PodInspect struct {
+	Config     *PodConfig
+	State      *PodInspectState
+	Containers []PodContainerInfo
+}
+
+// PodInspectState contains inspect data on the pod's state
+type PodInspectState struct {
+	CgroupPath       string `json:"cgroupPath"`
+	InfraContainerID string `json:"infraContainerID"`
+}
+
+// PodContainerInfo keeps information on a container in a pod
+type PodContainerInfo struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
 }
 
 // ID retrieves the pod's ID
@@ -75,10 +138,44 @@ func (p *Pod) UsePodCgroup() bool {
 	return p.config.UsePodCgroup
 }
 
+// SharesNet returns whether containers in the pod join the network namespace
+// of the pod's infra container
+func (p *Pod) SharesNet() bool {
+	return p.config.UsePodNet
+}
+
+// SharesIPC returns whether containers in the pod join the IPC namespace of the
+// pod's infra container
+func (p *Pod) SharesIPC() bool {
+	return p.config.UsePodIPC
+}
+
+// SharesUTS returns whether containers in the pod join the UTS namespace of the
+// pod's infra container
+func (p *Pod) SharesUTS() bool {
+	return p.config.UsePodUTS
+}
+
+// SharesNamespaces returns whether containers in the pod join any namespaces
+// of the pod's infra container
+func (p *Pod) SharesNamespaces() bool {
+	return p.config.UsePodNet || p.config.UsePodIPC || p.config.UsePodUTS
+}
+
+// HasInfraContainer returns whether the pod has an infra container
+func (p *Pod) HasInfraContainer() bool {
+	return p.config.InfraContainer != nil && p.config.InfraContainer.HasInfraContainer
+}
+
+// CreatedTime gets the time when the pod was created
+func (p *Pod) CreatedTime() time.Time {
+	return p.config.CreatedTime
+}
+
 // CgroupPath returns the path to the pod's CGroup
 func (p *Pod) CgroupPath() (string, error) {
 	p.lock.Lock()
-	p.lock.Unlock()
+	defer p.lock.Unlock()
 	if err := p.updatePod(); err != nil {
 		return "", err
 	}
@@ -86,12 +183,26 @@ func (p *Pod) CgroupPath() (string, error) {
 	return p.state.CgroupPath, nil
 }
 
+// InfraContainerID returns the ID of the pod's infra container, or an empty
+// string if the pod does not have one
+func (p *Pod) InfraContainerID() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.updatePod(); err != nil {
+		return "", err
+	}
+
+	return p.state.InfraContainerID, nil
+}
+
 // Creates a new, empty pod
 func newPod(lockDir string, runtime *Runtime) (*Pod, error) {
 	pod := new(Pod)
 	pod.config = new(PodConfig)
 	pod.config.ID = stringid.GenerateNonCryptoID()
 	pod.config.Labels = make(map[string]string)
+	pod.config.InfraContainer = new(InfraContainerConfig)
+	pod.config.CreatedTime = time.Now()
 	pod.state = new(podState)
 	pod.runtime = runtime
 
@@ -119,7 +230,7 @@ func (p *Pod) updatePod() error {
 // Save pod state to database
 func (p *Pod) save() error {
 	if err := p.runtime.state.SavePod(p); err != nil {
-		return errors.Wrapf(err, "error saving pod %s state")
+		return errors.Wrapf(err, "error saving pod %s state", p.ID())
 	}
 
 	return nil
@@ -138,8 +249,9 @@ func (p *Pod) refresh() error {
 	if p.config.UsePodCgroup {
 		switch p.runtime.config.CgroupManager {
 		case SystemdCgroupsManager:
-			// NOOP for now, until proper systemd cgroup management
-			// is implemented
+			p.state.CgroupPath = systemdPodSlice(p.config.CgroupParent, p.ID())
+
+			logrus.Debugf("setting pod slice to %s", p.state.CgroupPath)
 		case CgroupfsCgroupsManager:
 			p.state.CgroupPath = filepath.Join(p.config.CgroupParent, p.ID())
 
@@ -147,6 +259,10 @@ func (p *Pod) refresh() error {
 		default:
 			return errors.Wrapf(ErrInvalidArg, "unknown cgroups manager %s specified", p.runtime.config.CgroupManager)
 		}
+
+		if err := p.createCgroup(); err != nil {
+			return err
+		}
 	}
 
 	// Save changes
@@ -200,7 +316,11 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		startNode(ctx, node, false, ctrErrors, ctrsVisited)
 	}
 
-	return ctrErrors, nil
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error starting some containers")
+	}
+
+	return nil, nil
 }
 
 // Visit a node on a container graph and start the container, or set an error if
@@ -273,6 +393,9 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 
 	// Start the container (only if it is not running)
 	if !ctrErrored && node.container.state.State != ContainerStateRunning {
+		node.container.state.StoppedByUser = false
+		node.container.state.RestartCount = 0
+
 		if err := node.container.initAndStart(ctx); err != nil {
 			ctrErrored = true
 			ctrErrors[node.id] = err
@@ -295,8 +418,10 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 // containers will be ignored.
 // If cleanup is true, mounts and network namespaces will be cleaned up after
 // the container is stopped.
-// All containers are stopped independently. An error stopping one container
-// will not prevent other containers being stopped.
+// Containers are stopped in the reverse of the order they are started in. A
+// container will not be stopped until all containers depending on it have been
+// stopped. An error stopping one container will not prevent containers it does
+// not depend on being stopped.
 // An error and a map[string]error are returned
 // If the error is not nil and the map is nil, an error was encountered before
 // any containers were stopped
@@ -305,6 +430,14 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 // set to ErrCtrExists
 // If both error and the map are nil, all containers were stopped without error
 func (p *Pod) Stop(cleanup bool) (map[string]error, error) {
+	return p.StopWithTimeout(cleanup, -1)
+}
+
+// StopWithTimeout stops all containers within a pod that are not already
+// stopped, using the given timeout for every container
+// If timeout is negative, each container will use its own stop timeout
+// Otherwise it behaves identically to Stop()
+func (p *Pod) StopWithTimeout(cleanup bool, timeout int) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -317,12 +450,208 @@ func (p *Pod) Stop(cleanup bool) (map[string]error, error) {
 		return nil, err
 	}
 
+	// Build a dependency graph of containers in the pod
+	graph, err := buildContainerGraph(allCtrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
+	}
+
 	ctrErrors := make(map[string]error)
+	ctrsVisited := make(map[string]bool)
+
+	// Traverse the graph beginning at nodes no other container depends on
+	for _, node := range graph.notDependedOnNodes {
+		stopNode(node, false, cleanup, timeout, ctrErrors, ctrsVisited)
+	}
 
-	// TODO: There may be cases where it makes sense to order stops based on
-	// dependencies. Should we bother with this?
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error stopping some containers")
+	}
+
+	return nil, nil
+}
+
+// Visit a node on a container graph and stop the container, or set an error if
+// a container depending on it failed to stop
+func stopNode(node *containerNode, setError, cleanup bool, timeout int, ctrErrors map[string]error, ctrsVisited map[string]bool) {
+	// First, check if we have already visited the node
+	if ctrsVisited[node.id] {
+		return
+	}
+
+	// If setError is true, a container depending on us failed to stop
+	// Leave us running so it does not lose its dependency, and recurse
+	if setError {
+		// Mark us as visited, and set an error
+		ctrsVisited[node.id] = true
+		ctrErrors[node.id] = errors.Wrapf(ErrCtrStateInvalid, "a container depending on container %s failed to stop", node.id)
 
-	// Stop to all containers
+		// Hit our dependencies, and set errors on them too
+		for _, dep := range node.dependsOn {
+			stopNode(dep, true, cleanup, timeout, ctrErrors, ctrsVisited)
+		}
+
+		return
+	}
+
+	// Have all containers depending on us stopped?
+	// If not, don't visit the node yet
+	dependentsVisited := true
+	for _, dependent := range node.dependedOn {
+		dependentsVisited = dependentsVisited && ctrsVisited[dependent.id]
+	}
+	if !dependentsVisited {
+		// Don't visit us yet, we'll be reached again once the last
+		// container depending on us is stopped
+		return
+	}
+
+	// Going to try to stop the container, mark us as visited
+	ctrsVisited[node.id] = true
+
+	ctrErrored := false
+
+	node.container.lock.Lock()
+
+	// Sync the container to pick up current state
+	if err := node.container.syncContainer(); err != nil {
+		ctrErrored = true
+		ctrErrors[node.id] = err
+	}
+
+	// Stop the container (only if it is running)
+	if !ctrErrored && node.container.state.State == ContainerStateRunning {
+		stopTimeout := node.container.config.StopTimeout
+		if timeout >= 0 {
+			stopTimeout = uint(timeout)
+		}
+
+		if err := node.container.stop(stopTimeout); err != nil {
+			ctrErrored = true
+			ctrErrors[node.id] = err
+		} else {
+			// Do not let the restart policy bring the container
+			// back up
+			node.container.state.StoppedByUser = true
+			if err := node.container.save(); err != nil {
+				ctrErrored = true
+				ctrErrors[node.id] = err
+			}
+		}
+
+		if !ctrErrored && cleanup {
+			if err := node.container.cleanup(); err != nil {
+				ctrErrors[node.id] = err
+			}
+		}
+	}
+
+	node.container.lock.Unlock()
+
+	// Recurse to our dependencies and stop them
+	for _, dep := range node.dependsOn {
+		stopNode(dep, ctrErrored, cleanup, timeout, ctrErrors, ctrsVisited)
+	}
+
+	return
+}
+
+// Restart restarts all containers within a pod
+// Running containers are first stopped in reverse dependency order, as with
+// Stop(), with network namespaces and mounts cleaned up. All containers are then
+// started in dependency order, as with Start(). Containers that were not running
+// before the restart will be started as well.
+// An error and a map[string]error are returned
+// If the error is not nil and the map is nil, an error was encountered before
+// any containers were restarted
+// If map is not nil, an error was encountered when restarting one or more
+// containers. The container ID is mapped to the error encountered. The error is
+// set to ErrCtrExists
+// If both error and the map are nil, all containers were restarted without
+// error
+func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build a dependency graph of containers in the pod
+	graph, err := buildContainerGraph(allCtrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
+	}
+
+	// If there are no containers without dependencies, we can't start
+	// Error out
+	if len(graph.noDepNodes) == 0 {
+		return nil, errors.Wrapf(ErrNoSuchCtr, "no containers in pod %s have no dependencies, cannot restart pod", p.ID())
+	}
+
+	ctrErrors := make(map[string]error)
+	ctrsVisited := make(map[string]bool)
+
+	// Stop, beginning at nodes no other container depends on
+	for _, node := range graph.notDependedOnNodes {
+		stopNode(node, false, true, -1, ctrErrors, ctrsVisited)
+	}
+
+	startErrors := make(map[string]error)
+	ctrsVisited = make(map[string]bool)
+
+	// Start again, beginning at nodes with no dependencies
+	for _, node := range graph.noDepNodes {
+		startNode(ctx, node, false, startErrors, ctrsVisited)
+	}
+
+	// Errors encountered while stopping take precedence
+	for id, err := range startErrors {
+		if _, ok := ctrErrors[id]; !ok {
+			ctrErrors[id] = err
+		}
+	}
+
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error restarting some containers")
+	}
+
+	return nil, nil
+}
+
+// Pause pauses all containers within a pod that are running
+// Only running containers will be paused. Paused, stopped, or created
+// containers will be ignored.
+// All containers are paused independently. An error pausing one container
+// will not prevent other containers being paused.
+// An error and a map[string]error are returned
+// If the error is not nil and the map is nil, an error was encountered before
+// any containers were paused
+// If map is not nil, an error was encountered when pausing one or more
+// containers. The container ID is mapped to the error encountered. The error is
+// set to ErrCtrExists
+// If both error and the map are nil, all containers were paused without error
+func (p *Pod) Pause() (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrErrors := make(map[string]error)
+
+	// Pause all containers
 	for _, ctr := range allCtrs {
 		ctr.lock.Lock()
 
@@ -338,23 +667,94 @@ func (p *Pod) Stop(cleanup bool) (map[string]error, error) {
 			continue
 		}
 
-		if err := ctr.stop(ctr.config.StopTimeout); err != nil {
+		if err := ctr.runtime.ociRuntime.pauseContainer(ctr); err != nil {
 			ctr.lock.Unlock()
 			ctrErrors[ctr.ID()] = err
 			continue
 		}
 
-		if cleanup {
-			if err := ctr.cleanup(); err != nil {
-				ctrErrors[ctr.ID()] = err
-			}
+		logrus.Debugf("Paused container %s", ctr.ID())
+
+		ctr.state.State = ContainerStatePaused
+		ctr.newContainerEvent(events.Pause)
+
+		if err := ctr.save(); err != nil {
+			ctrErrors[ctr.ID()] = err
 		}
 
 		ctr.lock.Unlock()
 	}
 
 	if len(ctrErrors) > 0 {
-		return ctrErrors, errors.Wrapf(ErrCtrExists, "error stopping some containers")
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error pausing some containers")
+	}
+
+	return nil, nil
+}
+
+// Unpause unpauses all containers within a pod that are paused
+// Only paused containers will be unpaused. Running, stopped, or created
+// containers will be ignored.
+// All containers are unpaused independently. An error unpausing one container
+// will not prevent other containers being unpaused.
+// An error and a map[string]error are returned
+// If the error is not nil and the map is nil, an error was encountered before
+// any containers were unpaused
+// If map is not nil, an error was encountered when unpausing one or more
+// containers. The container ID is mapped to the error encountered. The error is
+// set to ErrCtrExists
+// If both error and the map are nil, all containers were unpaused without error
+func (p *Pod) Unpause() (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrErrors := make(map[string]error)
+
+	// Unpause all containers
+	for _, ctr := range allCtrs {
+		ctr.lock.Lock()
+
+		if err := ctr.syncContainer(); err != nil {
+			ctr.lock.Unlock()
+			ctrErrors[ctr.ID()] = err
+			continue
+		}
+
+		// Ignore containers that are not paused
+		if ctr.state.State != ContainerStatePaused {
+			ctr.lock.Unlock()
+			continue
+		}
+
+		if err := ctr.runtime.ociRuntime.unpauseContainer(ctr); err != nil {
+			ctr.lock.Unlock()
+			ctrErrors[ctr.ID()] = err
+			continue
+		}
+
+		logrus.Debugf("Unpaused container %s", ctr.ID())
+
+		ctr.state.State = ContainerStateRunning
+		ctr.newContainerEvent(events.Unpause)
+
+		if err := ctr.save(); err != nil {
+			ctrErrors[ctr.ID()] = err
+		}
+
+		ctr.lock.Unlock()
+	}
+
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error unpausing some containers")
 	}
 
 	return nil, nil
@@ -407,12 +807,24 @@ func (p *Pod) Kill(signal uint) (map[string]error, error) {
 			ctrErrors[ctr.ID()] = err
 			continue
 		}
+		ctr.newKillEvent(signal)
+
+		if ctr.signalStopsContainer(signal) {
+			ctr.state.StoppedByUser = true
+			if err := ctr.save(); err != nil {
+				ctr.lock.Unlock()
+				ctrErrors[ctr.ID()] = err
+				continue
+			}
+		}
 
 		logrus.Debugf("Killed container %s with signal %d", ctr.ID(), signal)
+
+		ctr.lock.Unlock()
 	}
 
 	if len(ctrErrors) > 0 {
-		return ctrErrors, nil
+		return ctrErrors, errors.Wrapf(ErrCtrExists, "error killing some containers")
 	}
 
 	return nil, nil
@@ -485,6 +897,54 @@ func (p *Pod) Status() (map[string]ContainerStatus, error) {
 	return status, nil
 }
 
+// Inspect returns a PodInspect struct to describe the pod
+func (p *Pod) Inspect() (*PodInspect, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	if err := p.updatePod(); err != nil {
+		return nil, err
+	}
+
+	containers, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	podContainers := make([]PodContainerInfo, 0, len(containers))
+	for _, c := range containers {
+		containerStatus := "unknown"
+		// Ignoring possible errors here because we don't want this to be
+		// catastrophic in nature
+		containerState, err := c.State()
+		if err == nil {
+			containerStatus = containerState.String()
+		}
+		podContainers = append(podContainers, PodContainerInfo{
+			ID:    c.ID(),
+			State: containerStatus,
+		})
+	}
+
+	config := new(PodConfig)
+	deepcopier.Copy(p.config).To(config)
+
+	inspectData := PodInspect{
+		Config: config,
+		State: &PodInspectState{
+			CgroupPath:       p.state.CgroupPath,
+			InfraContainerID: p.state.InfraContainerID,
+		},
+		Containers: podContainers,
+	}
+
+	return &inspectData, nil
+}
+
 // TODO add pod batching
 // Lock pod to avoid lock contention
 // Store and lock all containers (no RemoveContainer in batch guarantees cache will not become stale)
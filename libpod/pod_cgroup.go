@@ -0,0 +1,215 @@
+package libpod
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/cgroups"
+	systemdDbus "github.com/coreos/go-systemd/dbus"
+	"github.com/godbus/dbus"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCPUPeriod is the CFS period, in microseconds, used for a pod's CPU
+// quota when no period is given
+const DefaultCPUPeriod = 100000
+
+// Get the name of the systemd slice holding the cgroup of a pod, nested in the
+// given parent slice
+func systemdPodSlice(parent, id string) string {
+	name := fmt.Sprintf("libpod_pod_%s", id)
+	prefix := strings.TrimSuffix(path.Base(parent), ".slice")
+	// The root slice (-.slice) has no prefix
+	if prefix != "" && prefix != "-" {
+		name = prefix + "-" + name
+	}
+	return name + ".slice"
+}
+
+// Convert a systemd slice name into its path in the cgroup hierarchy
+// For example, machine-libpod_pod_1234.slice will be converted into
+// /machine.slice/machine-libpod_pod_1234.slice
+func expandSystemdSlice(slice string) string {
+	cgroupPath := "/"
+	prefix := ""
+	for _, component := range strings.Split(strings.TrimSuffix(slice, ".slice"), "-") {
+		prefix += component
+		cgroupPath = filepath.Join(cgroupPath, prefix+".slice")
+		prefix += "-"
+	}
+	return cgroupPath
+}
+
+// Does the pod restrict the CPUs or memory nodes its containers may use?
+func hasCPUSet(resources *spec.LinuxResources) bool {
+	return resources != nil && resources.CPU != nil && (resources.CPU.Cpus != "" || resources.CPU.Mems != "")
+}
+
+// Create the pod's cgroup and apply the pod's resource limits to it
+// The pod's cgroup path must be set before calling
+func (p *Pod) createCgroup() error {
+	if p.state.CgroupPath == "" {
+		return nil
+	}
+
+	switch p.runtime.config.CgroupManager {
+	case CgroupfsCgroupsManager:
+		// Without limits there is no need to create the cgroup - the
+		// first container to launch should do it for us
+		if p.config.CgroupResources == nil {
+			return nil
+		}
+
+		logrus.Debugf("Creating cgroup %s for pod %s", p.state.CgroupPath, p.ID())
+
+		if _, err := cgroups.New(cgroups.V1, cgroups.StaticPath(p.state.CgroupPath), p.config.CgroupResources); err != nil {
+			return errors.Wrapf(err, "error creating cgroup %s for pod %s", p.state.CgroupPath, p.ID())
+		}
+	case SystemdCgroupsManager:
+		logrus.Debugf("Creating slice %s for pod %s", p.state.CgroupPath, p.ID())
+
+		if err := startSystemdSlice(p.state.CgroupPath, fmt.Sprintf("libpod pod %s", p.ID()), p.config.CgroupResources); err != nil {
+			return errors.Wrapf(err, "error creating slice %s for pod %s", p.state.CgroupPath, p.ID())
+		}
+
+		// systemd does not manage the cpuset controller, so restrict
+		// CPUs and memory nodes through cgroupfs at the slice's path
+		if hasCPUSet(p.config.CgroupResources) {
+			cpuset := &spec.LinuxResources{
+				CPU: &spec.LinuxCPU{
+					Cpus: p.config.CgroupResources.CPU.Cpus,
+					Mems: p.config.CgroupResources.CPU.Mems,
+				},
+			}
+			cgroupPath := expandSystemdSlice(p.state.CgroupPath)
+			if _, err := cgroups.New(cgroups.SingleSubsystem(cgroups.V1, cgroups.Cpuset), cgroups.StaticPath(cgroupPath), cpuset); err != nil {
+				return errors.Wrapf(err, "error creating cpuset cgroup %s for pod %s", cgroupPath, p.ID())
+			}
+		}
+	default:
+		return errors.Wrapf(ErrInvalidArg, "unknown cgroups manager %s specified", p.runtime.config.CgroupManager)
+	}
+
+	return nil
+}
+
+// Remove the pod's cgroup
+func (p *Pod) removeCgroup() error {
+	if p.state.CgroupPath == "" {
+		return nil
+	}
+
+	switch p.runtime.config.CgroupManager {
+	case SystemdCgroupsManager:
+		logrus.Debugf("Removing pod slice %s", p.state.CgroupPath)
+
+		if hasCPUSet(p.config.CgroupResources) {
+			cgroupPath := expandSystemdSlice(p.state.CgroupPath)
+			cgroup, err := cgroups.Load(cgroups.SingleSubsystem(cgroups.V1, cgroups.Cpuset), cgroups.StaticPath(cgroupPath))
+			if err != nil && err != cgroups.ErrCgroupDeleted {
+				return err
+			} else if err == nil {
+				if err := cgroup.Delete(); err != nil {
+					return err
+				}
+			}
+		}
+
+		return stopSystemdSlice(p.state.CgroupPath)
+	case CgroupfsCgroupsManager:
+		// Delete the cgroupfs cgroup
+		logrus.Debugf("Removing pod cgroup %s", p.state.CgroupPath)
+
+		cgroup, err := cgroups.Load(cgroups.V1, cgroups.StaticPath(p.state.CgroupPath))
+		if err != nil && err != cgroups.ErrCgroupDeleted {
+			return err
+		} else if err == nil {
+			if err := cgroup.Delete(); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Wrapf(ErrInvalidArg, "unknown cgroups manager %s specified", p.runtime.config.CgroupManager)
+	}
+
+	return nil
+}
+
+// Start a transient systemd slice with the given resource limits
+// CPU and memory node restrictions are not supported by systemd and are
+// ignored
+func startSystemdSlice(slice, description string, resources *spec.LinuxResources) error {
+	conn, err := systemdDbus.New()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	properties := []systemdDbus.Property{
+		systemdDbus.PropDescription(description),
+		newSystemdProperty("MemoryAccounting", true),
+		newSystemdProperty("CPUAccounting", true),
+		newSystemdProperty("BlockIOAccounting", true),
+		newSystemdProperty("TasksAccounting", true),
+	}
+	if resources != nil {
+		if resources.Memory != nil && resources.Memory.Limit != nil {
+			properties = append(properties, newSystemdProperty("MemoryLimit", uint64(*resources.Memory.Limit)))
+		}
+		if resources.CPU != nil {
+			if resources.CPU.Shares != nil {
+				properties = append(properties, newSystemdProperty("CPUShares", *resources.CPU.Shares))
+			}
+			if resources.CPU.Quota != nil && resources.CPU.Period != nil && *resources.CPU.Period != 0 {
+				// systemd expects the quota as CPU time per second
+				quotaPerSec := uint64(*resources.CPU.Quota) * 1000000 / *resources.CPU.Period
+				properties = append(properties, newSystemdProperty("CPUQuotaPerSecUSec", quotaPerSec))
+			}
+		}
+		if resources.Pids != nil {
+			properties = append(properties, newSystemdProperty("TasksMax", uint64(resources.Pids.Limit)))
+		}
+	}
+
+	ch := make(chan string)
+	if _, err := conn.StartTransientUnit(slice, "replace", properties, ch); err != nil {
+		return err
+	}
+
+	// Block until job is started
+	if result := <-ch; result != "done" {
+		return errors.Errorf("job starting slice %s finished with result %s", slice, result)
+	}
+
+	return nil
+}
+
+// Stop a systemd slice
+func stopSystemdSlice(slice string) error {
+	conn, err := systemdDbus.New()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch := make(chan string)
+	if _, err := conn.StopUnit(slice, "replace", ch); err != nil {
+		return err
+	}
+
+	// Block until job is finished
+	<-ch
+
+	return nil
+}
+
+func newSystemdProperty(name string, value interface{}) systemdDbus.Property {
+	return systemdDbus.Property{
+		Name:  name,
+		Value: dbus.MakeVariant(value),
+	}
+}
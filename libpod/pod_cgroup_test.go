@@ -0,0 +1,18 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemdPodSlice(t *testing.T) {
+	assert.Equal(t, "machine-libpod_pod_1234.slice", systemdPodSlice("machine.slice", "1234"))
+	assert.Equal(t, "system-libpod-libpod_pod_1234.slice", systemdPodSlice("system-libpod.slice", "1234"))
+	assert.Equal(t, "libpod_pod_1234.slice", systemdPodSlice("-.slice", "1234"))
+}
+
+func TestExpandSystemdSlice(t *testing.T) {
+	assert.Equal(t, "/machine.slice/machine-libpod_pod_1234.slice", expandSystemdSlice("machine-libpod_pod_1234.slice"))
+	assert.Equal(t, "/libpod_pod_1234.slice", expandSystemdSlice("libpod_pod_1234.slice"))
+}
@@ -0,0 +1,203 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Contains the allocation of host ports to the port mappings of containers
+
+// ephemeralPortRangePath holds the range of ports the kernel assigns to local
+// sockets, which is also used for host ports allocated to containers
+const ephemeralPortRangePath = "/proc/sys/net/ipv4/ip_local_port_range"
+
+// defaultEphemeralPortRange is the range host ports are allocated from if the
+// kernel's range cannot be read
+var defaultEphemeralPortRange = [2]int32{32768, 60999}
+
+// hostPortUser is a host port used by a container
+type hostPortUser struct {
+	mapping ocicni.PortMapping
+	ctrID   string
+}
+
+// Allocate host ports to the container's port mappings that have none, and
+// check that the host ports of the others are not used by another container or
+// by a process on the host
+// Must be called with the network lock held, so no other container is created
+// with the same host ports in the meantime
+func (r *Runtime) allocateHostPorts(ctr *Container) error {
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return err
+	}
+
+	var used []hostPortUser
+	for _, other := range ctrs {
+		for _, mapping := range other.config.PortMappings {
+			used = append(used, hostPortUser{mapping: mapping, ctrID: other.ID()})
+		}
+	}
+
+	mappings, err := allocatePorts(ctr.ID(), ctr.config.PortMappings, used, ephemeralPortRange(), hostPortFree)
+	if err != nil {
+		return err
+	}
+	ctr.config.PortMappings = mappings
+	return nil
+}
+
+// Allocate host ports to the given port mappings of a container that have none,
+// from the given range of ports
+// Mappings of the same container port and host IP, for different protocols,
+// are allocated the same host port. Errors are returned for host ports that
+// are used by the containers given, or that isFree reports as used on the
+// host.
+func allocatePorts(ctrID string, mappings []ocicni.PortMapping, used []hostPortUser, portRange [2]int32, isFree func(hostIP string, port int32, protocol string) bool) ([]ocicni.PortMapping, error) {
+	allocated := make([]ocicni.PortMapping, len(mappings))
+	copy(allocated, mappings)
+
+	for _, mapping := range allocated {
+		if mapping.HostPort == 0 {
+			continue
+		}
+		for _, user := range used {
+			if hostPortsConflict(mapping, user.mapping) {
+				return nil, errors.Wrapf(ErrPortInUse, "host port %d/%s is already used by container %s", mapping.HostPort, mapping.Protocol, user.ctrID)
+			}
+		}
+		if !isFree(mapping.HostIP, mapping.HostPort, mapping.Protocol) {
+			return nil, errors.Wrapf(ErrPortInUse, "host port %d/%s is already used on the host", mapping.HostPort, mapping.Protocol)
+		}
+		used = append(used, hostPortUser{mapping: mapping, ctrID: ctrID})
+	}
+
+	next := portRange[0]
+	for i := range allocated {
+		if allocated[i].HostPort != 0 {
+			continue
+		}
+
+		// All mappings sharing the host port are allocated together
+		var group []int
+		for j := i; j < len(allocated); j++ {
+			if allocated[j].HostPort == 0 && allocated[j].ContainerPort == allocated[i].ContainerPort && allocated[j].HostIP == allocated[i].HostIP {
+				group = append(group, j)
+			}
+		}
+
+		for ; next <= portRange[1]; next++ {
+			free := true
+			for _, j := range group {
+				candidate := allocated[j]
+				candidate.HostPort = next
+				for _, user := range used {
+					if hostPortsConflict(candidate, user.mapping) {
+						free = false
+						break
+					}
+				}
+				if free && !isFree(candidate.HostIP, next, candidate.Protocol) {
+					free = false
+				}
+				if !free {
+					break
+				}
+			}
+			if free {
+				break
+			}
+		}
+		if next > portRange[1] {
+			return nil, errors.Wrapf(ErrPortInUse, "no free host port in range %d-%d for container port %d", portRange[0], portRange[1], allocated[i].ContainerPort)
+		}
+
+		logrus.Debugf("Using host port %d for container port %d of container %s", next, allocated[i].ContainerPort, ctrID)
+		for _, j := range group {
+			allocated[j].HostPort = next
+			used = append(used, hostPortUser{mapping: allocated[j], ctrID: ctrID})
+		}
+		next++
+	}
+
+	return allocated, nil
+}
+
+// Whether two port mappings use the same port on the host
+func hostPortsConflict(a, b ocicni.PortMapping) bool {
+	if a.HostPort != b.HostPort || a.Protocol != b.Protocol {
+		return false
+	}
+	return hostIPsOverlap(a.HostIP, b.HostIP)
+}
+
+// Whether ports bound to the given host IPs conflict
+// A port bound to no IP or an unspecified one is bound to all of the host's IPs
+func hostIPsOverlap(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipA.IsUnspecified() || ipB == nil || ipB.IsUnspecified() {
+		return true
+	}
+	return ipA.Equal(ipB)
+}
+
+// Whether no process on the host uses the given port
+func hostPortFree(hostIP string, port int32, protocol string) bool {
+	address := net.JoinHostPort(hostIP, strconv.Itoa(int(port)))
+
+	var err error
+	if protocol == "udp" {
+		var conn net.PacketConn
+		if conn, err = net.ListenPacket("udp", address); err == nil {
+			conn.Close()
+		}
+	} else {
+		var listener net.Listener
+		if listener, err = net.Listen("tcp", address); err == nil {
+			listener.Close()
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	// Other errors, like addresses not present on the host, do not
+	// indicate that the port is used
+	if opErr, ok := err.(*net.OpError); ok {
+		if sysErr, ok := opErr.Err.(*os.SyscallError); ok {
+			return sysErr.Err != syscall.EADDRINUSE
+		}
+	}
+	return true
+}
+
+// Get the range of ports the kernel assigns to local sockets
+func ephemeralPortRange() [2]int32 {
+	contents, err := ioutil.ReadFile(ephemeralPortRangePath)
+	if err != nil {
+		logrus.Debugf("Error reading ephemeral port range: %v", err)
+		return defaultEphemeralPortRange
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) != 2 {
+		return defaultEphemeralPortRange
+	}
+	low, err := strconv.ParseInt(fields[0], 10, 32)
+	if err != nil {
+		return defaultEphemeralPortRange
+	}
+	high, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil || high < low {
+		return defaultEphemeralPortRange
+	}
+	return [2]int32{int32(low), int32(high)}
+}
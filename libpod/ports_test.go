@@ -0,0 +1,74 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func allPortsFree(hostIP string, port int32, protocol string) bool {
+	return true
+}
+
+func TestAllocatePorts(t *testing.T) {
+	used := []hostPortUser{
+		{mapping: ocicni.PortMapping{HostPort: 40000, ContainerPort: 80, Protocol: "tcp"}, ctrID: "other"},
+		{mapping: ocicni.PortMapping{HostPort: 40002, ContainerPort: 80, Protocol: "udp", HostIP: "127.0.0.1"}, ctrID: "other"},
+	}
+	mappings := []ocicni.PortMapping{
+		{ContainerPort: 80, Protocol: "udp"},
+		{ContainerPort: 80, Protocol: "tcp"},
+		{ContainerPort: 443, Protocol: "tcp"},
+		{ContainerPort: 8080, HostPort: 8080, Protocol: "tcp"},
+	}
+
+	allocated, err := allocatePorts("ctr", mappings, used, [2]int32{40000, 40010}, allPortsFree)
+	assert.NoError(t, err)
+	// Both protocols of a container port get the same host port, which is
+	// free for both
+	assert.Equal(t, int32(40001), allocated[0].HostPort)
+	assert.Equal(t, int32(40001), allocated[1].HostPort)
+	assert.Equal(t, int32(40002), allocated[2].HostPort)
+	assert.Equal(t, int32(8080), allocated[3].HostPort)
+	// The given mappings are left alone
+	assert.Equal(t, int32(0), mappings[0].HostPort)
+
+	// Ports used by processes on the host are skipped
+	allocated, err = allocatePorts("ctr", mappings[2:3], used, [2]int32{40000, 40010}, func(hostIP string, port int32, protocol string) bool {
+		return port != 40001
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(40002), allocated[0].HostPort)
+
+	_, err = allocatePorts("ctr", mappings, used, [2]int32{40000, 40001}, allPortsFree)
+	assert.Equal(t, ErrPortInUse, errors.Cause(err))
+}
+
+func TestAllocatePortsConflicts(t *testing.T) {
+	used := []hostPortUser{
+		{mapping: ocicni.PortMapping{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "127.0.0.1"}, ctrID: "other"},
+	}
+
+	_, err := allocatePorts("ctr", []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}}, used, defaultEphemeralPortRange, allPortsFree)
+	assert.Equal(t, ErrPortInUse, errors.Cause(err))
+
+	// Ports on other host IPs and for other protocols do not conflict
+	_, err = allocatePorts("ctr", []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "127.0.0.2"}}, used, defaultEphemeralPortRange, allPortsFree)
+	assert.NoError(t, err)
+	_, err = allocatePorts("ctr", []ocicni.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "udp"}}, used, defaultEphemeralPortRange, allPortsFree)
+	assert.NoError(t, err)
+
+	// The same host port cannot be used twice by a container
+	_, err = allocatePorts("ctr", []ocicni.PortMapping{
+		{HostPort: 9090, ContainerPort: 80, Protocol: "tcp"},
+		{HostPort: 9090, ContainerPort: 81, Protocol: "tcp"},
+	}, nil, defaultEphemeralPortRange, allPortsFree)
+	assert.Equal(t, ErrPortInUse, errors.Cause(err))
+
+	_, err = allocatePorts("ctr", []ocicni.PortMapping{{HostPort: 9090, ContainerPort: 80, Protocol: "tcp"}}, nil, defaultEphemeralPortRange, func(hostIP string, port int32, protocol string) bool {
+		return false
+	})
+	assert.Equal(t, ErrPortInUse, errors.Cause(err))
+}
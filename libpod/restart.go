@@ -0,0 +1,307 @@
+package libpod
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// RestartPolicyNone indicates that no restart policy has been requested
+	// for the container. It is identical in function to RestartPolicyNo
+	RestartPolicyNone = ""
+	// RestartPolicyNo indicates that the container will not be restarted
+	// when it exits
+	RestartPolicyNo = "no"
+	// RestartPolicyOnFailure indicates that the container will be restarted
+	// when it exits with a non-zero exit code, up to an optional maximum
+	// number of retries
+	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyAlways indicates that the container will be restarted
+	// whenever it exits, unless it was stopped by the user
+	// Containers with this policy are also started at boot
+	RestartPolicyAlways = "always"
+	// RestartPolicyUnlessStopped is identical to RestartPolicyAlways, except
+	// that containers with this policy are not started at boot
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+const (
+	// restartDelayBase is the delay before a container is first restarted
+	// The delay doubles with each further restart
+	restartDelayBase = 100 * time.Millisecond
+	// restartDelayMax is the maximum delay between restarts
+	restartDelayMax = time.Minute
+	// restartDelayReset is how long a container must run before exiting
+	// for it to be restarted with the base delay again
+	restartDelayReset = 10 * time.Second
+	// logRotationInterval is how often the log files of containers are
+	// checked for having reached their maximum size
+	logRotationInterval = time.Second
+	// logRotationPercent is the percentage of their maximum size at which
+	// the log files of containers are rotated
+	logRotationPercent = 90
+	// healthCheckPollInterval is how often containers are checked for
+	// healthchecks that are due
+	healthCheckPollInterval = time.Second
+)
+
+// Supervise watches for containers exiting, cleans up their resources, and
+// restarts them as required by their restart policies
+// It also rotates the log files of containers once they reach their maximum
+// size, and runs the healthchecks of running containers at their intervals
+// Containers that exited before Supervise was called are handled immediately
+// Supervise blocks until the given context is cancelled, and waits for pending
+// restarts to be abandoned and running healthchecks to finish before returning
+func (r *Runtime) Supervise(ctx context.Context) error {
+	r.lock.RLock()
+	if !r.valid {
+		r.lock.RUnlock()
+		return ErrRuntimeStopped
+	}
+	exitsDir := r.ociRuntime.exitsDir
+	lockPath := r.superviseLockPath()
+	r.lock.RUnlock()
+
+	// Hold the supervise lock while running, so it can be told that
+	// containers are supervised
+	lockFile, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error opening supervise lock %s", lockPath)
+	}
+	defer lockFile.Close()
+	if err := unix.Flock(int(lockFile.Fd()), unix.LOCK_SH); err != nil {
+		return errors.Wrapf(err, "error acquiring supervise lock %s", lockPath)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrapf(err, "error creating watcher for exit files")
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(exitsDir); err != nil {
+		return errors.Wrapf(err, "error watching %s for exit files", exitsDir)
+	}
+	logrus.Debugf("Monitoring %s for exited containers", exitsDir)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	handleExit := func(id string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.handleContainerExit(ctx, id)
+		}()
+	}
+
+	// Handle containers that exited before we started watching
+	files, err := ioutil.ReadDir(exitsDir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading exit files from %s", exitsDir)
+	}
+	for _, file := range files {
+		handleExit(file.Name())
+	}
+
+	rotationTicker := time.NewTicker(logRotationInterval)
+	defer rotationTicker.Stop()
+
+	var healthChecks healthCheckSet
+	healthCheckTicker := time.NewTicker(healthCheckPollInterval)
+	defer healthCheckTicker.Stop()
+
+	for {
+		select {
+		case <-rotationTicker.C:
+			r.rotateLogs()
+		case <-healthCheckTicker.C:
+			r.runDueHealthChecks(&healthChecks, &wg)
+		case event := <-watcher.Events:
+			// conmon writes exit files atomically, by renaming them
+			// into place, so creation is all we need to look for
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				handleExit(filepath.Base(event.Name))
+			}
+		case err := <-watcher.Errors:
+			logrus.Errorf("Error monitoring %s for exited containers: %v", exitsDir, err)
+		case <-ctx.Done():
+			logrus.Debugf("Container supervision canceled: %v", ctx.Err())
+			return nil
+		}
+	}
+}
+
+// Get the path of the lock Supervise holds while it runs
+func (r *Runtime) superviseLockPath() string {
+	return filepath.Join(r.config.TmpDir, "supervise.lock")
+}
+
+// Determine whether Supervise is running, in this or another process
+func (r *Runtime) superviseRunning() (bool, error) {
+	lockPath := r.superviseLockPath()
+	lockFile, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return false, errors.Wrapf(err, "error opening supervise lock %s", lockPath)
+	}
+	defer lockFile.Close()
+
+	// Supervise holds a shared lock, which keeps an exclusive lock from
+	// being acquired
+	if err := unix.Flock(int(lockFile.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		if err == unix.EWOULDBLOCK {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "error checking supervise lock %s", lockPath)
+	}
+	if err := unix.Flock(int(lockFile.Fd()), unix.LOCK_UN); err != nil {
+		return false, errors.Wrapf(err, "error releasing supervise lock %s", lockPath)
+	}
+	return false, nil
+}
+
+// Handle the exit file of the container with the given ID
+func (r *Runtime) handleContainerExit(ctx context.Context, id string) {
+	ctr, err := r.state.Container(id)
+	if err != nil {
+		// Not every file in the exits directory is a container's
+		// exit file, and the container may have been removed since
+		logrus.Debugf("Ignoring exit file %s: %v", id, err)
+		return
+	}
+
+	if err := ctr.handleExit(ctx); err != nil {
+		if errors.Cause(err) == ErrCtrRemoved {
+			return
+		}
+		logrus.Errorf("Error handling exit of container %s: %v", id, err)
+	}
+}
+
+// Clean up a container that has exited, and restart it after a delay if its
+// restart policy requires it
+// Must be called without the container locked
+func (c *Container) handleExit(ctx context.Context) error {
+	c.lock.Lock()
+	if err := c.syncContainer(); err != nil {
+		c.lock.Unlock()
+		return err
+	}
+
+	if c.state.State != ContainerStateStopped {
+		c.lock.Unlock()
+		return nil
+	}
+
+	if err := c.cleanup(); err != nil {
+		c.lock.Unlock()
+		return errors.Wrapf(err, "error cleaning up container %s", c.ID())
+	}
+
+	if !c.shouldRestart() {
+		c.lock.Unlock()
+		return nil
+	}
+
+	delay := restartDelay(c.state.RestartCount)
+	if c.state.FinishedTime.Sub(c.state.StartedTime) >= restartDelayReset {
+		delay = restartDelayBase
+	}
+	c.lock.Unlock()
+
+	logrus.Debugf("Restarting container %s in %v", c.ID(), delay)
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil
+	}
+
+	return c.restartByPolicy(ctx)
+}
+
+// Restart an exited container as required by its restart policy
+// Must be called without the container locked
+func (c *Container) restartByPolicy(ctx context.Context) error {
+	if err := c.startPodInfraContainer(ctx); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	// The container may have been started or stopped by the user while we
+	// were waiting to restart it
+	if !c.shouldRestart() {
+		return nil
+	}
+
+	c.state.RestartCount++
+	logrus.Debugf("Container %s exited with code %d, restarting it (restart count %d) due to restart policy %s",
+		c.ID(), c.state.ExitCode, c.state.RestartCount, c.config.RestartPolicy)
+
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	return c.initAndStart(ctx)
+}
+
+// Determine whether sending a signal to the container counts as the user
+// stopping it, so its restart policy must not bring it back up when it exits
+// Only SIGKILL and the container's stop signal do; other signals, like SIGHUP
+// asking the container to reload, leave the restart policy in effect
+func (c *Container) signalStopsContainer(signal uint) bool {
+	stopSignal := c.config.StopSignal
+	if stopSignal == 0 {
+		stopSignal = uint(syscall.SIGTERM)
+	}
+	return signal == uint(syscall.SIGKILL) || signal == stopSignal
+}
+
+// Determine whether the container's restart policy requires it to be restarted
+// Must be called with the container locked and its state synced
+func (c *Container) shouldRestart() bool {
+	if c.state.State != ContainerStateStopped || c.state.StoppedByUser {
+		return false
+	}
+
+	switch c.config.RestartPolicy {
+	case RestartPolicyAlways, RestartPolicyUnlessStopped:
+		return true
+	case RestartPolicyOnFailure:
+		if c.state.ExitCode == 0 {
+			return false
+		}
+		return c.config.RestartRetries == 0 || c.state.RestartCount < c.config.RestartRetries
+	default:
+		return false
+	}
+}
+
+// Get the delay before a container that was already restarted the given number
+// of times is restarted again
+func restartDelay(restartCount uint) time.Duration {
+	delay := restartDelayBase
+	for i := uint(0); i < restartCount; i++ {
+		delay *= 2
+		if delay >= restartDelayMax {
+			return restartDelayMax
+		}
+	}
+	return delay
+}
@@ -0,0 +1,77 @@
+package libpod
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeExitedContainer(policy string, retries uint, exitCode int32) *Container {
+	return &Container{
+		config: &ContainerConfig{
+			RestartPolicy:  policy,
+			RestartRetries: retries,
+		},
+		state: &containerState{
+			State:    ContainerStateStopped,
+			ExitCode: exitCode,
+		},
+	}
+}
+
+func TestShouldRestartNoPolicy(t *testing.T) {
+	assert.False(t, makeExitedContainer(RestartPolicyNone, 0, 1).shouldRestart())
+	assert.False(t, makeExitedContainer(RestartPolicyNo, 0, 1).shouldRestart())
+}
+
+func TestShouldRestartAlways(t *testing.T) {
+	for _, policy := range []string{RestartPolicyAlways, RestartPolicyUnlessStopped} {
+		assert.True(t, makeExitedContainer(policy, 0, 0).shouldRestart())
+		assert.True(t, makeExitedContainer(policy, 0, 1).shouldRestart())
+	}
+}
+
+func TestShouldRestartOnFailure(t *testing.T) {
+	assert.False(t, makeExitedContainer(RestartPolicyOnFailure, 0, 0).shouldRestart())
+	assert.True(t, makeExitedContainer(RestartPolicyOnFailure, 0, 1).shouldRestart())
+
+	ctr := makeExitedContainer(RestartPolicyOnFailure, 2, 1)
+	ctr.state.RestartCount = 1
+	assert.True(t, ctr.shouldRestart())
+	ctr.state.RestartCount = 2
+	assert.False(t, ctr.shouldRestart())
+}
+
+func TestShouldRestartStoppedByUser(t *testing.T) {
+	ctr := makeExitedContainer(RestartPolicyAlways, 0, 0)
+	ctr.state.StoppedByUser = true
+	assert.False(t, ctr.shouldRestart())
+}
+
+func TestSignalStopsContainer(t *testing.T) {
+	ctr := makeExitedContainer(RestartPolicyAlways, 0, 0)
+	assert.True(t, ctr.signalStopsContainer(uint(syscall.SIGKILL)))
+	assert.True(t, ctr.signalStopsContainer(uint(syscall.SIGTERM)))
+	assert.False(t, ctr.signalStopsContainer(uint(syscall.SIGHUP)))
+	assert.False(t, ctr.signalStopsContainer(uint(syscall.SIGUSR1)))
+
+	ctr.config.StopSignal = uint(syscall.SIGINT)
+	assert.True(t, ctr.signalStopsContainer(uint(syscall.SIGINT)))
+	assert.False(t, ctr.signalStopsContainer(uint(syscall.SIGTERM)))
+}
+
+func TestShouldRestartRunning(t *testing.T) {
+	ctr := makeExitedContainer(RestartPolicyAlways, 0, 0)
+	ctr.state.State = ContainerStateRunning
+	assert.False(t, ctr.shouldRestart())
+}
+
+func TestRestartDelay(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, restartDelay(0))
+	assert.Equal(t, 200*time.Millisecond, restartDelay(1))
+	assert.Equal(t, 800*time.Millisecond, restartDelay(3))
+	assert.Equal(t, time.Minute, restartDelay(10))
+	assert.Equal(t, time.Minute, restartDelay(1000))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/docker/pkg/namesgenerator"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/projectatomic/libpod/libpod/image"
 	"github.com/projectatomic/libpod/pkg/hooks"
 	sysreg "github.com/projectatomic/libpod/pkg/registries"
@@ -54,6 +55,11 @@ const (
 	// configuration file. If OverrideConfigPath exists, it will be used in
 	// place of the configuration file pointed to by ConfigPath.
 	OverrideConfigPath = "/etc/containers/libpod.conf"
+
+	// DefaultInfraImage to use for infra container
+	DefaultInfraImage = "k8s.gcr.io/pause:3.1"
+	// DefaultInfraCommand to be run in an infra container
+	DefaultInfraCommand = "/pause"
 )
 
 // A RuntimeOption is a functional option which alters the Runtime created by
@@ -75,6 +81,7 @@ type Runtime struct {
 	valid          bool
 	lock           sync.RWMutex
 	imageRuntime   *image.Runtime
+	eventer        *events.Eventer
 }
 
 // RuntimeConfig contains configuration options used to set up the runtime
@@ -129,12 +136,34 @@ type RuntimeConfig struct {
 	// CNIPluginDir sets a number of directories where the CNI network
 	// plugins can be located
 	CNIPluginDir []string `toml:"cni_plugin_dir"`
+	// CNIDefaultNetwork is the name of the CNI network containers join
+	// when they are not given any network
+	// If it is empty or the network does not exist, the first network in
+	// CNIConfigDir is used
+	CNIDefaultNetwork string `toml:"cni_default_network,omitempty"`
 	// HooksDir Path to the directory containing hooks configuration files
 	HooksDir string `toml:"hooks_dir"`
 	// HooksDirNotExistFatal switches between fatal errors and non-fatal warnings if the configured HooksDir does not exist.
 	HooksDirNotExistFatal bool `toml:"hooks_dir_not_exist_fatal"`
 	// DefaultMountsFile is the path to the default mounts file for testing purposes only
 	DefaultMountsFile string `toml:"-"`
+	// InfraImage is the image a pod infra container will use to manage
+	// namespaces
+	InfraImage string `toml:"infra_image"`
+	// InfraCommand is the command run to start up a pod infra container
+	InfraCommand string `toml:"infra_command"`
+	// EventsLogFilePath is the path to the file events are recorded in
+	// If left empty, events are recorded in the events directory of
+	// StaticDir
+	EventsLogFilePath string `toml:"events_logfile_path,omitempty"`
+	// EventsLogMaxSize is the size, in bytes, the event log may reach
+	// before it is rotated
+	// If not positive, the event log is never rotated
+	EventsLogMaxSize int64 `toml:"events_logfile_max_size,omitempty"`
+	// VolumePath is the directory named volumes are created in
+	// If left empty, volumes are created in the volumes directory of the
+	// storage graph root
+	VolumePath string `toml:"volume_path,omitempty"`
 }
 
 var (
@@ -160,14 +189,18 @@ var (
 		ConmonEnvVars: []string{
 			"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
 		},
-		CgroupManager: CgroupfsCgroupsManager,
-		HooksDir:      hooks.DefaultDir,
-		StaticDir:     filepath.Join(storage.DefaultStoreOptions.GraphRoot, "libpod"),
-		TmpDir:        "/var/run/libpod",
-		MaxLogSize:    -1,
-		NoPivotRoot:   false,
-		CNIConfigDir:  "/etc/cni/net.d/",
-		CNIPluginDir:  []string{"/usr/libexec/cni", "/usr/lib/cni", "/opt/cni/bin"},
+		CgroupManager:     CgroupfsCgroupsManager,
+		HooksDir:          hooks.DefaultDir,
+		StaticDir:         filepath.Join(storage.DefaultStoreOptions.GraphRoot, "libpod"),
+		TmpDir:            "/var/run/libpod",
+		MaxLogSize:        -1,
+		NoPivotRoot:       false,
+		CNIConfigDir:      "/etc/cni/net.d/",
+		CNIPluginDir:      []string{"/usr/libexec/cni", "/usr/lib/cni", "/opt/cni/bin"},
+		CNIDefaultNetwork: "podman",
+		InfraCommand:      DefaultInfraCommand,
+		InfraImage:        DefaultInfraImage,
+		EventsLogMaxSize:  events.DefaultMaxLogSize,
 	}
 )
 
@@ -369,6 +402,25 @@ func makeRuntime(runtime *Runtime) error {
 		}
 	}
 
+	// Set up the event log
+	if runtime.config.EventsLogFilePath == "" {
+		runtime.config.EventsLogFilePath = filepath.Join(runtime.config.StaticDir, "events", "events.log")
+	}
+	runtime.eventer = events.NewEventer(runtime.config.EventsLogFilePath, runtime.config.EventsLogMaxSize)
+	runtime.imageRuntime.Eventer = runtime.eventer
+
+	// Make the directory holding named volumes if it does not exist
+	if runtime.config.VolumePath == "" {
+		runtime.config.VolumePath = filepath.Join(runtime.store.GraphRoot(), "volumes")
+	}
+	if err := os.MkdirAll(runtime.config.VolumePath, 0700); err != nil {
+		// The directory is allowed to exist
+		if !os.IsExist(err) {
+			return errors.Wrapf(err, "error creating runtime volumes directory %s",
+				runtime.config.VolumePath)
+		}
+	}
+
 	// Make a directory to hold container lockfiles
 	lockDir := filepath.Join(runtime.config.TmpDir, "lock")
 	if err := os.MkdirAll(lockDir, 0755); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,6 +37,12 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		return nil, ErrRuntimeStopped
 	}
 
+	return r.newContainer(ctx, rSpec, options...)
+}
+
+// Internal function to create a new container
+// Does not lock the runtime
+func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ...CtrCreateOption) (c *Container, err error) {
 	ctr, err := newContainer(rSpec, r.lockDir)
 	if err != nil {
 		return nil, err
@@ -48,6 +55,44 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		}
 	}
 
+	if ctr.config.RestartRetries != 0 && ctr.config.RestartPolicy != RestartPolicyOnFailure {
+		return nil, errors.Wrapf(ErrInvalidArg, "restart retries can only be set with the %s restart policy", RestartPolicyOnFailure)
+	}
+
+	// Host ports and static IPs are checked against those of the other
+	// containers, so no container using them may be added to the state
+	// until this container is. Networks must not be removed until the
+	// containers joining them are added.
+	if ctr.config.CreateNetNS || ctr.config.StaticIP != nil || len(ctr.config.PortMappings) > 0 || len(ctr.config.Networks) > 0 {
+		networkLock, err := r.getNetworkLock()
+		if err != nil {
+			return nil, err
+		}
+		networkLock.Lock()
+		defer networkLock.Unlock()
+
+		if len(ctr.config.Networks) > 0 {
+			if _, err := r.lookupContainerNetworks(ctr); err != nil {
+				return nil, err
+			}
+		} else if ctr.config.CreateNetNS {
+			// Record the default network the container joins, so it
+			// is detached from the same network even if the default
+			// network changes in the meantime
+			ctr.config.Networks = []string{r.defaultNetworkName()}
+		}
+		if ctr.config.StaticIP != nil {
+			if err := r.validateStaticIP(ctr); err != nil {
+				return nil, err
+			}
+		}
+		if len(ctr.config.PortMappings) > 0 {
+			if err := r.allocateHostPorts(ctr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	ctr.valid = true
 	ctr.state.State = ContainerStateConfigured
 	ctr.runtime = r
@@ -59,6 +104,13 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		if err != nil {
 			return nil, errors.Wrapf(err, "cannot add container %s to pod %s", ctr.ID(), ctr.config.Pod)
 		}
+
+		// Join the namespaces held by the pod's infra container
+		if pod.HasInfraContainer() && !ctr.config.IsInfra {
+			if err := r.joinPodNamespaces(ctr, pod); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if ctr.config.Name == "" {
@@ -104,6 +156,24 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 		return nil, errors.Wrapf(ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.CgroupManager)
 	}
 
+	// Create the named volumes that do not exist yet, and bind mount all
+	// of them into the container
+	// The volumes created are removed again if the container cannot be
+	// created, including when creating a later volume fails
+	createdVolumes, err := r.setupNamedVolumes(ctx, ctr)
+	defer func() {
+		if err != nil {
+			for _, vol := range createdVolumes {
+				if err2 := r.removeVolume(ctx, vol, false); err2 != nil {
+					logrus.Errorf("Error removing volume %s created for container %s: %v", vol.Name(), ctr.ID(), err2)
+				}
+			}
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
 	// Set up storage for the container
 	if err := ctr.setupStorage(ctx); err != nil {
 		return nil, err
@@ -148,9 +218,108 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 			return nil, err
 		}
 	}
+
+	ctr.newContainerEvent(events.Create)
+
 	return ctr, nil
 }
 
+// Create the named volumes of a container that do not exist yet, and add bind
+// mounts of all its named volumes to its spec
+// Mounts already in the spec at the destinations of the volumes, such as
+// those of a container imported from a checkpoint, are replaced
+// The volumes that were created are returned, even along with an error, so
+// they can be removed
+func (r *Runtime) setupNamedVolumes(ctx context.Context, ctr *Container) ([]*Volume, error) {
+	var created []*Volume
+	for _, namedVol := range ctr.config.NamedVolumes {
+		vol, err := r.state.Volume(namedVol.Name)
+		if err != nil {
+			if errors.Cause(err) != ErrNoSuchVolume {
+				return created, errors.Wrapf(err, "error retrieving volume %s", namedVol.Name)
+			}
+			vol, err = r.newVolume(ctx, WithVolumeName(namedVol.Name))
+			if err != nil {
+				return created, errors.Wrapf(err, "error creating volume %s for container %s", namedVol.Name, ctr.ID())
+			}
+			logrus.Debugf("Created volume %s for container %s", vol.Name(), ctr.ID())
+			created = append(created, vol)
+		}
+
+		options := []string{"rbind"}
+		var foundRWRO, foundRootProp bool
+		for _, opt := range namedVol.Options {
+			switch opt {
+			case "z", "Z", "nocopy":
+				// Handled when the volume is mounted
+				continue
+			case "rw", "ro":
+				foundRWRO = true
+			case "private", "rprivate", "slave", "rslave", "shared", "rshared":
+				foundRootProp = true
+			}
+			options = append(options, opt)
+		}
+		if !foundRWRO {
+			options = append(options, "rw")
+		}
+		if !foundRootProp {
+			options = append(options, "private")
+		}
+
+		mounts := make([]spec.Mount, 0, len(ctr.config.Spec.Mounts)+1)
+		for _, mount := range ctr.config.Spec.Mounts {
+			if filepath.Clean(mount.Destination) != namedVol.Dest {
+				mounts = append(mounts, mount)
+			}
+		}
+		ctr.config.Spec.Mounts = append(mounts, spec.Mount{
+			Destination: namedVol.Dest,
+			Type:        "bind",
+			Source:      vol.MountPoint(),
+			Options:     options,
+		})
+	}
+
+	return created, nil
+}
+
+// Set the container to join the namespaces shared by the pod's infra
+// container
+// Namespaces the container was explicitly set to share with another container
+// of the pod are left alone
+func (r *Runtime) joinPodNamespaces(ctr *Container, pod *Pod) error {
+	infraID := pod.state.InfraContainerID
+	if infraID == "" {
+		return errors.Wrapf(ErrInternal, "pod %s has no infra container", pod.ID())
+	}
+	infra, err := r.state.Container(infraID)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving infra container %s of pod %s", infraID, pod.ID())
+	}
+
+	if pod.config.UsePodNet && ctr.config.NetNsCtr == "" {
+		if len(ctr.config.PortMappings) > 0 {
+			return errors.Wrapf(ErrInvalidArg, "container %s joins the network namespace of pod %s and cannot publish ports", ctr.ID(), pod.ID())
+		}
+		ctr.config.CreateNetNS = false
+		ctr.config.PostConfigureNetNS = false
+		ctr.config.NetNsCtr = infraID
+	}
+	if pod.config.UsePodIPC && ctr.config.IPCNsCtr == "" {
+		ctr.config.IPCNsCtr = infraID
+		// Containers sharing an IPC namespace share /dev/shm as well
+		if ctr.config.ShmDir == "" {
+			ctr.config.ShmDir = infra.config.ShmDir
+		}
+	}
+	if pod.config.UsePodUTS && ctr.config.UTSNsCtr == "" {
+		ctr.config.UTSNsCtr = infraID
+	}
+
+	return nil
+}
+
 // RemoveContainer removes the given container
 // If force is specified, the container will be stopped first
 // Otherwise, RemoveContainer will return an error if the container is running
@@ -197,6 +366,10 @@ func (r *Runtime) removeContainer(c *Container, force bool) error {
 		return err
 	}
 
+	if c.config.IsInfra && pod != nil {
+		return errors.Wrapf(ErrCtrStateInvalid, "container %s is the infra container of pod %s and cannot be removed without removing the pod", c.ID(), pod.ID())
+	}
+
 	if c.state.State == ContainerStatePaused {
 		return errors.Wrapf(ErrCtrStateInvalid, "container %s is paused, cannot remove until unpaused", c.ID())
 	}
@@ -218,7 +391,7 @@ func (r *Runtime) removeContainer(c *Container, force bool) error {
 	}
 
 	// Check that all of our exec sessions have finished
-	if len(c.state.ExecSessions) != 0 {
+	if c.hasRunningExecSessions() {
 		if force {
 			if err := r.ociRuntime.execStopContainer(c, c.StopTimeout()); err != nil {
 				return err
@@ -271,9 +444,60 @@ func (r *Runtime) removeContainer(c *Container, force bool) error {
 	// Set container as invalid so it can no longer be used
 	c.valid = false
 
+	c.newContainerEvent(events.Remove)
+
 	return nil
 }
 
+// RenameContainer changes the name of the given container
+// The new name must not be in use by another container or pod
+// The hosts files of the container, and of the containers sharing a network
+// or pod with it, are updated to use the new name, including while they are
+// running
+func (r *Runtime) RenameContainer(c *Container, newName string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	if !ctrNameRegex.MatchString(newName) {
+		return errors.Wrapf(ErrInvalidArg, "name must match regex %s", ctrNameRegex.String())
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if newName == c.Name() {
+		return errors.Wrapf(ErrInvalidArg, "container %s is already named %s", c.ID(), newName)
+	}
+
+	oldName := c.Name()
+	if err := r.state.RenameContainer(c, newName); err != nil {
+		return errors.Wrapf(err, "error renaming container %s to %s", c.ID(), newName)
+	}
+
+	// The container's storage must follow, or its old name could not be
+	// reused
+	if err := r.store.SetNames(c.ID(), []string{newName}); err != nil {
+		if err2 := r.state.RenameContainer(c, oldName); err2 != nil {
+			logrus.Errorf("Error restoring name %s of container %s: %v", oldName, c.ID(), err2)
+		}
+		return errors.Wrapf(err, "error renaming storage of container %s to %s", c.ID(), newName)
+	}
+
+	logrus.Debugf("Renamed container %s from %s to %s", c.ID(), oldName, newName)
+
+	// Containers that are not created yet, or no longer running, have
+	// their hosts file generated again when they are started
+	return r.updateHostsFiles(c)
+}
+
 // GetContainer retrieves a container by its ID
 func (r *Runtime) GetContainer(id string) (*Container, error) {
 	r.lock.RLock()
@@ -391,3 +615,24 @@ func (r *Runtime) GetLatestContainer() (*Container, error) {
 	}
 	return ctrs[lastCreatedIndex], nil
 }
+
+// GetExecSessionContainer returns the container that has the exec session with
+// the given ID
+func (r *Runtime) GetExecSessionContainer(id string) (*Container, error) {
+	ctrs, err := r.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		sessions, err := ctr.ExecSessions()
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if session == id {
+				return ctr, nil
+			}
+		}
+	}
+	return nil, errors.Wrapf(ErrNoSuchExecSession, "no exec session with ID %s found", id)
+}
@@ -0,0 +1,18 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameContainerInvalidName(t *testing.T) {
+	runtime := &Runtime{valid: true}
+	ctr := &Container{config: &ContainerConfig{ID: "ctr", Name: "ctr"}}
+	for _, name := range []string{"", "-ctr", "a/b", "a b", "x\n1.2.3.4 victim"} {
+		err := runtime.RenameContainer(ctr, name)
+		assert.Equal(t, ErrInvalidArg, errors.Cause(err), name)
+	}
+	assert.Equal(t, "ctr", ctr.Name())
+}
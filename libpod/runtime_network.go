@@ -0,0 +1,521 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	cnitypes "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containers/storage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// Contains the public Runtime API for CNI networks
+
+// A NetworkCreateOption is a functional option which alters the network
+// created by NewNetwork
+type NetworkCreateOption func(*networkCreateConfig) error
+
+// NewNetwork creates a new CNI network, writing its configuration to the CNI
+// configuration directory
+// Networks are bridge networks unless another driver is given
+func (r *Runtime) NewNetwork(ctx context.Context, options ...NetworkCreateOption) (*Network, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	config := &networkCreateConfig{
+		driver: BridgeNetworkDriver,
+	}
+	for _, option := range options {
+		if err := option(config); err != nil {
+			return nil, errors.Wrapf(err, "error running network create option")
+		}
+	}
+	if err := validateNetworkCreateConfig(config); err != nil {
+		return nil, err
+	}
+
+	networkLock, err := r.getNetworkLock()
+	if err != nil {
+		return nil, err
+	}
+	networkLock.Lock()
+	defer networkLock.Unlock()
+
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if network.Name() == config.name {
+			return nil, errors.Wrapf(ErrNetworkExists, "network with name %s already exists", config.name)
+		}
+	}
+	path := filepath.Join(r.config.CNIConfigDir, config.name+".conflist")
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Wrapf(ErrNetworkExists, "configuration file %s of network %s already exists", path, config.name)
+	}
+
+	ifaceNames, ifaceSubnets, err := hostInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bridge networks route their subnet to the bridge, so it must not
+	// overlap the subnets of other networks or of the host's interfaces.
+	// Macvlan networks usually share the subnet of their parent interface.
+	usedSubnets := []*net.IPNet{}
+	for _, network := range networks {
+		usedSubnets = append(usedSubnets, network.Subnets()...)
+	}
+	if config.driver == BridgeNetworkDriver {
+		usedSubnets = append(usedSubnets, ifaceSubnets...)
+	}
+	if config.subnet == nil {
+		if config.subnet, err = freeSubnet(usedSubnets); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, used := range usedSubnets {
+			if subnetsOverlap(config.subnet, used) {
+				return nil, errors.Wrapf(ErrInvalidArg, "subnet %s overlaps subnet %s, which is already in use", config.subnet.String(), used.String())
+			}
+		}
+	}
+
+	bridgeName := ""
+	if config.driver == BridgeNetworkDriver {
+		bridgeName = freeBridgeName(networks, ifaceNames)
+	} else if config.driver == MacvlanNetworkDriver {
+		if _, err := net.InterfaceByName(config.macvlanParent); err != nil {
+			return nil, errors.Wrapf(ErrInvalidArg, "macvlan parent interface %s not found", config.macvlanParent)
+		}
+	}
+
+	contents, err := generateNetworkConfig(config, bridgeName)
+	if err != nil {
+		return nil, err
+	}
+	confList, err := libcni.ConfListFromBytes(contents)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInternal, "error parsing generated configuration of network %s: %v", config.name, err)
+	}
+
+	if err := os.MkdirAll(r.config.CNIConfigDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error creating CNI configuration directory %s", r.config.CNIConfigDir)
+	}
+
+	// Write the configuration to a temporary file first, so OCICNI never
+	// reads a partial configuration
+	tmpFile, err := ioutil.TempFile(r.config.CNIConfigDir, "."+config.name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating configuration file of network %s", config.name)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(contents); err != nil {
+		tmpFile.Close()
+		return nil, errors.Wrapf(err, "error writing configuration file of network %s", config.name)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, errors.Wrapf(err, "error writing configuration file of network %s", config.name)
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return nil, errors.Wrapf(err, "error setting permissions of configuration file of network %s", config.name)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return nil, errors.Wrapf(err, "error writing configuration file of network %s", config.name)
+	}
+
+	logrus.Debugf("Created network %s in %s", config.name, path)
+
+	return &Network{config: confList, path: path}, nil
+}
+
+// RemoveNetwork removes a network's configuration file
+// If force is specified, the containers joining the network are removed
+// first; otherwise, networks used by containers cannot be removed
+// The default network cannot be removed
+func (r *Runtime) RemoveNetwork(ctx context.Context, n *Network, force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	if n.Name() == r.defaultNetworkName() {
+		return errors.Wrapf(ErrInvalidArg, "network %s is the default network and cannot be removed", n.Name())
+	}
+
+	networkLock, err := r.getNetworkLock()
+	if err != nil {
+		return err
+	}
+	networkLock.Lock()
+	defer networkLock.Unlock()
+
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return err
+	}
+	var deps []*Container
+	for _, ctr := range ctrs {
+		if !ctr.config.CreateNetNS {
+			continue
+		}
+		for _, network := range ctr.config.Networks {
+			if network == n.Name() {
+				deps = append(deps, ctr)
+				break
+			}
+		}
+	}
+	if len(deps) != 0 {
+		if !force {
+			var ids []string
+			for _, dep := range deps {
+				ids = append(ids, dep.ID())
+			}
+			return errors.Wrapf(ErrNetworkBeingUsed, "network %s is being used by the following container(s): %s", n.Name(), strings.Join(ids, ", "))
+		}
+
+		for _, dep := range deps {
+			if err := r.removeContainer(dep, true); err != nil {
+				return errors.Wrapf(err, "error removing container %s using network %s", dep.ID(), n.Name())
+			}
+		}
+	}
+
+	if err := os.Remove(n.path); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Wrapf(ErrNoSuchNetwork, "configuration file %s of network %s does not exist", n.path, n.Name())
+		}
+		return errors.Wrapf(err, "error removing configuration file of network %s", n.Name())
+	}
+
+	// Remove the network's bridge, unless another network uses it
+	bridgeName := n.bridgeName()
+	if bridgeName == "" {
+		return nil
+	}
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		if network.bridgeName() == bridgeName {
+			return nil
+		}
+	}
+	if link, err := netlink.LinkByName(bridgeName); err == nil {
+		if err := netlink.LinkDel(link); err != nil {
+			logrus.Warnf("Error removing bridge %s of network %s: %v", bridgeName, n.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// GetNetwork retrieves a network by its name
+func (r *Runtime) GetNetwork(name string) (*Network, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	networks, err := loadNetworks(r.config.CNIConfigDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if network.Name() == name {
+			return network, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNoSuchNetwork, "no network with name %s found", name)
+}
+
+// Networks retrieves all networks, in the order they are considered when
+// choosing the default network
+func (r *Runtime) Networks() ([]*Network, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return loadNetworks(r.config.CNIConfigDir)
+}
+
+// ConnectNetwork connects a container to a network
+// The network is added to the networks the container joins. If the
+// container's network namespace is active, the container is attached to the
+// network right away; otherwise it is attached when the container next starts.
+func (r *Runtime) ConnectNetwork(ctx context.Context, n *Network, ctr *Container) (err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	if !ctr.valid {
+		return ErrCtrRemoved
+	}
+
+	networkLock, err := r.getNetworkLock()
+	if err != nil {
+		return err
+	}
+	networkLock.Lock()
+	defer networkLock.Unlock()
+
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if !ctr.config.CreateNetNS {
+		return errors.Wrapf(ErrInvalidArg, "network namespace of container %s is not managed by libpod, cannot connect it to network %s", ctr.ID(), n.Name())
+	}
+
+	networks := r.containerNetworks(ctr)
+	for _, network := range networks {
+		if network == n.Name() {
+			return errors.Wrapf(ErrInvalidArg, "container %s is already connected to network %s", ctr.ID(), n.Name())
+		}
+	}
+	newNetworks := append(append([]string{}, networks...), n.Name())
+
+	if ctr.state.NetNS != nil {
+		nsPath := ctr.state.NetNS.Path()
+		ifName := freeInterfaceName(ctr.state.NetworkStatus)
+		var result *cnitypes.Result
+		result, err = r.attachNetwork(ctr, nsPath, n.Name(), ifName)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				for _, ip := range result.IPs {
+					iptablesDNS("-D", ip.Address.IP.String())
+				}
+				if err2 := r.detachNetwork(ctr, nsPath, n.Name(), ifName); err2 != nil {
+					logrus.Errorf("Error detaching container %s from network %s: %v", ctr.ID(), n.Name(), err2)
+				}
+				delete(ctr.state.NetworkStatus, n.Name())
+				ctr.state.IPs, ctr.state.Routes, ctr.state.Interfaces = mergeNetworkStatus(networks, ctr.state.NetworkStatus)
+				if err2 := ctr.save(); err2 != nil {
+					logrus.Errorf("Error saving state of container %s: %v", ctr.ID(), err2)
+				}
+			}
+		}()
+
+		if ctr.state.NetworkStatus == nil {
+			ctr.state.NetworkStatus = make(map[string]*cnitypes.Result)
+		}
+		ctr.state.NetworkStatus[n.Name()] = result
+		ctr.state.IPs, ctr.state.Routes, ctr.state.Interfaces = mergeNetworkStatus(newNetworks, ctr.state.NetworkStatus)
+		if err := ctr.save(); err != nil {
+			return err
+		}
+	}
+
+	newConfig := new(ContainerConfig)
+	*newConfig = *ctr.config
+	newConfig.Networks = newNetworks
+	if err := r.state.RewriteContainerConfig(ctr, newConfig); err != nil {
+		return errors.Wrapf(err, "error saving networks of container %s", ctr.ID())
+	}
+
+	logrus.Debugf("Connected container %s to network %s", ctr.ID(), n.Name())
+
+	if err := r.updateHostsFiles(ctr); err != nil {
+		logrus.Errorf("Error updating hosts files of peers of container %s: %v", ctr.ID(), err)
+	}
+
+	return nil
+}
+
+// DisconnectNetwork disconnects a container from a network
+// If the container's network namespace is active, the container is detached
+// from the network right away. A container cannot be disconnected from its
+// last network, nor from the first network if its static IP, static MAC
+// address or port mappings are configured there.
+func (r *Runtime) DisconnectNetwork(ctx context.Context, n *Network, ctr *Container) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	if !ctr.valid {
+		return ErrCtrRemoved
+	}
+
+	networkLock, err := r.getNetworkLock()
+	if err != nil {
+		return err
+	}
+	networkLock.Lock()
+	defer networkLock.Unlock()
+
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if !ctr.config.CreateNetNS {
+		return errors.Wrapf(ErrInvalidArg, "network namespace of container %s is not managed by libpod, cannot disconnect it from network %s", ctr.ID(), n.Name())
+	}
+
+	networks := r.containerNetworks(ctr)
+	var newNetworks []string
+	index := -1
+	for i, network := range networks {
+		if network == n.Name() {
+			index = i
+			continue
+		}
+		newNetworks = append(newNetworks, network)
+	}
+	if index == -1 {
+		return errors.Wrapf(ErrInvalidArg, "container %s is not connected to network %s", ctr.ID(), n.Name())
+	}
+	if len(newNetworks) == 0 {
+		return errors.Wrapf(ErrInvalidArg, "network %s is the only network of container %s, cannot disconnect it", n.Name(), ctr.ID())
+	}
+	if index == 0 && (ctr.config.StaticIP != nil || ctr.config.StaticMAC != nil || len(ctr.config.PortMappings) > 0) {
+		return errors.Wrapf(ErrInvalidArg, "the static IP, MAC address or port mappings of container %s are configured in network %s, cannot disconnect it", ctr.ID(), n.Name())
+	}
+
+	if ctr.state.NetNS != nil {
+		ifName := fmt.Sprintf("eth%d", index)
+		if result, ok := ctr.state.NetworkStatus[n.Name()]; ok {
+			ifName = interfaceName(result)
+			for _, ip := range result.IPs {
+				iptablesDNS("-D", ip.Address.IP.String())
+			}
+		}
+		if err := r.detachNetwork(ctr, ctr.state.NetNS.Path(), n.Name(), ifName); err != nil {
+			return err
+		}
+
+		delete(ctr.state.NetworkStatus, n.Name())
+		ctr.state.IPs, ctr.state.Routes, ctr.state.Interfaces = mergeNetworkStatus(newNetworks, ctr.state.NetworkStatus)
+		if err := ctr.save(); err != nil {
+			return err
+		}
+	}
+
+	newConfig := new(ContainerConfig)
+	*newConfig = *ctr.config
+	newConfig.Networks = newNetworks
+	if err := r.state.RewriteContainerConfig(ctr, newConfig); err != nil {
+		return errors.Wrapf(err, "error saving networks of container %s", ctr.ID())
+	}
+
+	logrus.Debugf("Disconnected container %s from network %s", ctr.ID(), n.Name())
+
+	if err := r.updateHostsFiles(ctr, n.Name()); err != nil {
+		logrus.Errorf("Error updating hosts files of peers of container %s: %v", ctr.ID(), err)
+	}
+
+	return nil
+}
+
+// Get the lock serializing changes to networks, and checks of host ports and
+// static IPs against those of other containers
+func (r *Runtime) getNetworkLock() (storage.Locker, error) {
+	lock, err := storage.GetLockfile(filepath.Join(r.lockDir, "network"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving network lock")
+	}
+	return lock, nil
+}
+
+// Check the settings of a new network are consistent
+func validateNetworkCreateConfig(config *networkCreateConfig) error {
+	if config.name == "" {
+		return errors.Wrapf(ErrInvalidArg, "network name must be specified")
+	}
+
+	switch config.driver {
+	case BridgeNetworkDriver:
+		if config.macvlanParent != "" {
+			return errors.Wrapf(ErrInvalidArg, "a parent interface can only be set for macvlan networks")
+		}
+	case MacvlanNetworkDriver:
+		if config.macvlanParent == "" {
+			return errors.Wrapf(ErrInvalidArg, "macvlan networks require a parent interface")
+		}
+		if config.subnet == nil {
+			return errors.Wrapf(ErrInvalidArg, "macvlan networks require a subnet")
+		}
+		if config.internal {
+			return errors.Wrapf(ErrInvalidArg, "only bridge networks can be internal")
+		}
+	}
+
+	if config.subnet == nil {
+		if config.gateway != nil || config.ipRange != nil {
+			return errors.Wrapf(ErrInvalidArg, "a subnet must be given with the gateway and IP range of a network")
+		}
+		return nil
+	}
+	if config.gateway != nil && !config.subnet.Contains(config.gateway) {
+		return errors.Wrapf(ErrInvalidArg, "gateway %s is not in subnet %s", config.gateway, config.subnet)
+	}
+	if config.ipRange != nil {
+		subnetOnes, _ := config.subnet.Mask.Size()
+		rangeOnes, _ := config.ipRange.Mask.Size()
+		if !config.subnet.Contains(config.ipRange.IP) || rangeOnes < subnetOnes {
+			return errors.Wrapf(ErrInvalidArg, "IP range %s is not in subnet %s", config.ipRange, config.subnet)
+		}
+	}
+	return nil
+}
+
+// Get the names of the host's network interfaces, and the subnets of their
+// addresses
+func hostInterfaces() ([]string, []*net.IPNet, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error retrieving network interfaces of the host")
+	}
+
+	var names []string
+	var subnets []*net.IPNet
+	for _, iface := range ifaces {
+		names = append(names, iface.Name)
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error retrieving addresses of interface %s", iface.Name)
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil && !ipNet.IP.IsLoopback() {
+				subnets = append(subnets, &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask})
+			}
+		}
+	}
+	return names, subnets, nil
+}
@@ -1,12 +1,14 @@
 package libpod
 
 import (
+	"context"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/containerd/cgroups"
 	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,7 +24,9 @@ type PodCreateOption func(*Pod) error
 type PodFilter func(*Pod) bool
 
 // NewPod makes a new, empty pod
-func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
+// If the pod is set to have an infra container, it is created along with the
+// pod
+func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Pod, err error) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
@@ -49,6 +53,18 @@ func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
 		pod.config.Name = name
 	}
 
+	if pod.SharesNamespaces() && !pod.HasInfraContainer() {
+		return nil, errors.Wrapf(ErrInvalidArg, "pod %s cannot share namespaces without an infra container", pod.ID())
+	}
+
+	if len(pod.config.InfraContainer.PortBindings) > 0 && !pod.config.UsePodNet {
+		return nil, errors.Wrapf(ErrInvalidArg, "pod %s cannot publish ports without sharing its network namespace", pod.ID())
+	}
+
+	if pod.config.CgroupResources != nil && !pod.config.UsePodCgroup {
+		return nil, errors.Wrapf(ErrInvalidArg, "pod %s cannot set resource limits without a pod cgroup", pod.ID())
+	}
+
 	pod.valid = true
 
 	// Check CGroup parent sanity, and set it if it was not set
@@ -59,20 +75,24 @@ func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
 		} else if strings.HasSuffix(path.Base(pod.config.CgroupParent), ".slice") {
 			return nil, errors.Wrapf(ErrInvalidArg, "systemd slice received as cgroup parent when using cgroupfs")
 		}
-		// Creating CGroup path is currently a NOOP until proper systemd
-		// cgroup management is merged
+		// If we are set to use pod cgroups, set the cgroup parent that
+		// all containers in the pod will share
+		// Unless the pod has resource limits, there is no need to
+		// create it with cgroupfs - the first container to launch
+		// should do it for us
+		if pod.config.UsePodCgroup {
+			pod.state.CgroupPath = filepath.Join(pod.config.CgroupParent, pod.ID())
+		}
 	case SystemdCgroupsManager:
 		if pod.config.CgroupParent == "" {
 			pod.config.CgroupParent = SystemdDefaultCgroupParent
 		} else if len(pod.config.CgroupParent) < 6 || !strings.HasSuffix(path.Base(pod.config.CgroupParent), ".slice") {
 			return nil, errors.Wrapf(ErrInvalidArg, "did not receive systemd slice as cgroup parent when using systemd to manage cgroups")
 		}
-		// If we are set to use pod cgroups, set the cgroup parent that
-		// all containers in the pod will share
-		// No need to create it with cgroupfs - the first container to
-		// launch should do it for us
+		// If we are set to use pod cgroups, the pod gets a slice nested
+		// in the parent slice that all containers in the pod will share
 		if pod.config.UsePodCgroup {
-			pod.state.CgroupPath = filepath.Join(pod.config.CgroupParent, pod.ID())
+			pod.state.CgroupPath = systemdPodSlice(pod.config.CgroupParent, pod.ID())
 		}
 	default:
 		return nil, errors.Wrapf(ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.CgroupManager)
@@ -82,7 +102,50 @@ func (r *Runtime) NewPod(options ...PodCreateOption) (*Pod, error) {
 		return nil, errors.Wrapf(err, "error adding pod to state")
 	}
 
-	return nil, ErrNotImplemented
+	// Remove the pod, and its infra container and cgroup if they were
+	// created, if anything below fails
+	var infraCtr *Container
+	defer func() {
+		if err == nil {
+			return
+		}
+		if infraCtr != nil {
+			if err2 := infraCtr.teardownStorage(); err2 != nil {
+				logrus.Errorf("Error removing storage of infra container %s of pod %s: %v", infraCtr.ID(), pod.ID(), err2)
+			}
+			if err2 := r.state.RemoveContainerFromPod(pod, infraCtr); err2 != nil {
+				logrus.Errorf("Error removing infra container %s of pod %s: %v", infraCtr.ID(), pod.ID(), err2)
+			}
+			infraCtr.valid = false
+		}
+		if err2 := pod.removeCgroup(); err2 != nil {
+			logrus.Errorf("Error removing cgroup of pod %s: %v", pod.ID(), err2)
+		}
+		if err2 := r.state.RemovePod(pod); err2 != nil {
+			logrus.Errorf("Error removing pod %s: %v", pod.ID(), err2)
+		}
+		pod.valid = false
+	}()
+
+	if err := pod.createCgroup(); err != nil {
+		return nil, err
+	}
+
+	if pod.HasInfraContainer() {
+		infraCtr, err = r.createInfraContainer(ctx, pod)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error adding infra container to pod %s", pod.ID())
+		}
+
+		pod.state.InfraContainerID = infraCtr.ID()
+		if err := pod.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	pod.newPodEvent(events.Create)
+
+	return pod, nil
 }
 
 // RemovePod removes a pod
@@ -111,7 +174,14 @@ func (r *Runtime) RemovePod(p *Pod, removeCtrs, force bool) error {
 		return err
 	}
 
-	numCtrs := len(ctrs)
+	// The infra container is always removed along with the pod, so it
+	// does not count towards the pod's containers here
+	numCtrs := 0
+	for _, ctr := range ctrs {
+		if !ctr.config.IsInfra {
+			numCtrs++
+		}
+	}
 
 	if !removeCtrs && numCtrs > 0 {
 		return errors.Wrapf(ErrCtrExists, "pod %s contains containers and cannot be removed", p.ID())
@@ -143,7 +213,7 @@ func (r *Runtime) RemovePod(p *Pod, removeCtrs, force bool) error {
 		}
 
 		// If the container has active exec sessions and force is not set we can't do anything
-		if len(ctr.state.ExecSessions) != 0 && !force {
+		if ctr.hasRunningExecSessions() && !force {
 			return errors.Wrapf(ErrCtrStateInvalid, "pod %s contains container %s which has active exec sessions", p.ID(), ctr.ID())
 		}
 
@@ -181,7 +251,7 @@ func (r *Runtime) RemovePod(p *Pod, removeCtrs, force bool) error {
 				}
 			}
 			// If the container has active exec sessions, stop them now
-			if len(ctr.state.ExecSessions) != 0 {
+			if ctr.hasRunningExecSessions() {
 				if err := r.ociRuntime.execStopContainer(ctr, ctr.StopTimeout()); err != nil {
 					return err
 				}
@@ -221,29 +291,12 @@ func (r *Runtime) RemovePod(p *Pod, removeCtrs, force bool) error {
 	// Mark containers invalid
 	for _, ctr := range ctrs {
 		ctr.valid = false
+		ctr.newContainerEvent(events.Remove)
 	}
 
 	// Remove pod cgroup, if present
-	if p.state.CgroupPath != "" {
-		switch p.runtime.config.CgroupManager {
-		case SystemdCgroupsManager:
-			// NOOP for now, until proper systemd cgroup management
-			// is implemented
-		case CgroupfsCgroupsManager:
-			// Delete the cgroupfs cgroup
-			logrus.Debugf("Removing pod cgroup %s", p.state.CgroupPath)
-
-			cgroup, err := cgroups.Load(cgroups.V1, cgroups.StaticPath(p.state.CgroupPath))
-			if err != nil && err != cgroups.ErrCgroupDeleted {
-				return err
-			} else if err == nil {
-				if err := cgroup.Delete(); err != nil {
-					return err
-				}
-			}
-		default:
-			return errors.Wrapf(ErrInvalidArg, "unknown cgroups manager %s specified", p.runtime.config.CgroupManager)
-		}
+	if err := p.removeCgroup(); err != nil {
+		return err
 	}
 
 	// Remove pod from state
@@ -254,6 +307,8 @@ func (r *Runtime) RemovePod(p *Pod, removeCtrs, force bool) error {
 	// Mark pod invalid
 	p.valid = false
 
+	p.newPodEvent(events.Remove)
+
 	return nil
 }
 
@@ -325,3 +380,24 @@ func (r *Runtime) Pods(filters ...PodFilter) ([]*Pod, error) {
 
 	return podsFiltered, nil
 }
+
+// GetLatestPod returns a pod object of the latest created pod.
+func (r *Runtime) GetLatestPod() (*Pod, error) {
+	lastCreatedIndex := 0
+	var lastCreatedTime time.Time
+	pods, err := r.Pods()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get all pods")
+	}
+	if len(pods) == 0 {
+		return nil, ErrNoSuchPod
+	}
+	for podIndex, pod := range pods {
+		createdTime := pod.config.CreatedTime
+		if createdTime.After(lastCreatedTime) {
+			lastCreatedTime = createdTime
+			lastCreatedIndex = podIndex
+		}
+	}
+	return pods[lastCreatedIndex], nil
+}
@@ -0,0 +1,71 @@
+package libpod
+
+import (
+	"context"
+
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/image"
+)
+
+const (
+	// IDTruncLength is the length of the pod's id that will be used to make the
+	// infra container name
+	IDTruncLength = 12
+)
+
+// Make the infra container of a pod from the given image
+func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, imgID string) (*Container, error) {
+	command := p.config.InfraContainer.Command
+	if len(command) == 0 {
+		command = []string{r.config.InfraCommand}
+	}
+
+	// Set up generator for infra container defaults
+	g := generate.New()
+	g.SetRootReadonly(true)
+	g.SetProcessArgs(command)
+	// Containers joining the pod's UTS namespace see the pod's name as
+	// their hostname
+	if p.config.UsePodUTS {
+		g.SetHostname(p.Name())
+	}
+
+	options := []CtrCreateOption{
+		r.WithPod(p),
+		WithRootFSFromImage(imgID, imgName, false),
+		WithName(p.ID()[:IDTruncLength] + "-infra"),
+		withIsInfra(),
+	}
+	if p.config.UsePodNet {
+		options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, false, nil))
+	}
+
+	return r.newContainer(ctx, g.Spec(), options...)
+}
+
+// Create the infra container of a pod, pulling the infra image if it is not
+// present locally
+// Does not lock the runtime
+func (r *Runtime) createInfraContainer(ctx context.Context, p *Pod) (*Container, error) {
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	imageName := p.config.InfraContainer.Image
+	if imageName == "" {
+		imageName = r.config.InfraImage
+	}
+
+	newImage, err := r.ImageRuntime().New(ctx, imageName, r.config.SignaturePolicyPath, "", nil, nil, image.SigningOptions{}, false, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving infra image %s", imageName)
+	}
+
+	names := newImage.Names()
+	if len(names) > 0 {
+		imageName = names[0]
+	}
+
+	return r.makeInfraContainer(ctx, p, imageName, newImage.ID())
+}
@@ -0,0 +1,255 @@
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/storage"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/projectatomic/libpod/libpod/events"
+	"github.com/sirupsen/logrus"
+)
+
+// Contains the public Runtime API for volumes
+
+// A VolumeCreateOption is a functional option which alters the Volume created
+// by NewVolume
+type VolumeCreateOption func(*Volume) error
+
+// VolumeFilter is a function to determine whether a volume is included in
+// command output. Volumes to be outputted are tested using the function. A
+// true return will include the volume, a false return will exclude it.
+type VolumeFilter func(*Volume) bool
+
+// NewVolume creates a new, empty volume
+// Volumes not given a name are named with a random ID
+func (r *Runtime) NewVolume(ctx context.Context, options ...VolumeCreateOption) (*Volume, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return r.newVolume(ctx, options...)
+}
+
+// Internal function to create a new volume
+// Does not lock the runtime
+func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption) (*Volume, error) {
+	volume := newVolume(r)
+	for _, option := range options {
+		if err := option(volume); err != nil {
+			return nil, errors.Wrapf(err, "error running volume create option")
+		}
+	}
+
+	if volume.config.Name == "" {
+		volume.config.Name = stringid.GenerateNonCryptoID()
+	}
+
+	if exists, err := r.state.HasVolume(volume.config.Name); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, errors.Wrapf(ErrVolumeExists, "volume with name %s already exists", volume.config.Name)
+	}
+
+	// Create the directory holding the volume
+	// It must not exist already, as it would be another volume's
+	volumeDir := filepath.Join(r.config.VolumePath, volume.config.Name)
+	if err := os.Mkdir(volumeDir, 0700); err != nil {
+		if os.IsExist(err) {
+			return nil, errors.Wrapf(ErrVolumeExists, "directory %s of volume %s already exists", volumeDir, volume.config.Name)
+		}
+		return nil, errors.Wrapf(err, "error creating directory of volume %s", volume.config.Name)
+	}
+	volume.config.MountPoint = filepath.Join(volumeDir, "_data")
+	if err := os.Mkdir(volume.config.MountPoint, 0755); err != nil {
+		if err2 := os.RemoveAll(volumeDir); err2 != nil {
+			logrus.Errorf("Error removing directory of partially-created volume %s: %v", volume.config.Name, err2)
+		}
+		return nil, errors.Wrapf(err, "error creating mount point of volume %s", volume.config.Name)
+	}
+
+	lock, err := storage.GetLockfile(volumeLockPath(r.lockDir, volume.config.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating lockfile for new volume")
+	}
+	volume.lock = lock
+
+	volume.valid = true
+
+	if err := r.state.AddVolume(volume); err != nil {
+		if err2 := os.RemoveAll(volumeDir); err2 != nil {
+			logrus.Errorf("Error removing directory of partially-created volume %s: %v", volume.config.Name, err2)
+		}
+		return nil, errors.Wrapf(err, "error adding volume to state")
+	}
+
+	volume.newVolumeEvent(events.Create)
+
+	return volume, nil
+}
+
+// RemoveVolume removes a volume and its contents
+// If force is specified, the containers using the volume are removed first;
+// otherwise, volumes used by containers cannot be removed
+func (r *Runtime) RemoveVolume(ctx context.Context, v *Volume, force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return ErrRuntimeStopped
+	}
+
+	return r.removeVolume(ctx, v, force)
+}
+
+// Internal function to remove a volume
+// Does not lock the runtime
+func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error {
+	if !v.valid {
+		return ErrVolumeRemoved
+	}
+
+	deps, err := r.state.VolumeInUse(v)
+	if err != nil {
+		return err
+	}
+	if len(deps) != 0 {
+		if !force {
+			return errors.Wrapf(ErrVolumeBeingUsed, "volume %s is being used by the following container(s): %s", v.Name(), strings.Join(deps, ", "))
+		}
+
+		for _, dep := range deps {
+			ctr, err := r.state.Container(dep)
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving container %s using volume %s", dep, v.Name())
+			}
+			if err := r.removeContainer(ctr, true); err != nil {
+				return errors.Wrapf(err, "error removing container %s using volume %s", dep, v.Name())
+			}
+		}
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := r.state.RemoveVolume(v); err != nil {
+		return errors.Wrapf(err, "error removing volume %s", v.Name())
+	}
+
+	v.valid = false
+
+	if err := os.RemoveAll(filepath.Dir(v.config.MountPoint)); err != nil {
+		return errors.Wrapf(err, "error removing contents of volume %s", v.Name())
+	}
+
+	v.newVolumeEvent(events.Remove)
+
+	return nil
+}
+
+// GetVolume retrieves a volume by its name
+func (r *Runtime) GetVolume(name string) (*Volume, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return r.state.Volume(name)
+}
+
+// HasVolume checks to see if a volume with the given name exists
+func (r *Runtime) HasVolume(name string) (bool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return false, ErrRuntimeStopped
+	}
+
+	return r.state.HasVolume(name)
+}
+
+// Volumes retrieves all volumes
+// Filters can be provided which will determine which volumes are included
+// in the output. Multiple filters are handled by ANDing their output, so only
+// volumes matching all filters are returned
+func (r *Runtime) Volumes(filters ...VolumeFilter) ([]*Volume, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	volsFiltered := make([]*Volume, 0, len(vols))
+	for _, vol := range vols {
+		include := true
+		for _, filter := range filters {
+			include = include && filter(vol)
+		}
+
+		if include {
+			volsFiltered = append(volsFiltered, vol)
+		}
+	}
+
+	return volsFiltered, nil
+}
+
+// GetAllVolumes retrieves all the volumes
+func (r *Runtime) GetAllVolumes() ([]*Volume, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	return r.state.AllVolumes()
+}
+
+// PruneVolumes removes all volumes not used by any container
+// The result maps the name of each volume that was pruned to nil, or to the
+// error removing it
+func (r *Runtime) PruneVolumes(ctx context.Context) (map[string]error, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, ErrRuntimeStopped
+	}
+
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := make(map[string]error)
+	for _, vol := range vols {
+		deps, err := r.state.VolumeInUse(vol)
+		if err != nil {
+			pruned[vol.Name()] = err
+			continue
+		}
+		if len(deps) != 0 {
+			continue
+		}
+
+		pruned[vol.Name()] = r.removeVolume(ctx, vol, false)
+	}
+
+	return pruned, nil
+}
@@ -28,6 +28,17 @@ type State interface {
 	UpdateContainer(ctr *Container) error
 	// SaveContainer saves a container's current state to the backing store
 	SaveContainer(ctr *Container) error
+	// RenameContainer changes the name of a container
+	// The new name must be globally unique - pod names also conflict with
+	// container names
+	// On success, the given container's configuration will reflect the new
+	// name
+	RenameContainer(ctr *Container, newName string) error
+	// RewriteContainerConfig replaces the configuration of a container
+	// It must not be used to change anything the state indexes containers
+	// by, such as their ID, name, pod, or dependencies
+	// On success, the given container will use the new configuration
+	RewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) error
 	// ContainerInUse checks if other containers depend upon a given
 	// container
 	// It returns a slice of the IDs of containers which depend on the given
@@ -72,4 +83,26 @@ type State interface {
 	SavePod(pod *Pod) error
 	// Retrieves all pods presently in state
 	AllPods() ([]*Pod, error)
+
+	// Return a volume from the database by its full name
+	Volume(name string) (*Volume, error)
+	// Check if a volume with the given name exists in the database
+	HasVolume(name string) (bool, error)
+	// Adds volume to state
+	// Volume names must be unique among volumes, but do not conflict with
+	// container and pod names and IDs
+	AddVolume(volume *Volume) error
+	// Removes volume from state
+	// Volumes used by containers cannot be removed
+	RemoveVolume(volume *Volume) error
+	// UpdateVolume updates a volume's state from the database
+	UpdateVolume(volume *Volume) error
+	// SaveVolume saves a volume's state to the database
+	SaveVolume(volume *Volume) error
+	// VolumeInUse checks if containers use a given volume
+	// It returns a slice of the IDs of the containers using the volume. If
+	// the slice is empty, no containers use the volume.
+	VolumeInUse(volume *Volume) ([]string, error)
+	// Retrieves all volumes presently in state
+	AllVolumes() ([]*Volume, error)
 }
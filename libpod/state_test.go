@@ -502,6 +502,166 @@ func TestSaveContainerNotInStateReturnsError(t *testing.T) {
 	})
 }
 
+func TestRenameContainer(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		oldName := testCtr.Name()
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr, "renamed")
+		assert.NoError(t, err)
+		assert.Equal(t, "renamed", testCtr.Name())
+
+		_, err = state.LookupContainer(oldName)
+		assert.Error(t, err)
+
+		retrievedCtr, err := state.LookupContainer("renamed")
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr.ID(), retrievedCtr.ID())
+		assert.Equal(t, "renamed", retrievedCtr.Name())
+
+		// The old name can be reused
+		testCtr2, err := getTestContainer(strings.Repeat("2", 32), oldName, lockPath)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr2)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRenameContainerCtrNameConflictFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr1, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr1)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr2)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr1, testCtr2.Name())
+		assert.Error(t, err)
+		assert.Equal(t, "test1", testCtr1.Name())
+
+		retrievedCtr, err := state.LookupContainer("test1")
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr1.ID(), retrievedCtr.ID())
+	})
+}
+
+func TestRenameContainerPodNameConflictFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testPod, err := getTestPod2(lockPath)
+		assert.NoError(t, err)
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr, testPod.Name())
+		assert.Error(t, err)
+		assert.Equal(t, "test1", testCtr.Name())
+	})
+}
+
+func TestRenameContainerSameNameFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr, testCtr.Name())
+		assert.Error(t, err)
+
+		_, err = state.LookupContainer(testCtr.Name())
+		assert.NoError(t, err)
+	})
+}
+
+func TestRenameContainerNotInStateFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr, "renamed")
+		assert.Error(t, err)
+		assert.False(t, testCtr.valid)
+	})
+}
+
+func TestRenameContainerInPod(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testPod, err := getTestPod1(lockPath)
+		assert.NoError(t, err)
+
+		testCtr, err := getTestCtr2(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.Pod = testPod.ID()
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+
+		err = state.AddContainerToPod(testPod, testCtr)
+		assert.NoError(t, err)
+
+		err = state.RenameContainer(testCtr, "renamed")
+		assert.NoError(t, err)
+
+		podCtrs, err := state.PodContainers(testPod)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(podCtrs))
+		assert.Equal(t, "renamed", podCtrs[0].Name())
+
+		err = state.RemoveContainerFromPod(testPod, testCtr)
+		assert.NoError(t, err)
+
+		_, err = state.LookupContainer("renamed")
+		assert.Error(t, err)
+	})
+}
+
+func TestRewriteContainerConfig(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		newConfig := new(ContainerConfig)
+		*newConfig = *testCtr.config
+		newConfig.StopTimeout = 42
+
+		err = state.RewriteContainerConfig(testCtr, newConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), testCtr.config.StopTimeout)
+
+		retrievedCtr, err := state.Container(testCtr.ID())
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), retrievedCtr.config.StopTimeout)
+	})
+}
+
+func TestRewriteContainerConfigNotInStateFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+
+		err = state.RewriteContainerConfig(testCtr, testCtr.config)
+		assert.Error(t, err)
+		assert.False(t, testCtr.valid)
+	})
+}
+
 func TestRemoveContainer(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
 		testCtr, err := getTestCtr1(lockPath)
@@ -2455,3 +2615,234 @@ func TestSaveAndUpdatePod(t *testing.T) {
 		testPodsEqual(t, testPod, statePod)
 	})
 }
+
+func TestGetVolumeEmptyName(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		_, err := state.Volume("")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetVolumeNonexistent(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		_, err := state.Volume("test")
+		assert.Error(t, err)
+	})
+}
+
+func TestAddAndGetVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		exists, err := state.HasVolume("test")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		retrievedVol, err := state.Volume("test")
+		assert.NoError(t, err)
+
+		testVolumesEqual(t, testVol, retrievedVol)
+	})
+}
+
+func TestAddVolumeDuplicateNameFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol1, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+		testVol2, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol1)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol2)
+		assert.Error(t, err)
+
+		allVols, err := state.AllVolumes()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(allVols))
+	})
+}
+
+func TestAddVolumeSameNameAsContainerSucceeds(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testVol, err := getTestVolume(testCtr.Name(), lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		_, err = state.Volume(testCtr.Name())
+		assert.NoError(t, err)
+	})
+}
+
+func TestRemoveVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		err = state.RemoveVolume(testVol)
+		assert.NoError(t, err)
+
+		exists, err := state.HasVolume("test")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		allVols, err := state.AllVolumes()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(allVols))
+	})
+}
+
+func TestAddContainerWithNonexistentVolumeFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		err = state.AddContainer(testCtr)
+		assert.Error(t, err)
+
+		ctrs, err := state.AllContainers()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(ctrs))
+	})
+}
+
+func TestRemoveVolumeInUseFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+		testCtr, err := getTestCtr1(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		users, err := state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testCtr.ID()}, users)
+
+		err = state.RemoveVolume(testVol)
+		assert.Error(t, err)
+
+		exists, err := state.HasVolume("test")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		err = state.RemoveContainer(testCtr)
+		assert.NoError(t, err)
+
+		users, err = state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(users))
+
+		err = state.RemoveVolume(testVol)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRemovePodContainersReleasesVolumes(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+		testPod, err := getTestPod1(lockPath)
+		assert.NoError(t, err)
+		testCtr, err := getTestCtr2(lockPath)
+		assert.NoError(t, err)
+		testCtr.config.Pod = testPod.ID()
+		testCtr.config.NamedVolumes = []*ContainerNamedVolume{{Name: "test", Dest: "/data"}}
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+
+		err = state.AddContainerToPod(testPod, testCtr)
+		assert.NoError(t, err)
+
+		users, err := state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+
+		err = state.RemovePodContainers(testPod)
+		assert.NoError(t, err)
+
+		users, err = state.VolumeInUse(testVol)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(users))
+	})
+}
+
+func TestSaveAndUpdateVolume(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol)
+		assert.NoError(t, err)
+
+		stateVol, err := state.Volume("test")
+		assert.NoError(t, err)
+
+		testVolumesEqual(t, testVol, stateVol)
+
+		testVol.state.MountCount = 2
+		testVol.state.NeedsCopyUp = false
+
+		err = state.SaveVolume(testVol)
+		assert.NoError(t, err)
+
+		err = state.UpdateVolume(stateVol)
+		assert.NoError(t, err)
+
+		testVolumesEqual(t, testVol, stateVol)
+	})
+}
+
+func TestUpdateVolumeNotInStateFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol, err := getTestVolume("test", lockPath)
+		assert.NoError(t, err)
+
+		err = state.UpdateVolume(testVol)
+		assert.Error(t, err)
+		assert.False(t, testVol.valid)
+	})
+}
+
+func TestGetAllVolumes(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, lockPath string) {
+		testVol1, err := getTestVolume("test1", lockPath)
+		assert.NoError(t, err)
+		testVol2, err := getTestVolume("test2", lockPath)
+		assert.NoError(t, err)
+
+		err = state.AddVolume(testVol1)
+		assert.NoError(t, err)
+		err = state.AddVolume(testVol2)
+		assert.NoError(t, err)
+
+		allVols, err := state.AllVolumes()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(allVols))
+	})
+}
@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"math"
 	"strings"
 	"syscall"
 	"time"
@@ -29,6 +30,24 @@ type ContainerStats struct {
 	PIDs        uint64
 }
 
+// PodStats contains the statistics of the containers in a pod, along with
+// their combined totals
+type PodStats struct {
+	PodID       string
+	Name        string
+	CPU         float64
+	MemUsage    uint64
+	MemLimit    uint64
+	MemPerc     float64
+	NetInput    uint64
+	NetOutput   uint64
+	BlockInput  uint64
+	BlockOutput uint64
+	PIDs        uint64
+	// Containers contains the statistics of each container in the pod
+	Containers []*ContainerStats
+}
+
 // GetContainerStats gets the running stats for a given container
 func (c *Container) GetContainerStats(previousStats *ContainerStats) (*ContainerStats, error) {
 	stats := new(ContainerStats)
@@ -86,6 +105,71 @@ func (c *Container) GetContainerStats(previousStats *ContainerStats) (*Container
 	return stats, nil
 }
 
+// GetPodStats gets the running stats of all containers in the pod, along with
+// their totals
+// Statistics are gathered from each container in the pod as with
+// GetContainerStats. Containers that are not running report no usage.
+// previousStats are the statistics previously returned for the pod, used to
+// calculate CPU usage. It may be nil or empty if there are none.
+func (p *Pod) GetPodStats(previousStats *PodStats) (*PodStats, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	previousCtrStats := make(map[string]*ContainerStats)
+	if previousStats != nil {
+		for _, ctrStats := range previousStats.Containers {
+			previousCtrStats[ctrStats.ContainerID] = ctrStats
+		}
+	}
+
+	stats := new(PodStats)
+	stats.PodID = p.ID()
+	stats.Name = p.Name()
+
+	for _, ctr := range allCtrs {
+		previous, ok := previousCtrStats[ctr.ID()]
+		if !ok {
+			previous = new(ContainerStats)
+		}
+
+		ctrStats, err := ctr.GetContainerStats(previous)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to obtain stats for container %s in pod %s", ctr.ID(), p.ID())
+		}
+
+		stats.Containers = append(stats.Containers, ctrStats)
+		stats.CPU += ctrStats.CPU
+		stats.MemUsage += ctrStats.MemUsage
+		stats.NetInput += ctrStats.NetInput
+		stats.NetOutput += ctrStats.NetOutput
+		stats.BlockInput += ctrStats.BlockInput
+		stats.BlockOutput += ctrStats.BlockOutput
+		stats.PIDs += ctrStats.PIDs
+	}
+
+	// The memory of the pod is limited by its cgroup if a limit was set,
+	// and by the memory of the system otherwise
+	memLimit := uint64(math.MaxUint64)
+	if resources := p.config.CgroupResources; resources != nil && resources.Memory != nil && resources.Memory.Limit != nil {
+		memLimit = uint64(*resources.Memory.Limit)
+	}
+	stats.MemLimit = getMemLimit(memLimit)
+	if stats.MemLimit > 0 {
+		stats.MemPerc = (float64(stats.MemUsage) / float64(stats.MemLimit)) * 100
+	}
+
+	return stats, nil
+}
+
 // getMemory limit returns the memory limit for a given cgroup
 // If the configured memory limit is larger than the total memory on the sys, the
 // physical system memory size is returned
@@ -103,8 +187,16 @@ func getMemLimit(cgroupLimit uint64) uint64 {
 	return cgroupLimit
 }
 
+// getContainerNetIO returns the network statistics of the container's network
+// namespace
+// Containers that did not create a network namespace of their own, such as
+// those joining the namespace of another container, report no network I/O;
+// it is accounted to the container owning the namespace
 func getContainerNetIO(ctr *Container) (*netlink.LinkStatistics, error) {
 	var netStats *netlink.LinkStatistics
+	if ctr.state.NetNS == nil {
+		return new(netlink.LinkStatistics), nil
+	}
 	err := ns.WithNetNSPath(ctr.state.NetNS.Path(), func(_ ns.NetNS) error {
 		link, err := netlink.LinkByName(ocicni.DefaultInterfaceName)
 		if err != nil {
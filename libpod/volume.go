@@ -0,0 +1,348 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/opencontainers/selinux/go-selinux/label"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// LocalVolumeDriver is the only volume driver supported by libpod, which keeps
+// volumes in directories on the host
+const LocalVolumeDriver = "local"
+
+// Volume is a named volume: a directory on the host, managed by libpod, that
+// is mounted into containers and kept when they are removed
+// ffjson: skip
+type Volume struct {
+	config *VolumeConfig
+	state  *volumeState
+
+	valid   bool
+	runtime *Runtime
+	lock    storage.Locker
+}
+
+// VolumeConfig holds a volume's static configuration
+type VolumeConfig struct {
+	// Name of the volume
+	Name string `json:"name"`
+	// Labels contains labels applied to the volume
+	Labels map[string]string `json:"labels"`
+	// MountPoint is the directory on the host holding the volume's
+	// contents
+	MountPoint string `json:"mountPoint"`
+	// Driver is the driver of the volume
+	Driver string `json:"driver"`
+	// Options are the driver options of the volume
+	// The local driver accepts type, device and o, which give a filesystem
+	// to mount at the mount point while containers use the volume
+	Options map[string]string `json:"options"`
+	// Time the volume was created
+	CreatedTime time.Time `json:"createdAt"`
+}
+
+// volumeState holds the parts of a volume's state that change as containers
+// use it
+type volumeState struct {
+	// MountCount is the number of containers with mounted storage using
+	// the volume
+	MountCount uint `json:"mountCount"`
+	// NeedsCopyUp indicates that no container has used the volume yet, so
+	// the first container to do so will copy the contents of its image at
+	// the mount destination into the volume
+	NeedsCopyUp bool `json:"needsCopyUp"`
+}
+
+// VolumeInspect represents the data we want to display for
+// podman volume inspect
+type VolumeInspect struct {
+	Name       string            `json:"Name"`
+	Driver     string            `json:"Driver"`
+	Mountpoint string            `json:"Mountpoint"`
+	CreatedAt  time.Time         `json:"CreatedAt"`
+	Labels     map[string]string `json:"Labels"`
+	Scope      string            `json:"Scope"`
+	Options    map[string]string `json:"Options"`
+}
+
+// ContainerNamedVolume is a named volume mounted into a container
+type ContainerNamedVolume struct {
+	// Name is the name of the volume
+	Name string `json:"volumeName"`
+	// Dest is the path the volume is mounted at in the container
+	Dest string `json:"dest"`
+	// Options are the options the volume is mounted with, as for bind
+	// mounts, plus nocopy to leave the volume empty on first use
+	Options []string `json:"options,omitempty"`
+}
+
+// Name retrieves the volume's name
+func (v *Volume) Name() string {
+	return v.config.Name
+}
+
+// Labels returns the volume's labels
+func (v *Volume) Labels() map[string]string {
+	labels := make(map[string]string)
+	for key, value := range v.config.Labels {
+		labels[key] = value
+	}
+
+	return labels
+}
+
+// MountPoint returns the directory on the host holding the volume's contents
+func (v *Volume) MountPoint() string {
+	return v.config.MountPoint
+}
+
+// Driver returns the volume's driver
+func (v *Volume) Driver() string {
+	return v.config.Driver
+}
+
+// Options returns the volume's driver options
+func (v *Volume) Options() map[string]string {
+	options := make(map[string]string)
+	for key, value := range v.config.Options {
+		options[key] = value
+	}
+
+	return options
+}
+
+// CreatedTime returns the time the volume was created
+func (v *Volume) CreatedTime() time.Time {
+	return v.config.CreatedTime
+}
+
+// Inspect returns a VolumeInspect struct to describe the volume
+func (v *Volume) Inspect() (*VolumeInspect, error) {
+	if !v.valid {
+		return nil, ErrVolumeRemoved
+	}
+
+	return &VolumeInspect{
+		Name:       v.config.Name,
+		Driver:     v.config.Driver,
+		Mountpoint: v.config.MountPoint,
+		CreatedAt:  v.config.CreatedTime,
+		Labels:     v.Labels(),
+		Scope:      "local",
+		Options:    v.Options(),
+	}, nil
+}
+
+// Creates a new, empty volume
+func newVolume(runtime *Runtime) *Volume {
+	volume := new(Volume)
+	volume.config = new(VolumeConfig)
+	volume.state = new(volumeState)
+	volume.runtime = runtime
+	volume.config.Labels = make(map[string]string)
+	volume.config.Options = make(map[string]string)
+	volume.config.Driver = LocalVolumeDriver
+	volume.config.CreatedTime = time.Now()
+	volume.state.NeedsCopyUp = true
+
+	return volume
+}
+
+// Path of the lock file of the volume with the given name
+// Volume names are not registered with container and pod IDs, so the lock
+// files of volumes are prefixed to keep them apart
+func volumeLockPath(lockDir, name string) string {
+	return filepath.Join(lockDir, "volume-"+name)
+}
+
+// Refresh the volume's state from the database
+func (v *Volume) update() error {
+	return v.runtime.state.UpdateVolume(v)
+}
+
+// Save the volume's state to the database
+func (v *Volume) save() error {
+	return v.runtime.state.SaveVolume(v)
+}
+
+// Whether the volume has a filesystem to mount while containers use it
+func (v *Volume) needsMount() bool {
+	return v.config.Options["type"] != "" || v.config.Options["device"] != ""
+}
+
+// Mount the filesystem given by the volume's options at its mount point
+func (v *Volume) mount() error {
+	fsType := v.config.Options["type"]
+	device := v.config.Options["device"]
+	if device == "" {
+		device = fsType
+	}
+
+	var flags uintptr
+	var data []string
+	for _, opt := range strings.Split(v.config.Options["o"], ",") {
+		switch opt {
+		case "":
+		case "ro":
+			flags |= unix.MS_RDONLY
+		case "nodev":
+			flags |= unix.MS_NODEV
+		case "noexec":
+			flags |= unix.MS_NOEXEC
+		case "nosuid":
+			flags |= unix.MS_NOSUID
+		case "bind":
+			flags |= unix.MS_BIND
+		default:
+			data = append(data, opt)
+		}
+	}
+
+	if err := unix.Mount(device, v.config.MountPoint, fsType, flags, strings.Join(data, ",")); err != nil {
+		return errors.Wrapf(err, "error mounting %s at %s for volume %s", device, v.config.MountPoint, v.Name())
+	}
+	return nil
+}
+
+// Unmount the filesystem mounted at the volume's mount point
+func (v *Volume) unmount() error {
+	if err := unix.Unmount(v.config.MountPoint, unix.MNT_DETACH); err != nil && err != unix.EINVAL {
+		return errors.Wrapf(err, "error unmounting volume %s", v.Name())
+	}
+	return nil
+}
+
+// Mount the volume for a container whose root filesystem is mounted at
+// ctrRoot
+// If no container used the volume before, the contents of the container's
+// image at the destination are copied into it, unless the nocopy option is
+// given
+func (v *Volume) mountForContainer(c *Container, ctrRoot string, namedVol *ContainerNamedVolume) (err error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.state.MountCount == 0 && v.needsMount() {
+		if err := v.mount(); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				if err2 := v.unmount(); err2 != nil {
+					logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err2)
+				}
+			}
+		}()
+	}
+
+	relabel := false
+	shared := false
+	copyUp := true
+	for _, opt := range namedVol.Options {
+		switch opt {
+		case "z":
+			relabel, shared = true, true
+		case "Z":
+			relabel, shared = true, false
+		case "nocopy":
+			copyUp = false
+		}
+	}
+
+	if v.state.NeedsCopyUp && copyUp {
+		if err := v.copyUp(ctrRoot, namedVol.Dest); err != nil {
+			return errors.Wrapf(err, "error copying contents of %s in container %s into volume %s", namedVol.Dest, c.ID(), v.Name())
+		}
+	}
+	if relabel {
+		if err := label.Relabel(v.config.MountPoint, c.config.MountLabel, shared); err != nil {
+			return errors.Wrapf(err, "error relabeling volume %s", v.Name())
+		}
+	}
+
+	v.state.NeedsCopyUp = false
+	v.state.MountCount++
+
+	return v.save()
+}
+
+// Unmount the volume for a container that no longer uses it
+// The volume's filesystem is unmounted when no container uses it any more
+func (v *Volume) unmountForContainer() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.state.MountCount == 0 {
+		return nil
+	}
+	v.state.MountCount--
+
+	if v.state.MountCount == 0 && v.needsMount() {
+		if err := v.unmount(); err != nil {
+			return err
+		}
+	}
+
+	return v.save()
+}
+
+// Copy the contents of the given directory in a container's root filesystem
+// into the volume
+// Nothing is copied if the directory does not exist in the image, or if the
+// volume is not empty
+func (v *Volume) copyUp(ctrRoot, dest string) error {
+	resolved, err := resolveContainerPath(ctrRoot, nil, dest)
+	if err != nil {
+		return err
+	}
+	srcPath := filepath.Join(ctrRoot, resolved)
+
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	contents, err := ioutil.ReadDir(v.config.MountPoint)
+	if err != nil {
+		return err
+	}
+	if len(contents) > 0 {
+		return nil
+	}
+
+	if err := chrootarchive.NewArchiver(nil).CopyWithTar(srcPath, v.config.MountPoint); err != nil {
+		return err
+	}
+
+	// The volume takes the ownership and permissions of the directory
+	// it is mounted over
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		if err := os.Chown(v.config.MountPoint, int(stat.Uid), int(stat.Gid)); err != nil {
+			return err
+		}
+	}
+	return os.Chmod(v.config.MountPoint, info.Mode())
+}
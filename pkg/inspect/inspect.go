@@ -3,6 +3,7 @@ package inspect
 import (
 	"time"
 
+	"github.com/containers/image/manifest"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/go-connections/nat"
 	"github.com/opencontainers/go-digest"
@@ -22,7 +23,8 @@ type HostConfig struct {
 	ContainerIDFile      string                      `json:"ContainerIDFile"`
 	LogConfig            *LogConfig                  `json:"LogConfig"` //TODO
 	NetworkMode          string                      `json:"NetworkMode"`
-	PortBindings         nat.PortMap                 `json:"PortBindings"` //TODO
+	PortBindings         nat.PortMap                 `json:"PortBindings"`
+	RestartPolicy        *RestartPolicy              `json:"RestartPolicy"`
 	AutoRemove           bool                        `json:"AutoRemove"`
 	CapAdd               []string                    `json:"CapAdd"`
 	CapDrop              []string                    `json:"CapDrop"`
@@ -36,7 +38,7 @@ type HostConfig struct {
 	OomScoreAdj          *int                        `json:"OomScoreAdj"`
 	PidMode              string                      `json:"PidMode"`
 	Privileged           bool                        `json:"Privileged"`
-	PublishAllPorts      bool                        `json:"PublishAllPorts"` //TODO
+	PublishAllPorts      bool                        `json:"PublishAllPorts"`
 	ReadonlyRootfs       bool                        `json:"ReadonlyRootfs"`
 	SecurityOpt          []string                    `json:"SecurityOpt"`
 	UTSMode              string                      `json:"UTSMode"`
@@ -76,6 +78,12 @@ type HostConfig struct {
 	Tmpfs                []string                    `json:"Tmpfs"`
 }
 
+// RestartPolicy represents what to do when the container exits
+type RestartPolicy struct {
+	Name              string `json:"Name"`
+	MaximumRetryCount uint   `json:"MaximumRetryCount"`
+}
+
 // CtrConfig holds information about the container configuration
 type CtrConfig struct {
 	Hostname     string              `json:"Hostname"`
@@ -96,6 +104,16 @@ type CtrConfig struct {
 	Labels       map[string]string   `json:"Labels"`
 	Annotations  map[string]string   `json:"Annotations"`
 	StopSignal   uint                `json:"StopSignal"`
+	Healthcheck  *HealthConfig       `json:"Healthcheck,omitempty"`
+}
+
+// HealthConfig describes how to check that a container is healthy
+type HealthConfig struct {
+	Test        []string      `json:"Test"`
+	Interval    time.Duration `json:"Interval,omitempty"`
+	Timeout     time.Duration `json:"Timeout,omitempty"`
+	StartPeriod time.Duration `json:"StartPeriod,omitempty"`
+	Retries     int           `json:"Retries,omitempty"`
 }
 
 // LogConfig holds the log information for a container
@@ -125,6 +143,8 @@ type ImageData struct {
 	Labels          map[string]string `json:"Labels"`
 	Annotations     map[string]string `json:"Annotations"`
 	ManifestType    string            `json:"ManifestType"`
+	// HealthCheck is only set for Docker images with a healthcheck
+	HealthCheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 }
 
 // RootFS holds the root fs information of an image
@@ -154,7 +174,7 @@ type ContainerInspectData struct {
 	StaticDir       string                 `json:"StaticDir"`
 	LogPath         string                 `json:"LogPath"`
 	Name            string                 `json:"Name"`
-	RestartCount    int32                  `json:"RestartCount"` //TODO
+	RestartCount    int32                  `json:"RestartCount"`
 	Driver          string                 `json:"Driver"`
 	MountLabel      string                 `json:"MountLabel"`
 	ProcessLabel    string                 `json:"ProcessLabel"`
@@ -182,6 +202,53 @@ type ContainerInspectState struct {
 	Error      string    `json:"Error"` // TODO
 	StartedAt  time.Time `json:"StartedAt"`
 	FinishedAt time.Time `json:"FinishedAt"`
+	// Healthcheck is only set for containers with a healthcheck
+	Healthcheck *HealthCheckResults `json:"Healthcheck,omitempty"`
+	// Checkpointed is set if the container was stopped by checkpointing it
+	Checkpointed bool `json:"Checkpointed,omitempty"`
+}
+
+// HealthCheckResults describes the health of a container and the results of
+// its most recent healthchecks
+type HealthCheckResults struct {
+	// Status is healthy, unhealthy or starting
+	Status string `json:"Status"`
+	// FailingStreak is the number of consecutive failed healthchecks
+	FailingStreak int `json:"FailingStreak"`
+	// Log holds the results of the most recent healthchecks
+	Log []HealthCheckLog `json:"Log"`
+}
+
+// HealthCheckLog describes the result of a single healthcheck
+type HealthCheckLog struct {
+	Start    time.Time `json:"Start"`
+	End      time.Time `json:"End"`
+	ExitCode int       `json:"ExitCode"`
+	Output   string    `json:"Output"`
+}
+
+// ExecSessionInspectData holds the inspect information of an exec session
+type ExecSessionInspectData struct {
+	ID            string            `json:"ID"`
+	ContainerID   string            `json:"ContainerID"`
+	State         string            `json:"State"`
+	Running       bool              `json:"Running"`
+	ExitCode      int               `json:"ExitCode"`
+	Pid           int               `json:"Pid"`
+	Detached      bool              `json:"Detached"`
+	StartedAt     time.Time         `json:"StartedAt"`
+	FinishedAt    time.Time         `json:"FinishedAt"`
+	ProcessConfig ExecProcessConfig `json:"ProcessConfig"`
+}
+
+// ExecProcessConfig describes the process an exec session runs
+type ExecProcessConfig struct {
+	Command     []string `json:"Command"`
+	Tty         bool     `json:"Tty"`
+	Privileged  bool     `json:"Privileged"`
+	User        string   `json:"User"`
+	WorkingDir  string   `json:"WorkingDir"`
+	PreserveFDs uint     `json:"PreserveFDs"`
 }
 
 // NetworkSettings holds information about the newtwork settings of the container
@@ -203,6 +270,25 @@ type NetworkSettings struct {
 	IPPrefixLen            int                  `json:"IPPrefixLen"`
 	IPv6Gateway            string               `json:"IPv6Gateway"`
 	MacAddress             string               `json:"MacAddress"`
+	// Networks describes the container's configuration in each network
+	// it is attached to
+	Networks map[string]*EndpointSettings `json:"Networks,omitempty"`
+}
+
+// EndpointSettings describes a container's configuration in a network
+type EndpointSettings struct {
+	IPAMConfig  *EndpointIPAMConfig `json:"IPAMConfig"`
+	Aliases     []string            `json:"Aliases"`
+	IPAddress   string              `json:"IPAddress"`
+	IPPrefixLen int                 `json:"IPPrefixLen"`
+	Gateway     string              `json:"Gateway"`
+	MacAddress  string              `json:"MacAddress"`
+}
+
+// EndpointIPAMConfig holds the static IP requested for a container in a network
+type EndpointIPAMConfig struct {
+	IPv4Address string `json:"IPv4Address,omitempty"`
+	IPv6Address string `json:"IPv6Address,omitempty"`
 }
 
 // ImageResult is used for podman images for collection and output
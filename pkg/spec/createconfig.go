@@ -1,6 +1,7 @@
 package createconfig
 
 import (
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -83,6 +84,7 @@ type CreateConfig struct {
 	Env                map[string]string //env
 	ExposedPorts       map[nat.Port]struct{}
 	GroupAdd           []string // group-add
+	HealthCheck        *libpod.HealthCheckConfig
 	HostAdd            []string //add-host
 	Hostname           string   //hostname
 	Image              string
@@ -112,7 +114,9 @@ type CreateConfig struct {
 	Quiet              bool     //quiet
 	ReadOnlyRootfs     bool     //read-only
 	Resources          CreateResourceConfig
-	Rm                 bool //rm
+	RestartPolicy      string //restart
+	RestartRetries     uint   //restart
+	Rm                 bool   //rm
 	ShmDir             string
 	StopSignal         syscall.Signal       // stop-signal
 	StopTimeout        uint                 // stop-timeout
@@ -271,6 +275,10 @@ func (c *CreateConfig) GetVolumeMounts(specMounts []spec.Mount) ([]spec.Mount, e
 	for _, i := range c.Volumes {
 		// We need to handle SELinux options better here, specifically :Z
 		spliti := strings.Split(i, ":")
+		// Named volumes are mounted by libpod
+		if IsNamedVolume(spliti[0]) {
+			continue
+		}
 		if len(spliti) > 2 {
 			options = strings.Split(spliti[2], ",")
 		}
@@ -338,6 +346,32 @@ func (c *CreateConfig) GetVolumeMounts(specMounts []spec.Mount) ([]spec.Mount, e
 	return m, nil
 }
 
+// IsNamedVolume returns whether the source of a volume given with --volume is
+// the name of a volume rather than a host path
+func IsNamedVolume(source string) bool {
+	return !strings.HasPrefix(source, "/")
+}
+
+// GetNamedVolumes returns the named volumes given with --volume
+func (c *CreateConfig) GetNamedVolumes() []*libpod.ContainerNamedVolume {
+	var volumes []*libpod.ContainerNamedVolume
+	for _, vol := range c.Volumes {
+		spliti := strings.SplitN(vol, ":", 3)
+		if !IsNamedVolume(spliti[0]) {
+			continue
+		}
+		namedVol := &libpod.ContainerNamedVolume{
+			Name: spliti[0],
+			Dest: spliti[1],
+		}
+		if len(spliti) > 2 {
+			namedVol.Options = strings.Split(spliti[2], ",")
+		}
+		volumes = append(volumes, namedVol)
+	}
+	return volumes
+}
+
 //GetTmpfsMounts takes user provided input for Tmpfs mounts and creates Mount structs
 func (c *CreateConfig) GetTmpfsMounts() []spec.Mount {
 	var m []spec.Mount
@@ -375,6 +409,17 @@ func (c *CreateConfig) GetContainerCreateOptions() ([]libpod.CtrCreateOption, er
 		logrus.Debugf("appending name %s", c.Name)
 		options = append(options, libpod.WithName(c.Name))
 	}
+	if c.Pod != "" {
+		logrus.Debugf("adding container to pod %s", c.Pod)
+		pod, err := c.Runtime.LookupPod(c.Pod)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to add container to pod %s", c.Pod)
+		}
+		if err := c.validatePodNamespaces(pod); err != nil {
+			return nil, err
+		}
+		options = append(options, c.Runtime.WithPod(pod))
+	}
 
 	if len(c.PortBindings) > 0 {
 		portBindings, err = c.CreatePortBindings()
@@ -395,6 +440,10 @@ func (c *CreateConfig) GetContainerCreateOptions() ([]libpod.CtrCreateOption, er
 		options = append(options, libpod.WithUserVolumes(volumes))
 	}
 
+	if namedVolumes := c.GetNamedVolumes(); len(namedVolumes) != 0 {
+		options = append(options, libpod.WithNamedVolumes(namedVolumes))
+	}
+
 	if len(c.Command) != 0 {
 		options = append(options, libpod.WithCommand(c.Command))
 	}
@@ -412,8 +461,33 @@ func (c *CreateConfig) GetContainerCreateOptions() ([]libpod.CtrCreateOption, er
 		options = append(options, libpod.WithNetNSFrom(connectedCtr))
 	} else if !c.NetMode.IsHost() && !c.NetMode.IsNone() {
 		postConfigureNetNS := (len(c.IDMappings.UIDMap) > 0 || len(c.IDMappings.GIDMap) > 0) && !c.UsernsMode.IsHost()
-		options = append(options, libpod.WithNetNS([]ocicni.PortMapping{}, postConfigureNetNS))
-		options = append(options, libpod.WithNetNS(portBindings, postConfigureNetNS))
+		// Any network mode other than the builtin ones is a comma
+		// separated list of CNI networks to join
+		var networks []string
+		if c.NetMode.IsUserDefined() {
+			networks = strings.Split(c.NetMode.UserDefined(), ",")
+		}
+		options = append(options, libpod.WithNetNS(portBindings, postConfigureNetNS, networks))
+
+		if c.IPAddress != "" {
+			ip := net.ParseIP(c.IPAddress)
+			if ip == nil || ip.To4() == nil {
+				return nil, errors.Errorf("%q is not a valid IPv4 address", c.IPAddress)
+			}
+			options = append(options, libpod.WithStaticIP(ip))
+		}
+		if c.MacAddress != "" {
+			mac, err := net.ParseMAC(c.MacAddress)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid MAC address %q", c.MacAddress)
+			}
+			options = append(options, libpod.WithStaticMAC(mac))
+		}
+		if len(c.NetworkAlias) > 0 {
+			options = append(options, libpod.WithNetworkAliases(c.NetworkAlias))
+		}
+	} else if c.IPAddress != "" || c.MacAddress != "" || len(c.NetworkAlias) > 0 {
+		return nil, errors.Errorf("cannot set the IP, MAC address or network aliases of a container with the %s network mode", c.NetMode)
 	}
 
 	if c.PidMode.IsContainer() {
@@ -435,6 +509,15 @@ func (c *CreateConfig) GetContainerCreateOptions() ([]libpod.CtrCreateOption, er
 
 	options = append(options, libpod.WithStopSignal(c.StopSignal))
 	options = append(options, libpod.WithStopTimeout(c.StopTimeout))
+	if c.RestartPolicy != "" {
+		options = append(options, libpod.WithRestartPolicy(c.RestartPolicy))
+	}
+	if c.RestartRetries != 0 {
+		options = append(options, libpod.WithRestartRetries(c.RestartRetries))
+	}
+	if c.HealthCheck != nil {
+		options = append(options, libpod.WithHealthCheck(c.HealthCheck))
+	}
 	if len(c.DNSSearch) > 0 {
 		options = append(options, libpod.WithDNSSearch(c.DNSSearch))
 	}
@@ -447,15 +530,48 @@ func (c *CreateConfig) GetContainerCreateOptions() ([]libpod.CtrCreateOption, er
 	if len(c.HostAdd) > 0 {
 		options = append(options, libpod.WithHosts(c.HostAdd))
 	}
+	if c.LogDriver != "" {
+		options = append(options, libpod.WithLogDriver(c.LogDriver))
+	}
 	logPath := getLoggingPath(c.LogDriverOpt)
 	if logPath != "" {
 		options = append(options, libpod.WithLogPath(logPath))
 	}
+	logMaxSize, logMaxFiles, err := getLogRotation(c.LogDriverOpt)
+	if err != nil {
+		return nil, err
+	}
+	if logMaxSize > 0 {
+		options = append(options, libpod.WithLogRotation(logMaxSize, logMaxFiles))
+	}
 
 	options = append(options, libpod.WithPrivileged(c.Privileged))
 	return options, nil
 }
 
+// validatePodNamespaces ensures the container does not request namespaces
+// that conflict with the ones it will join from the pod's infra container
+func (c *CreateConfig) validatePodNamespaces(pod *libpod.Pod) error {
+	if pod.SharesNet() {
+		if c.NetMode.IsHost() || c.NetMode.IsNone() || c.NetMode.IsContainer() || c.NetMode.IsUserDefined() {
+			return errors.Errorf("cannot set the network mode of a container joining pod %s, which shares its network namespace", pod.Name())
+		}
+		if len(c.PortBindings) > 0 {
+			return errors.Errorf("cannot publish ports of a container joining pod %s, which shares its network namespace", pod.Name())
+		}
+		if c.IPAddress != "" || c.MacAddress != "" || len(c.NetworkAlias) > 0 {
+			return errors.Errorf("cannot set the IP, MAC address or network aliases of a container joining pod %s, which shares its network namespace", pod.Name())
+		}
+	}
+	if pod.SharesIPC() && (c.IpcMode.IsHost() || c.IpcMode.IsContainer()) {
+		return errors.Errorf("cannot set the IPC mode of a container joining pod %s, which shares its IPC namespace", pod.Name())
+	}
+	if pod.SharesUTS() && (c.UtsMode.IsHost() || c.Hostname != "") {
+		return errors.Errorf("cannot set the UTS mode or hostname of a container joining pod %s, which shares its UTS namespace", pod.Name())
+	}
+	return nil
+}
+
 // CreatePortBindings iterates ports mappings and exposed ports into a format CNI understands
 func (c *CreateConfig) CreatePortBindings() ([]ocicni.PortMapping, error) {
 	var portBindings []ocicni.PortMapping
@@ -466,9 +582,8 @@ func (c *CreateConfig) CreatePortBindings() ([]ocicni.PortMapping, error) {
 			var hostPort int
 			var err error
 			pm.HostIP = i.HostIP
-			if i.HostPort == "" {
-				hostPort = containerPb.Int()
-			} else {
+			// Ports without a host port are allocated one by libpod
+			if i.HostPort != "" {
 				hostPort, err = strconv.Atoi(i.HostPort)
 				if err != nil {
 					return nil, errors.Wrapf(err, "unable to convert host port to integer")
@@ -114,6 +114,42 @@ func getLoggingPath(opts []string) string {
 	return ""
 }
 
+// getLogRotation returns the size the log file may reach and the number of log
+// files to keep, given by the max-size and max-file logging options
+// A size of 0 means the log file is not limited
+func getLogRotation(opts []string) (int64, uint, error) {
+	var maxSize int64
+	maxFiles := uint64(1)
+	for _, opt := range opts {
+		arr := strings.SplitN(opt, "=", 2)
+		if len(arr) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(arr[1])
+		switch strings.TrimSpace(arr[0]) {
+		case "max-size":
+			size, err := units.RAMInBytes(value)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid max-size %q: %v", value, err)
+			}
+			if size <= 0 {
+				return 0, 0, fmt.Errorf("invalid max-size %q: must be greater than 0", value)
+			}
+			maxSize = size
+		case "max-file":
+			files, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || files == 0 {
+				return 0, 0, fmt.Errorf("invalid max-file %q: must be a positive number", value)
+			}
+			maxFiles = files
+		}
+	}
+	if maxSize == 0 && maxFiles > 1 {
+		return 0, 0, fmt.Errorf("max-file cannot be greater than 1 without max-size")
+	}
+	return maxSize, uint(maxFiles), nil
+}
+
 // Device transforms a libcontainer configs.Device to a specs.LinuxDevice object.
 func Device(d *configs.Device) spec.LinuxDevice {
 	return spec.LinuxDevice{
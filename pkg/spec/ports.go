@@ -2,11 +2,9 @@ package createconfig
 
 import (
 	"fmt"
-	"net"
 	"strconv"
 
 	"github.com/docker/go-connections/nat"
-	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -48,6 +46,8 @@ func ExposedPorts(expose, publish []string, publishAll bool, imageExposedPorts m
 	}
 
 	// iterate container ports and make port bindings from them
+	// Bindings without a host port are allocated one by libpod when the
+	// container is created
 	if publishAll {
 		for e := range containerPorts {
 			//support two formats for expose, original format <portnum>/[<proto>] or <startport-endport>/[<proto>]
@@ -56,47 +56,14 @@ func ExposedPorts(expose, publish []string, publishAll bool, imageExposedPorts m
 			if err != nil {
 				return nil, err
 			}
-			rp, err := getRandomPort()
-			if err != nil {
-				return nil, err
-			}
-			logrus.Debug(fmt.Sprintf("Using random host port %d with container port %d", rp, p.Int()))
-			portBindings[p] = CreatePortBinding(rp, "")
+			logrus.Debugf("Publishing exposed container port %d", p.Int())
+			portBindings[p] = []nat.PortBinding{{}}
 		}
 	}
 
-	// We need to see if any host ports are not populated and if so, we need to assign a
-	// random port to them.
-	for k, pb := range portBindings {
-		if pb[0].HostPort == "" {
-			hostPort, err := getRandomPort()
-			if err != nil {
-				return nil, err
-			}
-			logrus.Debug(fmt.Sprintf("Using random host port %d with container port %s", hostPort, k.Port()))
-			pb[0].HostPort = strconv.Itoa(hostPort)
-		}
-	}
 	return portBindings, nil
 }
 
-func getRandomPort() (int, error) {
-	l, err := net.Listen("tcp", ":0")
-	if err != nil {
-		return 0, errors.Wrapf(err, "unable to get free port")
-	}
-	defer l.Close()
-	_, randomPort, err := net.SplitHostPort(l.Addr().String())
-	if err != nil {
-		return 0, errors.Wrapf(err, "unable to determine free port")
-	}
-	rp, err := strconv.Atoi(randomPort)
-	if err != nil {
-		return 0, errors.Wrapf(err, "unable to convert random port to int")
-	}
-	return rp, nil
-}
-
 //CreatePortBinding takes port (int) and IP (string) and creates an array of portbinding structs
 func CreatePortBinding(hostPort int, hostIP string) []nat.PortBinding {
 	pb := nat.PortBinding{
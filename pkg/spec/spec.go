@@ -15,7 +15,25 @@ import (
 	"io/ioutil"
 )
 
-const cpuPeriod = 100000
+// CPUPeriod is the CFS period used to limit a container to a number of CPUs
+const CPUPeriod = 100000
+
+const (
+	// sharesPerCPU is the CPU shares (relative weight) of a full CPU
+	sharesPerCPU = 1024
+	// minShares is the lowest CPU shares value accepted by the kernel
+	minShares = 2
+)
+
+// MilliCPUToShares converts a number of milli CPUs into CPU shares, raising
+// the result to the lowest value accepted by the kernel
+func MilliCPUToShares(milliCPU int64) uint64 {
+	shares := milliCPU * sharesPerCPU / 1000
+	if shares < minShares {
+		return minShares
+	}
+	return uint64(shares)
+}
 
 // CreateConfigToOCISpec parses information needed to create a container into an OCI runtime spec
 func CreateConfigToOCISpec(config *CreateConfig) (*spec.Spec, error) { //nolint
@@ -101,8 +119,8 @@ func CreateConfigToOCISpec(config *CreateConfig) (*spec.Spec, error) { //nolint
 		g.SetLinuxResourcesCPUPeriod(config.Resources.CPUPeriod)
 	}
 	if config.Resources.CPUs != 0 {
-		g.SetLinuxResourcesCPUPeriod(cpuPeriod)
-		g.SetLinuxResourcesCPUQuota(int64(config.Resources.CPUs * cpuPeriod))
+		g.SetLinuxResourcesCPUPeriod(CPUPeriod)
+		g.SetLinuxResourcesCPUQuota(int64(config.Resources.CPUs * CPUPeriod))
 	}
 	if config.Resources.CPURtRuntime != 0 {
 		g.SetLinuxResourcesCPURealtimeRuntime(config.Resources.CPURtRuntime)
@@ -345,6 +363,9 @@ func addNetNS(config *CreateConfig, g *generate.Generator) error {
 		return nil
 	} else if netMode.IsContainer() {
 		logrus.Debug("Using container netmode")
+	} else if netMode.IsUserDefined() {
+		logrus.Debugf("Using CNI networks %s", netMode.UserDefined())
+		return nil
 	} else {
 		return errors.Errorf("unknown network mode")
 	}
@@ -420,3 +441,60 @@ func addDevice(g *generate.Generator, device string) error {
 	g.AddLinuxResourcesDevice(true, string(dev.Type), &dev.Major, &dev.Minor, dev.Permissions)
 	return nil
 }
+
+// UpdateResources converts the resource limits that can be changed on an
+// existing container to the resources to update, leaving limits that are 0
+// unset
+func (c *CreateResourceConfig) UpdateResources() *spec.LinuxResources {
+	resources := new(spec.LinuxResources)
+
+	cpu := new(spec.LinuxCPU)
+	if c.CPUShares != 0 {
+		cpu.Shares = &c.CPUShares
+	}
+	if c.CPUQuota != 0 {
+		cpu.Quota = &c.CPUQuota
+	}
+	if c.CPUPeriod != 0 {
+		cpu.Period = &c.CPUPeriod
+	}
+	if c.CPUs != 0 {
+		period := uint64(CPUPeriod)
+		quota := int64(c.CPUs * CPUPeriod)
+		cpu.Period = &period
+		cpu.Quota = &quota
+	}
+	cpu.Cpus = c.CPUsetCPUs
+	cpu.Mems = c.CPUsetMems
+	if *cpu != (spec.LinuxCPU{}) {
+		resources.CPU = cpu
+	}
+
+	memory := new(spec.LinuxMemory)
+	if c.Memory != 0 {
+		memory.Limit = &c.Memory
+	}
+	if c.MemoryReservation != 0 {
+		memory.Reservation = &c.MemoryReservation
+	}
+	if c.MemorySwap != 0 {
+		memory.Swap = &c.MemorySwap
+	}
+	if *memory != (spec.LinuxMemory{}) {
+		resources.Memory = memory
+	}
+
+	if c.BlkioWeight != 0 {
+		resources.BlockIO = &spec.LinuxBlockIO{
+			Weight: &c.BlkioWeight,
+		}
+	}
+
+	if c.PidsLimit != 0 {
+		resources.Pids = &spec.LinuxPids{
+			Limit: c.PidsLimit,
+		}
+	}
+
+	return resources
+}
@@ -12,17 +12,29 @@ func TestCreateConfig_GetVolumeMounts(t *testing.T) {
 	data := spec.Mount{
 		Destination: "/foobar",
 		Type:        "bind",
-		Source:      "foobar",
+		Source:      "/foobar",
 		Options:     []string{"ro", "rbind", "private"},
 	}
 	config := CreateConfig{
-		Volumes: []string{"foobar:/foobar:ro"},
+		Volumes: []string{"/foobar:/foobar:ro", "myvol:/myvol"},
 	}
 	specMount, err := config.GetVolumeMounts([]spec.Mount{})
 	assert.NoError(t, err)
+	assert.Len(t, specMount, 1)
 	assert.True(t, reflect.DeepEqual(data, specMount[0]))
 }
 
+func TestCreateConfig_GetNamedVolumes(t *testing.T) {
+	config := CreateConfig{
+		Volumes: []string{"/foobar:/foobar:ro", "myvol:/myvol:ro,nocopy"},
+	}
+	volumes := config.GetNamedVolumes()
+	assert.Len(t, volumes, 1)
+	assert.Equal(t, "myvol", volumes[0].Name)
+	assert.Equal(t, "/myvol", volumes[0].Dest)
+	assert.Equal(t, []string{"ro", "nocopy"}, volumes[0].Options)
+}
+
 func TestCreateConfig_GetAnnotations(t *testing.T) {
 	config := CreateConfig{}
 	annotations := config.GetAnnotations()
@@ -43,3 +55,57 @@ func TestCreateConfig_GetTmpfsMounts(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(data, tmpfsMount[0]))
 
 }
+
+func TestCreateResourceConfig_UpdateResources(t *testing.T) {
+	config := CreateResourceConfig{
+		CPUShares:  512,
+		CPUsetCPUs: "0-1",
+		Memory:     64 * 1024 * 1024,
+		PidsLimit:  100,
+	}
+	resources := config.UpdateResources()
+	assert.Equal(t, uint64(512), *resources.CPU.Shares)
+	assert.Nil(t, resources.CPU.Quota)
+	assert.Equal(t, "0-1", resources.CPU.Cpus)
+	assert.Equal(t, int64(64*1024*1024), *resources.Memory.Limit)
+	assert.Nil(t, resources.Memory.Swap)
+	assert.Nil(t, resources.BlockIO)
+	assert.Equal(t, int64(100), resources.Pids.Limit)
+}
+
+func TestCreateResourceConfig_UpdateResourcesCPUs(t *testing.T) {
+	config := CreateResourceConfig{
+		CPUs: 1.5,
+	}
+	resources := config.UpdateResources()
+	assert.Equal(t, uint64(CPUPeriod), *resources.CPU.Period)
+	assert.Equal(t, int64(150000), *resources.CPU.Quota)
+	assert.Nil(t, resources.Memory)
+	assert.Nil(t, resources.Pids)
+}
+
+func TestGetLogRotation(t *testing.T) {
+	maxSize, maxFiles, err := getLogRotation([]string{"path=/tmp/ctr.log"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), maxSize)
+	assert.Equal(t, uint(1), maxFiles)
+
+	maxSize, maxFiles, err = getLogRotation([]string{"max-size=10k", "max-file=3"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10*1024), maxSize)
+	assert.Equal(t, uint(3), maxFiles)
+
+	_, _, err = getLogRotation([]string{"max-file=3"})
+	assert.Error(t, err)
+	_, _, err = getLogRotation([]string{"max-size=foo"})
+	assert.Error(t, err)
+	_, _, err = getLogRotation([]string{"max-size=10k", "max-file=0"})
+	assert.Error(t, err)
+}
+
+func TestMilliCPUToShares(t *testing.T) {
+	assert.Equal(t, uint64(1024), MilliCPUToShares(1000))
+	assert.Equal(t, uint64(512), MilliCPUToShares(500))
+	assert.Equal(t, uint64(2), MilliCPUToShares(1))
+	assert.Equal(t, uint64(2), MilliCPUToShares(0))
+}
@@ -1,20 +1,20 @@
 package varlinkapi
 
 import (
-	"bufio"
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"os"
+	"strings"
 	"syscall"
-	"time"
 
 	"github.com/containers/storage/pkg/archive"
-	"github.com/pkg/errors"
 	"github.com/projectatomic/libpod/cmd/podman/batchcontainer"
 	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
 	"github.com/projectatomic/libpod/cmd/podman/varlink"
 	"github.com/projectatomic/libpod/libpod"
+	"github.com/projectatomic/libpod/libpod/logs"
+	cc "github.com/projectatomic/libpod/pkg/spec"
 )
 
 // ListContainers ...
@@ -121,7 +121,7 @@ func (i *LibpodAPI) ListContainerProcesses(call ioprojectatomicpodman.VarlinkCal
 
 // GetContainerLogs ...
 func (i *LibpodAPI) GetContainerLogs(call ioprojectatomicpodman.VarlinkCall, name string) error {
-	var logs []string
+	var lines []string
 	runtime, err := libpodruntime.GetRuntime(i.Cli)
 	if err != nil {
 		return call.ReplyRuntimeError(err.Error())
@@ -130,55 +130,32 @@ func (i *LibpodAPI) GetContainerLogs(call ioprojectatomicpodman.VarlinkCall, nam
 	if err != nil {
 		return call.ReplyContainerNotFound(name)
 	}
-	logPath := ctr.LogPath()
 
-	containerState, err := ctr.State()
-	if err != nil {
-		return call.ReplyErrorOccurred(err.Error())
-	}
-	if _, err := os.Stat(logPath); err != nil {
-		if containerState == libpod.ContainerStateConfigured {
-			return call.ReplyGetContainerLogs(logs)
-		}
-	}
-	file, err := os.Open(logPath)
-	if err != nil {
-		return errors.Wrapf(err, "unable to read container log file")
-	}
-	defer file.Close()
-	reader := bufio.NewReader(file)
-	if call.WantsMore() {
+	// If we want to follow, each line is returned as it is read
+	follow := call.WantsMore()
+	if follow {
 		call.Continues = true
 	}
-	for {
-		line, err := reader.ReadString('\n')
-		// We've read the entire file
-		if err == io.EOF {
-			if !call.WantsMore() {
-				// If this is a non-following log request, we return what we have
-				break
-			} else {
-				// If we want to follow, return what we have, wipe the slice, and make
-				// sure the container is still running before iterating.
-				call.ReplyGetContainerLogs(logs)
-				logs = []string{}
-				time.Sleep(1 * time.Second)
-				state, err := ctr.State()
-				if err != nil {
-					return call.ReplyErrorOccurred(err.Error())
-				}
-				if state != libpod.ContainerStateRunning && state != libpod.ContainerStatePaused {
-					return call.ReplyErrorOccurred(fmt.Sprintf("%s is no longer running", ctr.ID()))
-				}
-
-			}
-		} else if err != nil {
-			return call.ReplyErrorOccurred(err.Error())
-		} else {
-			logs = append(logs, line)
+	options := &logs.LogOptions{
+		Follow: follow,
+	}
+	logChannel := make(chan *logs.LogLine)
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- runtime.Log(getContext(), []*libpod.Container{ctr}, options, logChannel)
+	}()
+	for line := range logChannel {
+		if follow {
+			call.ReplyGetContainerLogs([]string{line.String()})
+			continue
 		}
+		lines = append(lines, line.String())
+	}
+	if err := <-readErr; err != nil {
+		return call.ReplyErrorOccurred(err.Error())
 	}
-	return call.ReplyGetContainerLogs(logs)
+	call.Continues = false
+	return call.ReplyGetContainerLogs(lines)
 }
 
 // ListContainerChanges ...
@@ -221,6 +198,61 @@ func (i *LibpodAPI) ExportContainer(call ioprojectatomicpodman.VarlinkCall, name
 	return call.ReplyExportContainer(path)
 }
 
+// archiveReplyWriter sends everything written to it as a part of the archive
+// returned by a streaming CopyFromContainer call
+type archiveReplyWriter struct {
+	call ioprojectatomicpodman.VarlinkCall
+}
+
+func (w *archiveReplyWriter) Write(p []byte) (int, error) {
+	if err := w.call.ReplyCopyFromContainer(base64.StdEncoding.EncodeToString(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// CopyFromContainer ...
+func (i *LibpodAPI) CopyFromContainer(call ioprojectatomicpodman.VarlinkCall, name, path string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		return call.ReplyContainerNotFound(name)
+	}
+	if call.WantsMore() {
+		call.Continues = true
+		if err := ctr.CopyFrom(path, &archiveReplyWriter{call: call}); err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		call.Continues = false
+		return call.ReplyCopyFromContainer("")
+	}
+	var buf bytes.Buffer
+	if err := ctr.CopyFrom(path, &buf); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyCopyFromContainer(base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// CopyToContainer ...
+func (i *LibpodAPI) CopyToContainer(call ioprojectatomicpodman.VarlinkCall, name, path, archive string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		return call.ReplyContainerNotFound(name)
+	}
+	input := base64.NewDecoder(base64.StdEncoding, strings.NewReader(archive))
+	if err := ctr.CopyTo(path, input); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyCopyToContainer(ctr.ID())
+}
+
 // GetContainerStats ...
 func (i *LibpodAPI) GetContainerStats(call ioprojectatomicpodman.VarlinkCall, name string) error {
 	runtime, err := libpodruntime.GetRuntime(i.Cli)
@@ -335,13 +367,48 @@ func (i *LibpodAPI) KillContainer(call ioprojectatomicpodman.VarlinkCall, name s
 }
 
 // UpdateContainer ...
-func (i *LibpodAPI) UpdateContainer(call ioprojectatomicpodman.VarlinkCall) error {
-	return call.ReplyMethodNotImplemented("UpdateContainer")
+func (i *LibpodAPI) UpdateContainer(call ioprojectatomicpodman.VarlinkCall, name string, resources ioprojectatomicpodman.CreateResourceConfig) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		return call.ReplyContainerNotFound(name)
+	}
+	config := cc.CreateResourceConfig{
+		BlkioWeight:       uint16(resources.Blkio_weight),
+		CPUShares:         uint64(resources.Cpu_shares),
+		CPUPeriod:         uint64(resources.Cpu_period),
+		CPUQuota:          resources.Cpu_quota,
+		CPUs:              resources.Cpus,
+		CPUsetCPUs:        resources.Cpuset_cpus,
+		CPUsetMems:        resources.Cpuset_mems,
+		Memory:            resources.Memory,
+		MemoryReservation: resources.Memory_reservation,
+		MemorySwap:        resources.Memory_swap,
+		PidsLimit:         resources.Pids_limit,
+	}
+	if err := ctr.Update(config.UpdateResources()); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyUpdateContainer(ctr.ID())
 }
 
 // RenameContainer ...
-func (i *LibpodAPI) RenameContainer(call ioprojectatomicpodman.VarlinkCall) error {
-	return call.ReplyMethodNotImplemented("RenameContainer")
+func (i *LibpodAPI) RenameContainer(call ioprojectatomicpodman.VarlinkCall, name, newName string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		return call.ReplyContainerNotFound(name)
+	}
+	if err := runtime.RenameContainer(ctr, newName); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyRenameContainer(ctr.ID())
 }
 
 // PauseContainer ...
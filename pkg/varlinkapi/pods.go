@@ -0,0 +1,248 @@
+package varlinkapi
+
+import (
+	"encoding/json"
+	"strings"
+	"syscall"
+
+	"github.com/projectatomic/libpod/cmd/podman/batchcontainer"
+	"github.com/projectatomic/libpod/cmd/podman/libpodruntime"
+	"github.com/projectatomic/libpod/cmd/podman/shared"
+	"github.com/projectatomic/libpod/cmd/podman/varlink"
+	"github.com/projectatomic/libpod/libpod"
+)
+
+// CreatePod ...
+func (i *LibpodAPI) CreatePod(call ioprojectatomicpodman.VarlinkCall, create ioprojectatomicpodman.PodCreate) error {
+	var options []libpod.PodCreateOption
+
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+
+	if create.Name != "" {
+		options = append(options, libpod.WithPodName(create.Name))
+	}
+	if create.CgroupParent != "" {
+		options = append(options, libpod.WithPodCgroupParent(create.CgroupParent))
+	}
+	if len(create.Labels) > 0 {
+		options = append(options, libpod.WithPodLabels(create.Labels))
+	}
+
+	if create.Infra {
+		options = append(options, libpod.WithInfraContainer())
+		if create.InfraImage != "" {
+			options = append(options, libpod.WithInfraImage(create.InfraImage))
+		}
+		if len(create.InfraCommand) > 0 {
+			options = append(options, libpod.WithInfraCommand(create.InfraCommand))
+		}
+		share := shared.DefaultPodNamespaces
+		if len(create.Share) > 0 {
+			share = strings.Join(create.Share, ",")
+		}
+		nsOptions, err := shared.GetPodNamespaceOptions(share)
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		options = append(options, nsOptions...)
+	} else {
+		if len(create.Share) > 0 {
+			return call.ReplyErrorOccurred("you must have an infra container to share namespaces")
+		}
+		if create.InfraImage != "" || len(create.InfraCommand) > 0 {
+			return call.ReplyErrorOccurred("an infra image or command cannot be used without an infra container")
+		}
+	}
+
+	// always have containers use pod cgroups
+	options = append(options, libpod.WithPodCgroups())
+
+	pod, err := runtime.NewPod(getContext(), options...)
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyCreatePod(pod.ID())
+}
+
+// ListPods ...
+func (i *LibpodAPI) ListPods(call ioprojectatomicpodman.VarlinkCall) error {
+	var listPods []ioprojectatomicpodman.ListPodData
+
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pods, err := runtime.Pods()
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	for _, pod := range pods {
+		listPod, err := makeListPod(pod)
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		listPods = append(listPods, listPod)
+	}
+	return call.ReplyListPods(listPods)
+}
+
+// GetPod ...
+func (i *LibpodAPI) GetPod(call ioprojectatomicpodman.VarlinkCall, name string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	listPod, err := makeListPod(pod)
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyGetPod(listPod)
+}
+
+// InspectPod ...
+func (i *LibpodAPI) InspectPod(call ioprojectatomicpodman.VarlinkCall, name string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	inspectData, err := pod.Inspect()
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	b, err := json.Marshal(&inspectData)
+	if err != nil {
+		return call.ReplyErrorOccurred("unable to serialize")
+	}
+	return call.ReplyInspectPod(string(b))
+}
+
+// StartPod ...
+func (i *LibpodAPI) StartPod(call ioprojectatomicpodman.VarlinkCall, name string) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	ctrErrs, err := pod.Start(getContext())
+	if err != nil {
+		return replyPodError(call, pod, ctrErrs, err)
+	}
+	return call.ReplyStartPod(pod.ID())
+}
+
+// StopPod ...
+func (i *LibpodAPI) StopPod(call ioprojectatomicpodman.VarlinkCall, name string, timeout int64) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	ctrErrs, err := pod.StopWithTimeout(true, int(timeout))
+	if err != nil {
+		return replyPodError(call, pod, ctrErrs, err)
+	}
+	return call.ReplyStopPod(pod.ID())
+}
+
+// KillPod ...
+func (i *LibpodAPI) KillPod(call ioprojectatomicpodman.VarlinkCall, name string, signal int64) error {
+	var killSignal uint = uint(syscall.SIGKILL)
+	if signal != -1 {
+		killSignal = uint(signal)
+	}
+
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	ctrErrs, err := pod.Kill(killSignal)
+	if err != nil {
+		return replyPodError(call, pod, ctrErrs, err)
+	}
+	return call.ReplyKillPod(pod.ID())
+}
+
+// RemovePod ...
+func (i *LibpodAPI) RemovePod(call ioprojectatomicpodman.VarlinkCall, name string, force bool) error {
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	if err := runtime.RemovePod(pod, force, force); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyRemovePod(pod.ID())
+}
+
+// ListPodContainers ...
+func (i *LibpodAPI) ListPodContainers(call ioprojectatomicpodman.VarlinkCall, name string) error {
+	var listContainers []ioprojectatomicpodman.ListContainerData
+
+	runtime, err := libpodruntime.GetRuntime(i.Cli)
+	if err != nil {
+		return call.ReplyRuntimeError(err.Error())
+	}
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		return call.ReplyPodNotFound(name)
+	}
+	containers, err := pod.AllContainers()
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	opts := batchcontainer.PsOptions{
+		Namespace: true,
+		Size:      true,
+	}
+	for _, ctr := range containers {
+		batchInfo, err := batchcontainer.BatchContainerOp(ctr, opts)
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+
+		listContainers = append(listContainers, makeListContainer(ctr.ID(), batchInfo))
+	}
+	return call.ReplyListPodContainers(listContainers)
+}
+
+// replyPodError replies with the errors of the containers of a pod that failed
+// an operation, or with the error of the operation itself if no container
+// failed
+func replyPodError(call ioprojectatomicpodman.VarlinkCall, pod *libpod.Pod, ctrErrs map[string]error, err error) error {
+	if len(ctrErrs) == 0 {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	errs := make([]ioprojectatomicpodman.PodContainerErrorData, 0, len(ctrErrs))
+	for ctrID, ctrErr := range ctrErrs {
+		errs = append(errs, ioprojectatomicpodman.PodContainerErrorData{
+			Containerid: ctrID,
+			Reason:      ctrErr.Error(),
+		})
+	}
+	return call.ReplyPodContainerError(pod.ID(), errs)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/projectatomic/libpod/cmd/podman/batchcontainer"
+	"github.com/projectatomic/libpod/cmd/podman/shared"
 	"github.com/projectatomic/libpod/cmd/podman/varlink"
 	"github.com/projectatomic/libpod/libpod"
 )
@@ -75,3 +76,44 @@ func makeListContainer(containerID string, batchInfo batchcontainer.BatchContain
 	}
 	return lc
 }
+
+func makeListPodContainers(containers []*libpod.Container) ([]ioprojectatomicpodman.ListPodContainerInfo, map[string]libpod.ContainerStatus, error) {
+	var listPodContainers []ioprojectatomicpodman.ListPodContainerInfo
+	ctrStatuses := make(map[string]libpod.ContainerStatus, len(containers))
+	for _, ctr := range containers {
+		state, err := ctr.State()
+		if err != nil {
+			return nil, nil, err
+		}
+		ctrStatuses[ctr.ID()] = state
+		listPodContainers = append(listPodContainers, ioprojectatomicpodman.ListPodContainerInfo{
+			Name:   ctr.Name(),
+			Id:     ctr.ID(),
+			Status: state.String(),
+		})
+	}
+	return listPodContainers, ctrStatuses, nil
+}
+
+func makeListPod(pod *libpod.Pod) (ioprojectatomicpodman.ListPodData, error) {
+	containers, err := pod.AllContainers()
+	if err != nil {
+		return ioprojectatomicpodman.ListPodData{}, err
+	}
+	listPodContainers, ctrStatuses, err := makeListPodContainers(containers)
+	if err != nil {
+		return ioprojectatomicpodman.ListPodData{}, err
+	}
+
+	lp := ioprojectatomicpodman.ListPodData{
+		Id:                 pod.ID(),
+		Name:               pod.Name(),
+		Createdat:          pod.CreatedTime().String(),
+		Cgroup:             pod.CgroupParent(),
+		Status:             shared.GetPodStatus(ctrStatuses),
+		Labels:             pod.Labels(),
+		Numberofcontainers: int64(len(containers)),
+		Containersinfo:     listPodContainers,
+	}
+	return lp, nil
+}
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman checkpoint", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman checkpoint bogus container", func() {
+		session := podmanTest.Podman([]string{"container", "checkpoint", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman restore bogus container", func() {
+		session := podmanTest.Podman([]string{"container", "restore", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman checkpoint container that is not running", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman restore container without checkpoint", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"container", "restore", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman checkpoint --export with multiple containers", func() {
+		result := podmanTest.Podman([]string{"container", "checkpoint", "--export", "/tmp/checkpoint.tar.gz", "first", "second"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman checkpoint and restore container", func() {
+		if _, err := exec.LookPath("criu"); err != nil {
+			Skip("criu is not installed")
+		}
+		session := podmanTest.RunTopContainer("test")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfRunningContainers()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.InspectContainerToJSON()[0].State.Checkpointed).To(BeTrue())
+
+		result = podmanTest.Podman([]string{"container", "restore", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfRunningContainers()).To(Equal(1))
+	})
+})
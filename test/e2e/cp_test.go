@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman cp", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman cp without a container", func() {
+		session := podmanTest.Podman([]string{"cp", "/etc/hosts", "/tmp"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman cp bogus container", func() {
+		session := podmanTest.Podman([]string{"cp", "foobar:/etc/hosts", tempdir})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman cp file from stopped container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		dest := filepath.Join(tempdir, "os-release")
+		result := podmanTest.Podman([]string{"cp", "test:/etc/os-release", dest})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		content, err := ioutil.ReadFile(dest)
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(ContainSubstring("Alpine"))
+	})
+
+	It("podman cp file into running container", func() {
+		session := podmanTest.RunTopContainer("test")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		src := filepath.Join(tempdir, "cpfile")
+		err := ioutil.WriteFile(src, []byte("copied into container"), 0644)
+		Expect(err).To(BeNil())
+
+		result := podmanTest.Podman([]string{"cp", src, "test:/tmp/newname"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"exec", "test", "cat", "/tmp/newname"})
+		check.WaitWithDefaultTimeout()
+		Expect(check.ExitCode()).To(Equal(0))
+		Expect(check.OutputToString()).To(Equal("copied into container"))
+	})
+
+	It("podman cp directory into and out of container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		src := filepath.Join(tempdir, "cpdir")
+		err := os.MkdirAll(filepath.Join(src, "sub"), 0755)
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filepath.Join(src, "sub", "file"), []byte("nested"), 0644)
+		Expect(err).To(BeNil())
+
+		result := podmanTest.Podman([]string{"cp", src, "test:/srv"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		dest := filepath.Join(tempdir, "out")
+		result = podmanTest.Podman([]string{"cp", "test:/srv/cpdir", dest})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		content, err := ioutil.ReadFile(filepath.Join(dest, "sub", "file"))
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("nested"))
+	})
+
+	It("podman cp follows symlinks inside the container", func() {
+		session := podmanTest.Podman([]string{"run", "--name", "test", ALPINE, "sh", "-c", "mkdir /target && echo inside > /target/file && ln -s /target /link && ln -s ../../../../etc /escape"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		dest := filepath.Join(tempdir, "file")
+		result := podmanTest.Podman([]string{"cp", "test:/link/file", dest})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		content, err := ioutil.ReadFile(dest)
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("inside\n"))
+
+		dest = filepath.Join(tempdir, "os-release")
+		result = podmanTest.Podman([]string{"cp", "test:/escape/os-release", dest})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		content, err = ioutil.ReadFile(dest)
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(ContainSubstring("Alpine"))
+	})
+})
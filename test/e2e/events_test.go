@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman events", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman events with bad filter", func() {
+		session := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "foo=bar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman events of container lifecycle", func() {
+		session := podmanTest.Podman([]string{"run", "--name", "events_lifecycle", ALPINE, "sh", "-c", "exit 3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(3))
+
+		rm := podmanTest.Podman([]string{"rm", "events_lifecycle"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+
+		// The container is gone, so its events are found by name
+		result := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=events_lifecycle", "--format", "{{.Status}} {{.ContainerExitCode}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		var statuses []string
+		for _, line := range result.OutputToStringArray() {
+			statuses = append(statuses, strings.Fields(line)[0])
+		}
+		Expect(statuses).To(ContainElement("create"))
+		Expect(statuses).To(ContainElement("init"))
+		Expect(statuses).To(ContainElement("start"))
+		Expect(statuses).To(ContainElement("remove"))
+		Expect(result.OutputToStringArray()).To(ContainElement("die 3"))
+	})
+
+	It("podman events with event filter and json format", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+		cid := setup.OutputToString()
+
+		pause := podmanTest.Podman([]string{"pause", cid})
+		pause.WaitWithDefaultTimeout()
+		Expect(pause.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=" + cid, "--filter", "event=pause", "--format", "json"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		lines := result.OutputToStringArray()
+		Expect(len(lines)).To(Equal(1))
+
+		var event map[string]interface{}
+		Expect(json.Unmarshal([]byte(lines[0]), &event)).To(BeNil())
+		Expect(event["id"]).To(Equal(cid))
+		Expect(event["status"]).To(Equal("pause"))
+		Expect(event["type"]).To(Equal("container"))
+	})
+
+	It("podman events with until", func() {
+		session := podmanTest.Podman([]string{"events", "--until", "1s", "--filter", "type=pod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+})
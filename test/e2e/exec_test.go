@@ -1,7 +1,10 @@
 package integration
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -87,4 +90,72 @@ var _ = Describe("Podman exec", func() {
 
 	})
 
+	It("podman exec exit code", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "-l", "sh", "-c", "exit 100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(100))
+	})
+
+	It("podman exec with workdir", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "-l", "--workdir", "/etc", "pwd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("/etc"))
+	})
+
+	It("podman exec with env file", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		envFile := filepath.Join(podmanTest.TempDir, "env")
+		err := ioutil.WriteFile(envFile, []byte("FOO=BAR\n"), 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"exec", "-l", "--env-file", envFile, "printenv", "FOO"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("BAR"))
+	})
+
+	It("podman exec detached and inspect exec session", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "-d", "-l", "sh", "-c", "exit 3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		execID := session.OutputToString()
+		Expect(execID).To(Not(Equal("")))
+
+		Eventually(func() string {
+			inspect := podmanTest.Podman([]string{"inspect", "--type", "exec", "--format", "{{.Running}} {{.ExitCode}}", execID})
+			inspect.WaitWithDefaultTimeout()
+			return strings.TrimSpace(inspect.OutputToString())
+		}, "10s", "500ms").Should(Equal("false 3"))
+
+		ids := podmanTest.Podman([]string{"inspect", "--format", "{{.ExecIDs}}", "test1"})
+		ids.WaitWithDefaultTimeout()
+		Expect(ids.ExitCode()).To(Equal(0))
+		Expect(ids.OutputToString()).To(ContainSubstring(execID))
+	})
+
+	It("podman exec detached with tty fails", func() {
+		setup := podmanTest.RunTopContainer("test1")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "-d", "-t", "-l", "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
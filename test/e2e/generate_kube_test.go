@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"os"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+)
+
+var _ = Describe("Podman generate kube", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman generate kube without container or pod", func() {
+		kube := podmanTest.Podman([]string{"generate", "kube"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+	})
+
+	It("podman generate kube on bogus object", func() {
+		kube := podmanTest.Podman([]string{"generate", "kube", "foobar"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman generate kube on container", func() {
+		session := podmanTest.RunTopContainer("top")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "top"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).To(BeNil())
+		Expect(pod.Name).To(Equal("top"))
+		Expect(len(pod.Spec.Containers)).To(Equal(1))
+		Expect(pod.Spec.Containers[0].Image).To(ContainSubstring("alpine"))
+	})
+
+	It("podman generate service kube on container", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "top", "-p", "8080:80", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "-s", "top"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		Expect(kube.OutputToString()).To(ContainSubstring("kind: Service"))
+		Expect(kube.OutputToString()).To(ContainSubstring("targetPort: 80"))
+	})
+
+	It("podman generate kube on pod", func() {
+		_, rc, _ := podmanTest.CreatePod("toppod")
+		Expect(rc).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("topcontainer", "toppod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "toppod"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).To(BeNil())
+		Expect(pod.Name).To(Equal("toppod"))
+		// The infra container is not part of the generated pod
+		Expect(len(pod.Spec.Containers)).To(Equal(1))
+		Expect(pod.Spec.Containers[0].Name).To(Equal("topcontainer"))
+	})
+})
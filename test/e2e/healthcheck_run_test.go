@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman healthcheck run", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman healthcheck run on container without healthcheck", func() {
+		podmanTest.RunTopContainer("nohc")
+		session := podmanTest.Podman([]string{"healthcheck", "run", "nohc"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman healthcheck run on nonexistent container", func() {
+		session := podmanTest.Podman([]string{"healthcheck", "run", "doesnotexist"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman healthcheck run healthy container", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "hc", "--health-cmd", "ls /", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(0))
+		Expect(hc.OutputToString()).To(Equal("healthy"))
+
+		inspect := podmanTest.Podman([]string{"inspect", "hc"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data := inspect.InspectContainerToJSON()
+		Expect(data[0].State.Healthcheck.Status).To(Equal("healthy"))
+		Expect(len(data[0].State.Healthcheck.Log)).To(Equal(1))
+		Expect(data[0].Config.Healthcheck.Test).To(Equal([]string{"CMD-SHELL", "ls /"}))
+
+		ps := podmanTest.Podman([]string{"ps", "-q", "--no-trunc", "--filter", "health=healthy"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(ContainSubstring(session.OutputToString()))
+	})
+
+	It("podman healthcheck run unhealthy container", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "hc", "--health-cmd", "exit 1", "--health-retries", "2", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(1))
+		Expect(hc.OutputToString()).To(Equal("starting"))
+
+		hc = podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(1))
+		Expect(hc.OutputToString()).To(Equal("unhealthy"))
+
+		ps := podmanTest.Podman([]string{"ps", "-q", "--no-trunc", "--filter", "health=unhealthy"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(ContainSubstring(session.OutputToString()))
+	})
+
+	It("podman healthcheck run on stopped container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "hc", "--health-cmd", "ls /", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman run with health options and no healthcheck", func() {
+		session := podmanTest.Podman([]string{"run", "--health-retries", "3", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--no-healthcheck", "--health-cmd", "ls", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+})
@@ -201,6 +201,9 @@ func (p *PodmanTest) Cleanup() {
 	// Remove all containers
 	session := p.Podman([]string{"rm", "-fa"})
 	session.Wait(90)
+	// Remove all pods
+	podrm := p.Podman([]string{"pod", "rm", "-fa"})
+	podrm.Wait(90)
 	// Nuke tempdir
 	if err := os.RemoveAll(p.TempDir); err != nil {
 		fmt.Printf("%q\n", err)
@@ -412,6 +415,30 @@ func (p *PodmanTest) RunTopContainer(name string) *PodmanSession {
 	return p.Podman(podmanArgs)
 }
 
+// RunTopContainerInPod runs a simple container in the background that
+// runs top inside the given pod.  If the name passed != "", it will have a name
+func (p *PodmanTest) RunTopContainerInPod(name, pod string) *PodmanSession {
+	var podmanArgs = []string{"run", "--pod", pod}
+	if name != "" {
+		podmanArgs = append(podmanArgs, "--name", name)
+	}
+	podmanArgs = append(podmanArgs, "-d", ALPINE, "top")
+	return p.Podman(podmanArgs)
+}
+
+// CreatePod creates a pod with no containers.  If the name passed != "",
+// the pod will have a name.  It returns the session, its exit code and the
+// pod ID.
+func (p *PodmanTest) CreatePod(name string) (*PodmanSession, int, string) {
+	var podmanArgs = []string{"pod", "create"}
+	if name != "" {
+		podmanArgs = append(podmanArgs, "--name", name)
+	}
+	session := p.Podman(podmanArgs)
+	session.WaitWithDefaultTimeout()
+	return session, session.ExitCode(), session.OutputToString()
+}
+
 //RunLsContainer runs a simple container in the background that
 // simply runs ls. If the name passed != "", it will have a name
 func (p *PodmanTest) RunLsContainer(name string) (*PodmanSession, int, string) {
@@ -470,6 +497,21 @@ func (p *PodmanTest) NumberOfRunningContainers() int {
 	return len(containers)
 }
 
+// NumberOfPods returns an int of how many
+// pods are currently defined.
+func (p *PodmanTest) NumberOfPods() int {
+	var pods []string
+	ps := p.Podman([]string{"pod", "ps", "-q"})
+	ps.WaitWithDefaultTimeout()
+	Expect(ps.ExitCode()).To(Equal(0))
+	for _, i := range ps.OutputToStringArray() {
+		if i != "" {
+			pods = append(pods, i)
+		}
+	}
+	return len(pods)
+}
+
 // StringInSlice determines if a string is in a string slice, returns bool
 func StringInSlice(s string, sl []string) bool {
 	for _, i := range sl {
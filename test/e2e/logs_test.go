@@ -2,6 +2,7 @@ package integration
 
 import (
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -106,4 +107,140 @@ var _ = Describe("Podman logs", func() {
 		Expect(results.ExitCode()).To(Equal(0))
 		Expect(len(results.OutputToStringArray())).To(Equal(4))
 	})
+
+	It("podman logs of container with none log driver", func() {
+		logc := podmanTest.Podman([]string{"run", "--log-driver", "none", ALPINE, "echo", "podman"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+		cid := podmanTest.Podman([]string{"ps", "-lq"})
+		cid.WaitWithDefaultTimeout()
+
+		results := podmanTest.Podman([]string{"logs", cid.OutputToString()})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Not(Equal(0)))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.LogConfig.Type}}", cid.OutputToString()})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("none"))
+	})
+
+	It("podman logs with journald log driver", func() {
+		logc := podmanTest.Podman([]string{"run", "-d", "--log-driver", "journald", ALPINE, "sh", "-c", "echo podman; echo podman"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+		cid := logc.OutputToString()
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", cid})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"podman", "podman", ""}))
+	})
+
+	It("podman run with invalid log driver", func() {
+		session := podmanTest.Podman([]string{"create", "--log-driver", "syslog", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"create", "--log-driver", "none", "--log-opt", "path=/tmp/log", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman logs across rotated log files", func() {
+		supervise := podmanTest.Podman([]string{"supervise"})
+		defer supervise.Terminate().Wait(10)
+		// Give podman supervise time to take its lock
+		time.Sleep(2 * time.Second)
+
+		session := podmanTest.Podman([]string{"run", "--name", "logs_rotate", "--log-opt", "max-size=10", "--log-opt", "max-file=3", ALPINE, "echo", "first"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// The full log file is rotated when the container starts again
+		start := podmanTest.Podman([]string{"start", "--attach", "logs_rotate"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "logs_rotate"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"first", "first", ""}))
+	})
+
+	It("podman run with rotated log files requires podman supervise", func() {
+		session := podmanTest.Podman([]string{"run", "--log-opt", "max-size=10k", "--log-opt", "max-file=3", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--log-opt", "max-size=10k", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman run with invalid log rotation", func() {
+		session := podmanTest.Podman([]string{"create", "--log-opt", "max-file=3", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"create", "--log-driver", "journald", "--log-opt", "max-size=10k", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman logs of multiple containers", func() {
+		first := podmanTest.Podman([]string{"run", "-d", ALPINE, "echo", "first"})
+		first.WaitWithDefaultTimeout()
+		Expect(first.ExitCode()).To(Equal(0))
+		second := podmanTest.Podman([]string{"run", "-d", ALPINE, "echo", "second"})
+		second.WaitWithDefaultTimeout()
+		Expect(second.ExitCode()).To(Equal(0))
+
+		for _, cid := range []string{first.OutputToString(), second.OutputToString()} {
+			wait := podmanTest.Podman([]string{"wait", cid})
+			wait.WaitWithDefaultTimeout()
+			Expect(wait.ExitCode()).To(Equal(0))
+		}
+
+		results := podmanTest.Podman([]string{"logs", first.OutputToString(), second.OutputToString()})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{
+			first.OutputToString()[:12] + " first",
+			second.OutputToString()[:12] + " second",
+			"",
+		}))
+	})
+
+	It("podman logs follow stops when the container exits", func() {
+		logc := podmanTest.Podman([]string{"run", "-d", ALPINE, "sh", "-c", "echo podman; sleep 2; echo done"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+		cid := logc.OutputToString()
+
+		results := podmanTest.Podman([]string{"logs", "--follow", cid})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"podman", "done", ""}))
+	})
+
+	It("podman logs with until", func() {
+		logc := podmanTest.Podman([]string{"run", "-d", ALPINE, "echo", "podman"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+		cid := logc.OutputToString()
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "--until", "2017-08-07T10:10:09.056611202-04:00", cid})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToString()).To(Equal(""))
+	})
 })
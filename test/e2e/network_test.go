@@ -0,0 +1,235 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman network", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman network create, ls, inspect and rm", func() {
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.55.0/24", "--ip-range", "10.99.55.128/25", "network_test_net"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		defer func() {
+			podmanTest.Podman([]string{"network", "rm", "--force", "network_test_net"}).WaitWithDefaultTimeout()
+		}()
+
+		dup := podmanTest.Podman([]string{"network", "create", "network_test_net"})
+		dup.WaitWithDefaultTimeout()
+		Expect(dup.ExitCode()).To(Not(Equal(0)))
+
+		overlap := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.55.0/25", "network_test_overlap"})
+		overlap.WaitWithDefaultTimeout()
+		Expect(overlap.ExitCode()).To(Not(Equal(0)))
+
+		ls := podmanTest.Podman([]string{"network", "ls", "-q"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToStringArray()).To(ContainElement("network_test_net"))
+
+		inspect := podmanTest.Podman([]string{"network", "inspect", "network_test_net"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		var data []map[string]interface{}
+		Expect(json.Unmarshal(inspect.Out.Contents(), &data)).To(BeNil())
+		Expect(data).To(HaveLen(1))
+		Expect(data[0]["Driver"]).To(Equal("bridge"))
+		Expect(data[0]["Subnets"]).To(Equal([]interface{}{"10.99.55.0/24"}))
+		Expect(data[0]["Path"]).To(Equal(session.OutputToString()))
+
+		rm := podmanTest.Podman([]string{"network", "rm", "network_test_net"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+		_, err := os.Stat(session.OutputToString())
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("podman run on a named network", func() {
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.56.0/24", "network_test_run"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		defer func() {
+			podmanTest.Podman([]string{"network", "rm", "--force", "network_test_run"}).WaitWithDefaultTimeout()
+		}()
+
+		session = podmanTest.Podman([]string{"run", "-dt", "--name", "network_test_ctr", "--network", "network_test_run", "--ip", "10.99.56.20", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "network_test_ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containerConfig := inspect.InspectContainerToJSON()
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveKey("network_test_run"))
+		Expect(containerConfig[0].NetworkSettings.Networks["network_test_run"].IPAddress).To(Equal("10.99.56.20"))
+
+		// Networks joined by containers are only removed with --force
+		rm := podmanTest.Podman([]string{"network", "rm", "network_test_run"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Not(Equal(0)))
+
+		rm = podmanTest.Podman([]string{"network", "rm", "--force", "network_test_run"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
+
+	It("podman run on several networks", func() {
+		for _, args := range [][]string{
+			{"--subnet", "10.99.57.0/24", "network_test_multi1"},
+			{"--subnet", "10.99.58.0/24", "network_test_multi2"},
+		} {
+			session := podmanTest.Podman(append([]string{"network", "create"}, args...))
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			defer func(name string) {
+				podmanTest.Podman([]string{"network", "rm", "--force", name}).WaitWithDefaultTimeout()
+			}(args[2])
+		}
+
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "network_test_multi", "--network", "network_test_multi1,network_test_multi2", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "network_test_multi"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containerConfig := inspect.InspectContainerToJSON()
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveLen(2))
+		Expect(containerConfig[0].NetworkSettings.Networks["network_test_multi1"].IPAddress).To(ContainSubstring("10.99.57."))
+		Expect(containerConfig[0].NetworkSettings.Networks["network_test_multi2"].IPAddress).To(ContainSubstring("10.99.58."))
+		Expect(containerConfig[0].NetworkSettings.IPAddress).To(HaveLen(2))
+
+		session = podmanTest.Podman([]string{"exec", "network_test_multi", "ip", "addr", "show", "eth1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("10.99.58."))
+	})
+
+	It("podman network connect and disconnect", func() {
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.59.0/24", "network_test_connect"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		defer func() {
+			podmanTest.Podman([]string{"network", "rm", "--force", "network_test_connect"}).WaitWithDefaultTimeout()
+		}()
+
+		session = podmanTest.Podman([]string{"run", "-dt", "--name", "network_test_connect_ctr", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// A container cannot leave its only network
+		disconnect := podmanTest.Podman([]string{"network", "disconnect", "podman", "network_test_connect_ctr"})
+		disconnect.WaitWithDefaultTimeout()
+		Expect(disconnect.ExitCode()).To(Not(Equal(0)))
+
+		connect := podmanTest.Podman([]string{"network", "connect", "network_test_connect", "network_test_connect_ctr"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "network_test_connect_ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containerConfig := inspect.InspectContainerToJSON()
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveKey("podman"))
+		Expect(containerConfig[0].NetworkSettings.Networks["network_test_connect"].IPAddress).To(ContainSubstring("10.99.59."))
+
+		session = podmanTest.Podman([]string{"exec", "network_test_connect_ctr", "ip", "addr", "show", "eth1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("10.99.59."))
+
+		connect = podmanTest.Podman([]string{"network", "connect", "network_test_connect", "network_test_connect_ctr"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Not(Equal(0)))
+
+		disconnect = podmanTest.Podman([]string{"network", "disconnect", "podman", "network_test_connect_ctr"})
+		disconnect.WaitWithDefaultTimeout()
+		Expect(disconnect.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"inspect", "network_test_connect_ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containerConfig = inspect.InspectContainerToJSON()
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveLen(1))
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveKey("network_test_connect"))
+
+		session = podmanTest.Podman([]string{"exec", "network_test_connect_ctr", "ip", "link", "show", "eth0"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman containers on a network resolve each other by name", func() {
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.60.0/24", "network_test_hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		defer func() {
+			podmanTest.Podman([]string{"network", "rm", "--force", "network_test_hosts"}).WaitWithDefaultTimeout()
+		}()
+
+		session = podmanTest.Podman([]string{"run", "-dt", "--name", "network_test_hosts1", "--network", "network_test_hosts", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "-dt", "--name", "network_test_hosts2", "--network", "network_test_hosts", "--network-alias", "network_test_alias", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// Containers started before a peer learn its name
+		hosts := podmanTest.Podman([]string{"exec", "network_test_hosts1", "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(Equal(0))
+		Expect(hosts.OutputToString()).To(ContainSubstring("network_test_hosts2 network_test_alias"))
+
+		ping := podmanTest.Podman([]string{"exec", "network_test_hosts2", "ping", "-c", "1", "network_test_hosts1"})
+		ping.WaitWithDefaultTimeout()
+		Expect(ping.ExitCode()).To(Equal(0))
+
+		rename := podmanTest.Podman([]string{"rename", "network_test_hosts2", "network_test_hosts3"})
+		rename.WaitWithDefaultTimeout()
+		Expect(rename.ExitCode()).To(Equal(0))
+
+		hosts = podmanTest.Podman([]string{"exec", "network_test_hosts1", "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(Equal(0))
+		Expect(hosts.OutputToString()).To(ContainSubstring("network_test_hosts3"))
+		Expect(hosts.OutputToString()).To(Not(ContainSubstring("network_test_hosts2")))
+
+		stop := podmanTest.Podman([]string{"stop", "network_test_hosts3"})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop.ExitCode()).To(Equal(0))
+
+		hosts = podmanTest.Podman([]string{"exec", "network_test_hosts1", "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(Equal(0))
+		Expect(hosts.OutputToString()).To(Not(ContainSubstring("network_test_hosts3")))
+	})
+
+	It("podman run on a missing network fails", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "network_test_missing", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+})
@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var kubePodYAML = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  labels:
+    app: %s
+spec:
+  containers:
+  - name: %s
+    image: %s
+    imagePullPolicy: IfNotPresent
+    command:
+    - top
+    env:
+    - name: FOO
+      value: bar
+    workingDir: /tmp
+    ports:
+    - containerPort: 80
+      hostPort: 8080
+      protocol: TCP
+`
+
+func writeKubeYAML(dir, podName, ctrName string) (string, error) {
+	kubeYAML := filepath.Join(dir, "kube.yaml")
+	content := fmt.Sprintf(kubePodYAML, podName, podName, ctrName, ALPINE)
+	return kubeYAML, ioutil.WriteFile(kubeYAML, []byte(content), 0644)
+}
+
+var _ = Describe("Podman play kube", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman play kube without a file", func() {
+		kube := podmanTest.Podman([]string{"play", "kube"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+	})
+
+	It("podman play kube with a missing file", func() {
+		kube := podmanTest.Podman([]string{"play", "kube", filepath.Join(podmanTest.TempDir, "missing.yaml")})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+	})
+
+	It("podman play kube creates a pod", func() {
+		kubeYAML, err := writeKubeYAML(podmanTest.TempDir, "kubepod", "kubectr")
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYAML})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		Expect(len(kube.OutputToStringArray())).To(Equal(2))
+		Expect(podmanTest.NumberOfPods()).To(Equal(1))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{ .Config.WorkingDir }} {{ .Config.Env }} {{ .Config.Cmd }}", "kubectr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("/tmp"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("FOO=bar"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("top"))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+	})
+
+	It("podman play kube --start starts the pod", func() {
+		kubeYAML, err := writeKubeYAML(podmanTest.TempDir, "kubepod", "kubectr")
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", "--start", kubeYAML})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		// The infra container runs alongside the container from the YAML
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+	})
+
+	It("podman play kube of generated YAML", func() {
+		_, rc, _ := podmanTest.CreatePod("toppod")
+		Expect(rc).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("topcontainer", "toppod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "toppod"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		kubeYAML := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := ioutil.WriteFile(kubeYAML, kube.Out.Contents(), 0644)
+		Expect(err).To(BeNil())
+
+		rm := podmanTest.Podman([]string{"pod", "rm", "-f", "toppod"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+
+		play := podmanTest.Podman([]string{"play", "kube", "--start", kubeYAML})
+		play.WaitWithDefaultTimeout()
+		Expect(play.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfPods()).To(Equal(1))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+	})
+})
@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod create", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman create pod", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc"})
+		check.WaitWithDefaultTimeout()
+		match, _ := check.GrepString(podID)
+		Expect(match).To(BeTrue())
+		Expect(len(check.OutputToStringArray())).To(Equal(1))
+	})
+
+	It("podman create pod with name", func() {
+		name := "test"
+		_, ec, _ := podmanTest.CreatePod(name)
+		Expect(ec).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "--no-trunc"})
+		check.WaitWithDefaultTimeout()
+		match, _ := check.GrepString(name)
+		Expect(match).To(BeTrue())
+	})
+
+	It("podman create pod with doubled name", func() {
+		name := "test"
+		_, ec, _ := podmanTest.CreatePod(name)
+		Expect(ec).To(Equal(0))
+
+		_, ec2, _ := podmanTest.CreatePod(name)
+		Expect(ec2).To(Not(Equal(0)))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		check.WaitWithDefaultTimeout()
+		Expect(len(check.OutputToStringArray())).To(Equal(1))
+	})
+
+	It("podman create pod with same name as ctr", func() {
+		name := "test"
+		session := podmanTest.Podman([]string{"create", "--name", name, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		_, ec, _ := podmanTest.CreatePod(name)
+		Expect(ec).To(Not(Equal(0)))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		check.WaitWithDefaultTimeout()
+		Expect(len(check.OutputToStringArray())).To(Equal(0))
+	})
+
+	It("podman create pod with labels", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--label", "foo=bar", "--name", "labelled"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "-q", "--filter", "label=foo=bar"})
+		check.WaitWithDefaultTimeout()
+		Expect(check.ExitCode()).To(Equal(0))
+		Expect(len(check.OutputToStringArray())).To(Equal(1))
+	})
+
+	It("podman create pod with podidfile", func() {
+		podIDFile := tempdir + "podid"
+		session := podmanTest.Podman([]string{"pod", "create", "--podidfile", podIDFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		contents, err := ioutil.ReadFile(podIDFile)
+		Expect(err).To(BeNil())
+		Expect(string(contents)).To(Equal(session.OutputToString()))
+	})
+
+	It("podman create container in pod", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", podID, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "--no-trunc", "--ctr-ids", "--format", "{{.ContainerIDs}}"})
+		check.WaitWithDefaultTimeout()
+		Expect(check.OutputToString()).To(ContainSubstring(session.OutputToString()))
+	})
+
+	It("podman create container in bogus pod", func() {
+		session := podmanTest.Podman([]string{"create", "--pod", "foobar", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod exists", func() {
+		_, ec, podID := podmanTest.CreatePod("foobar")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "exists", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "exists", podID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman pod does not exist", func() {
+		session := podmanTest.Podman([]string{"pod", "exists", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(1))
+	})
+	It("podman pod create with resource limits", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--memory", "256m", "--cpus", "1.5", "--cpu-shares", "512", "--pids-limit", "100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", podID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.IsJSONOutputValid()).To(BeTrue())
+		output := inspect.OutputToString()
+		Expect(output).To(ContainSubstring("\"limit\": 268435456"))
+		Expect(output).To(ContainSubstring("\"shares\": 512"))
+		Expect(output).To(ContainSubstring("\"quota\": 150000"))
+		Expect(output).To(ContainSubstring("\"limit\": 100"))
+	})
+
+	It("podman pod memory limit applies to its containers", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--memory", "256m"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "cat", "/sys/fs/cgroup/memory/memory.stat"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("hierarchical_memory_limit 268435456"))
+	})
+
+	It("podman pod create with --cpus and --cpu-quota", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--cpus", "1", "--cpu-quota", "50000"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(podmanTest.NumberOfPods()).To(Equal(0))
+	})
+
+	It("podman pod create with invalid memory limit", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--memory", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(podmanTest.NumberOfPods()).To(Equal(0))
+	})
+})
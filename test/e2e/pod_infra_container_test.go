@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod infra container", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman create infra container", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc"})
+		check.WaitWithDefaultTimeout()
+		match, _ := check.GrepString(podID)
+		Expect(match).To(BeTrue())
+		Expect(len(check.OutputToStringArray())).To(Equal(1))
+
+		check = podmanTest.Podman([]string{"ps", "-qa", "--no-trunc"})
+		check.WaitWithDefaultTimeout()
+		Expect(len(check.OutputToStringArray())).To(Equal(1))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", podID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring(check.OutputToString()))
+	})
+
+	It("podman create pod without infra container", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--infra=false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
+
+	It("podman create pod sharing namespaces without infra container", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--infra=false", "--share", "net"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman create pod with invalid shared namespace", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--share", "pid"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman start infra container", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "start", podID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+	})
+
+	It("podman run in pod starts the infra container", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podID)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+	})
+
+	It("podman containers in pod share network and IPC namespaces", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podID)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		for _, ns := range []string{"net", "ipc"} {
+			first := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "readlink", "/proc/self/ns/" + ns})
+			first.WaitWithDefaultTimeout()
+			Expect(first.ExitCode()).To(Equal(0))
+
+			second := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "readlink", "/proc/self/ns/" + ns})
+			second.WaitWithDefaultTimeout()
+			Expect(second.ExitCode()).To(Equal(0))
+			Expect(first.OutputToString()).To(Equal(second.OutputToString()))
+		}
+	})
+
+	It("podman containers in pod use the pod name as hostname", func() {
+		_, ec, _ := podmanTest.CreatePod("foobar")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"run", "--pod", "foobar", ALPINE, "hostname"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("foobar"))
+	})
+
+	It("podman pod sharing only the network namespace", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "foobar", "--share", "net"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--pod", "foobar", ALPINE, "hostname"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Not(Equal("foobar")))
+	})
+
+	It("podman container in pod sharing network cannot publish ports", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", podID, "-p", "8080:80", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman container in pod sharing network cannot use host network", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", podID, "--net", "host", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman rm infra container fails", func() {
+		_, ec, _ := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"ps", "-qa", "--no-trunc"})
+		check.WaitWithDefaultTimeout()
+		infraID := check.OutputToString()
+
+		session := podmanTest.Podman([]string{"rm", infraID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman pod rm removes infra container", func() {
+		_, ec, podID := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "rm", podID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
+})
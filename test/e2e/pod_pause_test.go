@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod pause", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman pod pause bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "pause", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman pod unpause bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "unpause", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman pod pause a created pod", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "pause", podid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+	})
+
+	It("podman pod pause and unpause a running pod", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podid)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+
+		result := podmanTest.Podman([]string{"pod", "pause", podid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal(podid))
+
+		paused := podmanTest.Podman([]string{"ps", "-q", "--filter", "status=paused"})
+		paused.WaitWithDefaultTimeout()
+		Expect(paused.ExitCode()).To(Equal(0))
+		Expect(len(paused.OutputToStringArray())).To(Equal(2))
+
+		result = podmanTest.Podman([]string{"pod", "unpause", podid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal(podid))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+	})
+
+	It("podman pod pause and unpause all pods", func() {
+		_, ec, _ := podmanTest.CreatePod("foobar99")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", "foobar99")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		_, ec, _ = podmanTest.CreatePod("foobar100")
+		Expect(ec).To(Equal(0))
+
+		session = podmanTest.RunTopContainerInPod("", "foobar100")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "pause", "--all"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		paused := podmanTest.Podman([]string{"ps", "-q", "--filter", "status=paused"})
+		paused.WaitWithDefaultTimeout()
+		Expect(len(paused.OutputToStringArray())).To(Equal(4))
+
+		result = podmanTest.Podman([]string{"pod", "unpause", "--all"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(4))
+	})
+})
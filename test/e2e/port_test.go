@@ -79,4 +79,33 @@ var _ = Describe("Podman port", func() {
 		result.WaitWithDefaultTimeout()
 		Expect(result.ExitCode()).To(Equal(0))
 	})
+
+	It("podman publish-all allocates distinct host ports", func() {
+		first := podmanTest.Podman([]string{"create", "--expose", "80", "-P", ALPINE, "top"})
+		first.WaitWithDefaultTimeout()
+		Expect(first.ExitCode()).To(Equal(0))
+		second := podmanTest.Podman([]string{"create", "--expose", "80", "-P", ALPINE, "top"})
+		second.WaitWithDefaultTimeout()
+		Expect(second.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", first.OutputToString(), second.OutputToString()})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containers := inspect.InspectContainerToJSON()
+		Expect(containers).To(HaveLen(2))
+		Expect(containers[0].HostConfig.PublishAllPorts).To(BeTrue())
+		firstPort := containers[0].NetworkSettings.Ports[0].HostPort
+		Expect(firstPort).ToNot(Equal(int32(0)))
+		Expect(containers[1].NetworkSettings.Ports[0].HostPort).ToNot(Equal(firstPort))
+	})
+
+	It("podman publish host port used by another container", func() {
+		session := podmanTest.Podman([]string{"create", "-p", "18080:80", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "-p", "18080:8080", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).ToNot(Equal(0))
+	})
 })
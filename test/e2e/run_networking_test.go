@@ -91,4 +91,40 @@ var _ = Describe("Podman rmi", func() {
 		Expect(containerConfig[0].NetworkSettings.Ports[0].HostPort).ToNot(Equal("80"))
 	})
 
+	It("podman run network with static IP and network aliases", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "static_ip", "--ip", "10.88.64.128", "--network-alias", "web", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "static_ip"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		containerConfig := inspect.InspectContainerToJSON()
+		Expect(containerConfig[0].NetworkSettings.IPAddress).To(Equal([]string{"10.88.64.128"}))
+		Expect(containerConfig[0].NetworkSettings.Networks).To(HaveLen(1))
+		for _, endpoint := range containerConfig[0].NetworkSettings.Networks {
+			Expect(endpoint.IPAMConfig.IPv4Address).To(Equal("10.88.64.128"))
+			Expect(endpoint.Aliases).To(Equal([]string{"web"}))
+		}
+
+		// The IP cannot be requested by another container
+		session = podmanTest.Podman([]string{"create", "--ip", "10.88.64.128", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman run network with invalid static IP and MAC address", func() {
+		session := podmanTest.Podman([]string{"create", "--ip", "192.0.2.1", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"create", "--ip", "10.88.64.129", "--network", "host", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"create", "--mac-address", "not-a-mac", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
 })
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -50,4 +51,98 @@ var _ = Describe("Podman run restart containers", func() {
 		session2.WaitWithDefaultTimeout()
 		Expect(session2.ExitCode()).To(Equal(0))
 	})
+
+	It("Podman run with an invalid restart policy", func() {
+		session := podmanTest.Podman([]string{"run", "--restart", "sometimes", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--restart", "always:3", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("Podman run with --rm and --restart", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "--restart", "always", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("Podman inspect shows the restart policy", func() {
+		session := podmanTest.Podman([]string{"create", "--restart", "on-failure:3", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"inspect", "--latest"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		conData := result.InspectContainerToJSON()
+		Expect(conData[0].HostConfig.RestartPolicy.Name).To(Equal("on-failure"))
+		Expect(conData[0].HostConfig.RestartPolicy.MaximumRetryCount).To(Equal(uint(3)))
+		Expect(conData[0].RestartCount).To(Equal(int32(0)))
+	})
+
+	It("Podman supervise restarts a failed container up to its maximum retries", func() {
+		supervise := podmanTest.Podman([]string{"supervise"})
+		defer supervise.Terminate().Wait(10)
+
+		session := podmanTest.Podman([]string{"run", "-d", "--restart", "on-failure:2", ALPINE, "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		var restartCount int32
+		for i := 0; i < 20 && restartCount < 2; i++ {
+			time.Sleep(1 * time.Second)
+			result := podmanTest.Podman([]string{"inspect", "--latest"})
+			result.WaitWithDefaultTimeout()
+			Expect(result.ExitCode()).To(Equal(0))
+			restartCount = result.InspectContainerToJSON()[0].RestartCount
+		}
+		Expect(restartCount).To(Equal(int32(2)))
+
+		// No more restarts once the maximum is reached
+		time.Sleep(2 * time.Second)
+		result := podmanTest.Podman([]string{"inspect", "--latest"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		conData := result.InspectContainerToJSON()
+		Expect(conData[0].RestartCount).To(Equal(int32(2)))
+		Expect(conData[0].State.Running).To(BeFalse())
+	})
+
+	It("Podman supervise does not restart a container stopped by the user", func() {
+		supervise := podmanTest.Podman([]string{"supervise"})
+		defer supervise.Terminate().Wait(10)
+
+		session := podmanTest.Podman([]string{"run", "-d", "--restart", "always", "--name", "test1", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		stop := podmanTest.Podman([]string{"stop", "test1"})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop.ExitCode()).To(Equal(0))
+
+		time.Sleep(2 * time.Second)
+		Expect(podmanTest.NumberOfRunningContainers()).To(Equal(0))
+	})
+
+	It("Podman start --all with a restart policy filter", func() {
+		session := podmanTest.Podman([]string{"create", "--restart", "always", "--name", "test1", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--name", "test2", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=always"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfRunningContainers()).To(Equal(1))
+
+		ps := podmanTest.Podman([]string{"ps", "-q", "--filter", "name=test1"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(len(ps.OutputToStringArray())).To(Equal(1))
+	})
 })
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman update", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman update bogus container", func() {
+		session := podmanTest.Podman([]string{"update", "--cpu-shares", "512", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman update without resources", func() {
+		podmanTest.RunTopContainer("test")
+		session := podmanTest.Podman([]string{"update", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman update --cpus with --cpu-quota", func() {
+		session := podmanTest.Podman([]string{"update", "--cpus", "1", "--cpu-quota", "50000", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman update running container", func() {
+		session := podmanTest.RunTopContainer("test")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"update", "--cpu-shares", "512", "--pids-limit", "100", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		shares := podmanTest.Podman([]string{"exec", "test", "cat", "/sys/fs/cgroup/cpu/cpu.shares"})
+		shares.WaitWithDefaultTimeout()
+		Expect(shares.ExitCode()).To(Equal(0))
+		Expect(shares.OutputToString()).To(Equal("512"))
+
+		inspect := podmanTest.Podman([]string{"inspect", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data := inspect.InspectContainerToJSON()
+		Expect(*data[0].HostConfig.CPUShares).To(Equal(uint64(512)))
+		Expect(*data[0].HostConfig.PidsLimit).To(Equal(int64(100)))
+	})
+
+	It("podman update stopped container keeps limits on start", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "cat", "/sys/fs/cgroup/cpu/cpu.shares"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"update", "--cpu-shares", "256", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"start", "--attach", "test"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+		Expect(start.OutputToString()).To(Equal("256"))
+	})
+})
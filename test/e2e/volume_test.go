@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman volume", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest PodmanTest
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanCreate(tempdir)
+		podmanTest.RestoreAllArtifacts()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+	})
+
+	It("podman volume create, ls and inspect", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--label", "app=volume_test", "volume_myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("volume_myvol"))
+
+		dup := podmanTest.Podman([]string{"volume", "create", "volume_myvol"})
+		dup.WaitWithDefaultTimeout()
+		Expect(dup.ExitCode()).To(Not(Equal(0)))
+
+		other := podmanTest.Podman([]string{"volume", "create"})
+		other.WaitWithDefaultTimeout()
+		Expect(other.ExitCode()).To(Equal(0))
+
+		ls := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToStringArray()).To(ContainElement("volume_myvol"))
+		Expect(ls.OutputToStringArray()).To(ContainElement(other.OutputToString()))
+
+		filtered := podmanTest.Podman([]string{"volume", "ls", "-q", "--filter", "label=app=volume_test"})
+		filtered.WaitWithDefaultTimeout()
+		Expect(filtered.ExitCode()).To(Equal(0))
+		Expect(filtered.OutputToStringArray()).To(Equal([]string{"volume_myvol"}))
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "volume_myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		var data []map[string]interface{}
+		Expect(json.Unmarshal(inspect.Out.Contents(), &data)).To(BeNil())
+		Expect(len(data)).To(Equal(1))
+		Expect(data[0]["Name"]).To(Equal("volume_myvol"))
+		Expect(data[0]["Driver"]).To(Equal("local"))
+	})
+
+	It("podman run with a named volume", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", "volume_runvol:/etc/apk", ALPINE, "sh", "-c", "echo hello > /etc/apk/testfile"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// The volume was created, and holds both the image's contents at
+		// the destination and what the container wrote
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "volume_runvol:/data", ALPINE, "ls", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(ContainElement("testfile"))
+		Expect(session.OutputToStringArray()).To(ContainElement("repositories"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "volume_nocopyvol:/etc/apk:nocopy", ALPINE, "ls", "/etc/apk"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(""))
+	})
+
+	It("podman volume rm of a volume in use", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "volume_user", "-v", "volume_usedvol:/data", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		rm := podmanTest.Podman([]string{"volume", "rm", "volume_usedvol"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Not(Equal(0)))
+
+		prune := podmanTest.Podman([]string{"volume", "prune", "--force"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune.ExitCode()).To(Equal(0))
+		Expect(prune.OutputToStringArray()).To(Not(ContainElement("volume_usedvol")))
+
+		rm = podmanTest.Podman([]string{"volume", "rm", "--force", "volume_usedvol"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+
+		ps := podmanTest.Podman([]string{"ps", "-aq", "--filter", "name=volume_user"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(Equal(""))
+	})
+
+	It("podman volume prune", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "volume_prunevol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		prune := podmanTest.Podman([]string{"volume", "prune", "-f"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune.ExitCode()).To(Equal(0))
+		Expect(prune.OutputToStringArray()).To(ContainElement("volume_prunevol"))
+
+		ls := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToStringArray()).To(Not(ContainElement("volume_prunevol")))
+	})
+})